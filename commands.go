@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	"github.com/caner-cetin/hospital-tracker/internal/database"
+	"github.com/caner-cetin/hospital-tracker/internal/redis"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/rs/zerolog/log"
+)
+
+// runCommand runs a maintenance command or the job worker in place of the
+// server.
+func runCommand(cfg *config.Config, name string, args []string) error {
+	switch name {
+	case "worker":
+		return runWorker(cfg)
+	case "backfill-headcount":
+		return runBackfillHeadcount(cfg, args)
+	case "audit-verify":
+		return runAuditVerify(cfg)
+	case "migrate":
+		return runMigrate(cfg, args)
+	case "seed":
+		return runSeed(cfg, args)
+	default:
+		return fmt.Errorf("unknown command %q, expected worker, backfill-headcount, audit-verify, migrate or seed", name)
+	}
+}
+
+// runWorker runs scheduled and queued background jobs until it receives
+// SIGINT or SIGTERM, letting the jobs in flight finish first.
+func runWorker(cfg *config.Config) error {
+	db, err := database.Initialize(cfg.Database)
+	if err != nil {
+		return err
+	}
+	redisClient, err := redis.Initialize(cfg.Redis)
+	if err != nil {
+		return err
+	}
+	if err := services.EnableAuditLog(db); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go services.NewEventService(db, redisClient).RunRelay(ctx)
+	go services.NewDashboardService(db, redisClient).RunInvalidation(ctx)
+	services.NewJobService(db, redisClient, cfg).RunWorker(ctx)
+	log.Info().Msg("Job worker stopped")
+	return nil
+}
+
+func runBackfillHeadcount(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backfill-headcount", flag.ContinueOnError)
+	from := flags.String("from", "", "first day to reconstruct (YYYY-MM-DD)")
+	to := flags.String("to", time.Now().Format("2006-01-02"), "last day to reconstruct (YYYY-MM-DD)")
+	overwrite := flags.Bool("overwrite", false, "replace days that already have snapshots")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fromDate, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		return fmt.Errorf("-from must be a date in YYYY-MM-DD format")
+	}
+	toDate, err := time.Parse("2006-01-02", *to)
+	if err != nil {
+		return fmt.Errorf("-to must be a date in YYYY-MM-DD format")
+	}
+
+	db, err := database.Initialize(cfg.Database)
+	if err != nil {
+		return err
+	}
+
+	// noon keeps the dates on the same day in the report timezone
+	result, err := services.NewReportService(db, cfg).Backfill(fromDate.Add(12*time.Hour), toDate.Add(12*time.Hour), *overwrite)
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("from", result.From).
+		Str("to", result.To).
+		Int("days", result.Days).
+		Int64("rows", result.Rows).
+		Msg("Headcount backfill completed")
+	return nil
+}
+
+// runAuditVerify checks the audit log hash chain and fails when it is broken.
+func runAuditVerify(cfg *config.Config) error {
+	db, err := database.Initialize(cfg.Database)
+	if err != nil {
+		return err
+	}
+
+	result, err := services.NewAuditService(db).VerifyChain()
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return fmt.Errorf("audit chain broken at entry %d after %d entries: %s", *result.BrokenAt, result.Checked, result.Reason)
+	}
+
+	log.Info().Int64("entries", result.Checked).Msg("Audit chain verified")
+	return nil
+}
+
+// runMigrate applies, reverts or lists schema migrations. It only connects,
+// so it works on a database the server would refuse to start against.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected migrate up, down or status")
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	to := flags.Int64("to", 0, "last version to apply (up only, default all)")
+	steps := flags.Int("steps", 1, "number of migrations to revert (down only)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		return err
+	}
+	migrator, err := database.NewMigrator(db, database.MigrationFiles)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(*to)
+		if err != nil {
+			return err
+		}
+		log.Info().Int("applied", applied).Msg("Migrations applied")
+	case "down":
+		reverted, err := migrator.Down(*steps)
+		if err != nil {
+			return err
+		}
+		log.Info().Int("reverted", reverted).Msg("Migrations reverted")
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Modified {
+				appliedAt += " (modified since)"
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown migrate action %q, expected up, down or status", args[0])
+	}
+	return nil
+}
+
+// runSeed loads the reference datasets, all of them with -force rather than
+// only those whose file changed, and the demo hospitals with -demo.
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	demo := flags.Bool("demo", false, "also create the demo hospitals, users, clinics and staff")
+	force := flags.Bool("force", false, "reload every reference dataset, not only the changed ones")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dbConfig := cfg.Database
+	dbConfig.SeedReference = false
+	dbConfig.SeedDemo = false
+	db, err := database.Initialize(dbConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := database.SeedReference(db, *force); err != nil {
+		return err
+	}
+	if *demo {
+		if err := database.SeedDemo(db); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Msg("Seeding completed")
+	return nil
+}
@@ -11,11 +11,17 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Logging  LoggingConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	JWT         JWTConfig
+	Logging     LoggingConfig
+	Attendance  AttendanceConfig
+	Credentials CredentialConfig
+	Trash       TrashConfig
+	Webhooks    WebhookConfig
+	Jobs        JobsConfig
+	Admin       AdminConfig
 }
 
 type ServerConfig struct {
@@ -30,6 +36,13 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// AutoMigrate applies pending migrations on start; when off the
+	// server refuses to start until `migrate up` has run.
+	AutoMigrate bool
+	// SeedReference loads changed reference data files on start and
+	// SeedDemo adds the demo hospitals, for local development.
+	SeedReference bool
+	SeedDemo      bool
 }
 
 type RedisConfig struct {
@@ -44,6 +57,64 @@ type JWTConfig struct {
 	ExpireHours int
 }
 
+type AttendanceConfig struct {
+	Timezone         string
+	ShiftStart       string
+	ShiftEnd         string
+	LateGraceMinutes int
+}
+
+type CredentialConfig struct {
+	ExpiryWarningDays      int
+	BlockExpiredAssignment bool
+}
+
+// TrashConfig sets how long soft-deleted records are kept before the purge
+// job erases them. A retention of zero keeps them forever.
+type TrashConfig struct {
+	StaffRetentionDays    int
+	UserRetentionDays     int
+	ClinicRetentionDays   int
+	HospitalRetentionDays int
+}
+
+// WebhookConfig sets how often pending webhook deliveries are sent and how
+// failures are retried. The wait before a retry doubles from BackoffSeconds
+// with every attempt; after MaxAttempts the delivery becomes a dead letter.
+// Receivers on private, loopback or link-local addresses are refused unless
+// AllowPrivateTargets is set.
+type WebhookConfig struct {
+	DeliveryIntervalSeconds int
+	MaxAttempts             int
+	BackoffSeconds          int
+	TimeoutSeconds          int
+	AllowPrivateTargets     bool
+}
+
+// JobsConfig sets up the background job worker. Failed runs are retried
+// with a wait that doubles from BackoffSeconds, up to MaxAttempts. Schedules
+// are cron expressions in the attendance timezone; an empty schedule turns
+// the job off. Later headcount snapshots on the same day replace earlier
+// ones, so the default takes the last one just before midnight.
+type JobsConfig struct {
+	Concurrency                int
+	MaxAttempts                int
+	BackoffSeconds             int
+	TimeoutSeconds             int
+	PasswordResetPurgeSchedule string
+	CacheWarmSchedule          string
+	CredentialExpirySchedule   string
+	TrashPurgeSchedule         string
+	HeadcountSnapshotSchedule  string
+	WebhookDeliverySchedule    string
+}
+
+// AdminConfig holds the token for platform operator endpoints that work
+// across hospitals. They are disabled while the token is empty.
+type AdminConfig struct {
+	Token string
+}
+
 type LoggingConfig struct {
 	Level   string
 	Format  string
@@ -68,6 +139,10 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", "password"),
 			Name:     getEnv("DB_NAME", "hospital_tracker"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			AutoMigrate:   getEnvBool("DB_AUTO_MIGRATE", true),
+			SeedReference: getEnvBool("DB_SEED_REFERENCE", true),
+			SeedDemo:      getEnvBool("DB_SEED_DEMO", false),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -84,6 +159,44 @@ func Load() *Config {
 			Format:  getEnv("LOG_FORMAT", "console"),
 			Console: getEnv("LOG_CONSOLE", "true") == "true",
 		},
+		Attendance: AttendanceConfig{
+			Timezone:         getEnv("ATTENDANCE_TIMEZONE", "Europe/Istanbul"),
+			ShiftStart:       getEnv("ATTENDANCE_SHIFT_START", "08:00"),
+			ShiftEnd:         getEnv("ATTENDANCE_SHIFT_END", "17:00"),
+			LateGraceMinutes: getEnvInt("ATTENDANCE_LATE_GRACE_MINUTES", 10),
+		},
+		Credentials: CredentialConfig{
+			ExpiryWarningDays:      getEnvInt("CREDENTIAL_EXPIRY_WARNING_DAYS", 30),
+			BlockExpiredAssignment: getEnv("CREDENTIAL_BLOCK_EXPIRED_ASSIGNMENT", "false") == "true",
+		},
+		Trash: TrashConfig{
+			StaffRetentionDays:    getEnvInt("TRASH_STAFF_RETENTION_DAYS", 90),
+			UserRetentionDays:     getEnvInt("TRASH_USER_RETENTION_DAYS", 90),
+			ClinicRetentionDays:   getEnvInt("TRASH_CLINIC_RETENTION_DAYS", 90),
+			HospitalRetentionDays: getEnvInt("TRASH_HOSPITAL_RETENTION_DAYS", 365),
+		},
+		Webhooks: WebhookConfig{
+			DeliveryIntervalSeconds: getEnvInt("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 5),
+			MaxAttempts:             getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BackoffSeconds:          getEnvInt("WEBHOOK_BACKOFF_SECONDS", 30),
+			TimeoutSeconds:          getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+			AllowPrivateTargets:     getEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+		},
+		Jobs: JobsConfig{
+			Concurrency:                getEnvInt("JOB_WORKER_CONCURRENCY", 4),
+			MaxAttempts:                getEnvInt("JOB_MAX_ATTEMPTS", 5),
+			BackoffSeconds:             getEnvInt("JOB_BACKOFF_SECONDS", 30),
+			TimeoutSeconds:             getEnvInt("JOB_TIMEOUT_SECONDS", 300),
+			PasswordResetPurgeSchedule: getEnv("JOB_PASSWORD_RESET_PURGE_SCHEDULE", "*/30 * * * *"),
+			CacheWarmSchedule:          getEnv("JOB_CACHE_WARM_SCHEDULE", "0 * * * *"),
+			CredentialExpirySchedule:   getEnv("JOB_CREDENTIAL_EXPIRY_SCHEDULE", "0 6 * * *"),
+			TrashPurgeSchedule:         getEnv("JOB_TRASH_PURGE_SCHEDULE", "0 3 * * *"),
+			HeadcountSnapshotSchedule:  getEnv("JOB_HEADCOUNT_SNAPSHOT_SCHEDULE", "55 5,11,17,23 * * *"),
+			WebhookDeliverySchedule:    getEnv("JOB_WEBHOOK_DELIVERY_SCHEDULE", "* * * * *"),
+		},
+		Admin: AdminConfig{
+			Token: getEnv("ADMIN_API_TOKEN", ""),
+		},
 	}
 }
 
@@ -103,6 +216,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func InitLogger(cfg *LoggingConfig) {
 	level, err := zerolog.ParseLevel(cfg.Level)
 	if err != nil {
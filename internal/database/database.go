@@ -11,7 +11,8 @@ import (
 	"gorm.io/gorm"
 )
 
-func Initialize(cfg config.DatabaseConfig) (*gorm.DB, error) {
+// Connect opens the database without touching its schema.
+func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	log.Info().Str("host", cfg.Host).Str("database", cfg.Name).Msg("Connecting to database")
 
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
@@ -24,121 +25,89 @@ func Initialize(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	}
 
 	log.Info().Msg("Database connection established")
+	return db, nil
+}
 
-	log.Info().Msg("Running database migrations")
-	err = migrate(db)
+// Initialize connects, brings the schema up to date and seeds the reference
+// data, plus the demo data when enabled. With AutoMigrate off it only checks
+// that no migration is pending, leaving `migrate up` to the deploy.
+func Initialize(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := Connect(cfg)
 	if err != nil {
-		log.Error().Err(err).Msg("Database migration failed")
 		return nil, err
 	}
 
-	log.Info().Msg("Seeding database")
-	err = seedData(db)
+	migrator, err := NewMigrator(db, MigrationFiles)
 	if err != nil {
-		log.Error().Err(err).Msg("Database seeding failed")
 		return nil, err
 	}
 
+	if cfg.AutoMigrate {
+		log.Info().Msg("Running database migrations")
+		applied, err := migrator.Up(0)
+		if err != nil {
+			log.Error().Err(err).Msg("Database migration failed")
+			return nil, err
+		}
+		log.Info().Int("applied", applied).Msg("Database schema is up to date")
+	} else {
+		pending, err := migrator.Pending()
+		if err != nil {
+			return nil, err
+		}
+		if pending > 0 {
+			return nil, fmt.Errorf("%d database migrations are pending, run `migrate up` first", pending)
+		}
+	}
+
+	if cfg.SeedReference {
+		log.Info().Msg("Seeding reference data")
+		err = SeedReference(db, false)
+		if err != nil {
+			log.Error().Err(err).Msg("Database seeding failed")
+			return nil, err
+		}
+	}
+
+	if cfg.SeedDemo {
+		err = SeedDemo(db)
+		if err != nil {
+			log.Error().Err(err).Msg("Demo data seeding failed")
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
 
-func migrate(db *gorm.DB) error {
-	// migrate base tables without foreign key dependencies
-	err := db.AutoMigrate(
-		&models.Province{},
-		&models.District{},
-		&models.ProfessionGroup{},
-		&models.Title{},
-		&models.ClinicType{},
-		&models.PasswordReset{},
-	)
+// seedStaffingRules installs the platform default rules that used to be
+// hardcoded in the staff service. Databases created before the rule engine
+// get them on their next start.
+func seedStaffingRules(db *gorm.DB) error {
+	var chiefPhysician models.Title
+	err := db.Joins("JOIN profession_groups ON profession_groups.id = titles.profession_group_id").
+		Where("titles.name = ? AND profession_groups.name = ?", "Başhekim", "İdari Personel").
+		Where("titles.hospital_id IS NULL AND profession_groups.hospital_id IS NULL").
+		First(&chiefPhysician).Error
 	if err != nil {
-		return errors.Wrap(err, "failed to migrate base tables")
-	}
-
-	// migrate tables with dependencies
-	err = db.AutoMigrate(
-		&models.Hospital{},
-		&models.User{},
-	)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to find chief physician title")
+	}
+
+	rule := models.StaffingRule{
+		Type:        models.StaffingRuleMaxTitleCount,
+		Scope:       models.StaffingRuleScopeHospital,
+		TitleID:     &chiefPhysician.ID,
+		Threshold:   1,
+		Enabled:     true,
+		Description: "hospital can only have one chief physician (Başhekim)",
+	}
+	err = db.Where("hospital_id IS NULL AND type = ? AND title_id = ?", rule.Type, chiefPhysician.ID).
+		FirstOrCreate(&rule).Error
 	if err != nil {
-		return errors.Wrap(err, "failed to migrate dependency tables")
-	}
-
-	// migrate tables with circular dependencies
-	err = db.AutoMigrate(
-		&models.Clinic{},
-		&models.Staff{},
-	)
-	if err != nil {
-		return errors.Wrap(err, "failed to migrate circular dependency tables")
+		return errors.Wrap(err, "failed to seed staffing rules")
 	}
 	return nil
 }
-
-func seedData(db *gorm.DB) error {
-	var count int64
-
-	db.Model(&models.Province{}).Count(&count)
-	if count > 0 {
-		return nil
-	}
-
-	provinces := []models.Province{
-		{Name: "İstanbul"},
-		{Name: "Ankara"},
-		{Name: "İzmir"},
-	}
-
-	if err := db.Create(&provinces).Error; err != nil {
-		return err
-	}
-
-	districts := []models.District{
-		{Name: "Kadıköy", ProvinceID: 1},
-		{Name: "Beşiktaş", ProvinceID: 1},
-		{Name: "Çankaya", ProvinceID: 2},
-		{Name: "Keçiören", ProvinceID: 2},
-		{Name: "Konak", ProvinceID: 3},
-		{Name: "Bornova", ProvinceID: 3},
-	}
-
-	if err := db.Create(&districts).Error; err != nil {
-		return err
-	}
-
-	professionGroups := []models.ProfessionGroup{
-		{Name: "Doktor"},
-		{Name: "İdari Personel"},
-		{Name: "Hizmet Personeli"},
-	}
-
-	if err := db.Create(&professionGroups).Error; err != nil {
-		return err
-	}
-
-	titles := []models.Title{
-		{Name: "Asistan", ProfessionGroupID: 1},
-		{Name: "Uzman", ProfessionGroupID: 1},
-		{Name: "Başhekim", ProfessionGroupID: 2},
-		{Name: "Müdür", ProfessionGroupID: 2},
-		{Name: "Danışman", ProfessionGroupID: 3},
-		{Name: "Temizlik", ProfessionGroupID: 3},
-		{Name: "Güvenlik", ProfessionGroupID: 3},
-	}
-
-	if err := db.Create(&titles).Error; err != nil {
-		return err
-	}
-
-	clinicTypes := []models.ClinicType{
-		{Name: "Dahiliye"},
-		{Name: "Kardiyoloji"},
-		{Name: "Nöroloji"},
-		{Name: "Ortopedi"},
-		{Name: "Göz Hastalıkları"},
-		{Name: "Kulak Burun Boğaz"},
-	}
-
-	return db.Create(&clinicTypes).Error
-}
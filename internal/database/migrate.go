@@ -0,0 +1,319 @@
+package database
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// MigrationFiles holds the migrations shipped with the binary, named
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+var MigrationFiles, _ = fs.Sub(migrationFiles, "migrations")
+
+// migrationLockKey is the postgres advisory lock held while migrating, so
+// replicas starting together apply each migration once.
+const migrationLockKey int64 = 4_817_202_611
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// SchemaMigration records an applied migration with the checksum of the up
+// script it ran, so later edits to an applied migration are caught.
+type SchemaMigration struct {
+	Version    int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name       string    `gorm:"not null"`
+	Checksum   string    `gorm:"not null"`
+	AppliedAt  time.Time `gorm:"not null"`
+	DurationMs int64     `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Modified is set when the script changed after it was applied.
+	Modified bool
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB, files fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// LoadMigrations reads the migration pairs in files ordered by version.
+// Every version needs both an up and a down script.
+func LoadMigrations(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list migrations")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range names {
+		base := strings.TrimSuffix(path.Base(file), ".sql")
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end in .up.sql or .down.sql", file)
+		}
+		base = strings.TrimSuffix(base, "."+direction)
+
+		prefix, name, found := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !found || name == "" || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>", file)
+		}
+
+		content, err := fs.ReadFile(files, file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration %s", file)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, migration.Name, name)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies the pending migrations up to and including target, or all of
+// them when target is zero, and returns how many it applied. Each migration
+// runs in its own transaction.
+func (m *Migrator) Up(target int64) (int, error) {
+	applied := 0
+	err := m.locked(func(conn *gorm.DB) error {
+		records, err := m.verify(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if target > 0 && migration.Version > target {
+				break
+			}
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+
+			log.Info().Int64("version", migration.Version).Str("name", migration.Name).Msg("Applying migration")
+			started := time.Now()
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{
+					Version:    migration.Version,
+					Name:       migration.Name,
+					Checksum:   migration.Checksum,
+					AppliedAt:  time.Now(),
+					DurationMs: time.Since(started).Milliseconds(),
+				}).Error
+			})
+			if err != nil {
+				return errors.Wrapf(err, "migration %d_%s failed", migration.Version, migration.Name)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the latest steps applied migrations, newest first, and
+// returns how many it reverted.
+func (m *Migrator) Down(steps int) (int, error) {
+	if steps < 1 {
+		return 0, fmt.Errorf("steps must be at least 1")
+	}
+
+	reverted := 0
+	err := m.locked(func(conn *gorm.DB) error {
+		records, err := m.verify(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+
+			log.Info().Int64("version", migration.Version).Str("name", migration.Name).Msg("Reverting migration")
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return errors.Wrapf(err, "reverting migration %d_%s failed", migration.Version, migration.Name)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration with when it was applied.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.locked(func(conn *gorm.DB) error {
+		records, err := m.records(conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if record, ok := records[migration.Version]; ok {
+				appliedAt := record.AppliedAt
+				status.AppliedAt = &appliedAt
+				status.Modified = record.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Pending returns how many migrations have not been applied yet.
+func (m *Migrator) Pending() (int, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// locked runs fn on a single connection holding the migration lock. The
+// lock is session scoped, so every statement has to go through conn.
+func (m *Migrator) locked(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return errors.Wrap(err, "failed to acquire migration lock")
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				log.Error().Err(err).Msg("Failed to release migration lock")
+			}
+		}()
+
+		err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name text NOT NULL,
+			checksum text NOT NULL,
+			applied_at timestamptz NOT NULL,
+			duration_ms bigint NOT NULL
+		)`).Error
+		if err != nil {
+			return errors.Wrap(err, "failed to create schema_migrations table")
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) records(conn *gorm.DB) (map[int64]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to load applied migrations")
+	}
+
+	records := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		records[row.Version] = row
+	}
+	return records, nil
+}
+
+// verify loads the applied migrations and refuses to go on when one of them
+// was edited after it ran or is unknown to this build, which means the
+// database is ahead of the binary.
+func (m *Migrator) verify(conn *gorm.DB) (map[int64]SchemaMigration, error) {
+	records, err := m.records(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+	for version, record := range records {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("database has migration %d_%s applied, which this build does not know", version, record.Name)
+		}
+		if record.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("migration %d_%s was modified after it was applied", version, record.Name)
+		}
+	}
+	return records, nil
+}
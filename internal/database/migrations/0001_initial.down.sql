@@ -0,0 +1,37 @@
+-- Drops the whole schema. The pg_trgm extension is left installed since
+-- other database objects may depend on it.
+
+DROP TABLE IF EXISTS "job_runs" CASCADE;
+DROP TABLE IF EXISTS "notification_preferences" CASCADE;
+DROP TABLE IF EXISTS "user_notifications" CASCADE;
+DROP TABLE IF EXISTS "webhook_deliveries" CASCADE;
+DROP TABLE IF EXISTS "webhook_events" CASCADE;
+DROP TABLE IF EXISTS "webhook_subscriptions" CASCADE;
+DROP TABLE IF EXISTS "staff_versions" CASCADE;
+DROP TABLE IF EXISTS "audit_logs" CASCADE;
+DROP TABLE IF EXISTS "headcount_snapshots" CASCADE;
+DROP TABLE IF EXISTS "clinic_closures" CASCADE;
+DROP TABLE IF EXISTS "hospital_holidays" CASCADE;
+DROP TABLE IF EXISTS "clinic_opening_hours" CASCADE;
+DROP TABLE IF EXISTS "staff_status_changes" CASCADE;
+DROP TABLE IF EXISTS "staff_employments" CASCADE;
+DROP TABLE IF EXISTS "staffing_rules" CASCADE;
+DROP TABLE IF EXISTS "staff_credentials" CASCADE;
+DROP TABLE IF EXISTS "staff_import_jobs" CASCADE;
+DROP TABLE IF EXISTS "attendance_days" CASCADE;
+DROP TABLE IF EXISTS "attendance_events" CASCADE;
+DROP TABLE IF EXISTS "attendance_kiosks" CASCADE;
+DROP TABLE IF EXISTS "staff_clinic_assignments" CASCADE;
+DROP TABLE IF EXISTS "clinic_rooms" CASCADE;
+DROP TABLE IF EXISTS "staffs" CASCADE;
+DROP TABLE IF EXISTS "clinics" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
+DROP TABLE IF EXISTS "hospitals" CASCADE;
+DROP TABLE IF EXISTS "password_resets" CASCADE;
+DROP TABLE IF EXISTS "clinic_types" CASCADE;
+DROP TABLE IF EXISTS "titles" CASCADE;
+DROP TABLE IF EXISTS "profession_groups" CASCADE;
+DROP TABLE IF EXISTS "districts" CASCADE;
+DROP TABLE IF EXISTS "provinces" CASCADE;
+
+DROP FUNCTION IF EXISTS tr_fold(text);
@@ -0,0 +1,679 @@
+-- Initial schema, generated from the models as they stood when versioned
+-- migrations replaced AutoMigrate. Every statement is idempotent so databases
+-- that AutoMigrate created run it without changes and are recorded at this
+-- version. Tables those databases already have are skipped by CREATE TABLE,
+-- so the columns added to them since are added separately after each one,
+-- existing rows taking the column default.
+
+-- plain unique constraints replaced by the partial unique indexes below;
+-- gorm named them uni_<table>_<column>, older schemas <table>_<column>_key
+ALTER TABLE IF EXISTS "hospitals" DROP CONSTRAINT IF EXISTS "uni_hospitals_tax_id";
+ALTER TABLE IF EXISTS "hospitals" DROP CONSTRAINT IF EXISTS "hospitals_tax_id_key";
+ALTER TABLE IF EXISTS "hospitals" DROP CONSTRAINT IF EXISTS "uni_hospitals_email";
+ALTER TABLE IF EXISTS "hospitals" DROP CONSTRAINT IF EXISTS "hospitals_email_key";
+ALTER TABLE IF EXISTS "hospitals" DROP CONSTRAINT IF EXISTS "uni_hospitals_phone";
+ALTER TABLE IF EXISTS "hospitals" DROP CONSTRAINT IF EXISTS "hospitals_phone_key";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "uni_users_national_id";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "users_national_id_key";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "uni_users_email";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "users_email_key";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "uni_users_phone";
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "users_phone_key";
+ALTER TABLE IF EXISTS "staffs" DROP CONSTRAINT IF EXISTS "uni_staffs_national_id";
+ALTER TABLE IF EXISTS "staffs" DROP CONSTRAINT IF EXISTS "staffs_national_id_key";
+ALTER TABLE IF EXISTS "staffs" DROP CONSTRAINT IF EXISTS "uni_staffs_phone";
+ALTER TABLE IF EXISTS "staffs" DROP CONSTRAINT IF EXISTS "staffs_phone_key";
+ALTER TABLE IF EXISTS "clinic_types" DROP CONSTRAINT IF EXISTS "uni_clinic_types_name";
+ALTER TABLE IF EXISTS "clinic_types" DROP CONSTRAINT IF EXISTS "clinic_types_name_key";
+ALTER TABLE IF EXISTS "profession_groups" DROP CONSTRAINT IF EXISTS "uni_profession_groups_name";
+ALTER TABLE IF EXISTS "profession_groups" DROP CONSTRAINT IF EXISTS "profession_groups_name_key";
+
+CREATE TABLE IF NOT EXISTS "provinces" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_provinces_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "districts" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "province_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "profession_groups" (
+    "id" bigserial,
+    "hospital_id" bigint,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "profession_groups" ADD COLUMN IF NOT EXISTS "hospital_id" bigint;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_profession_groups_global_name" ON "profession_groups" ("name") WHERE hospital_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_profession_groups_hospital_name" ON "profession_groups" ("hospital_id","name") WHERE hospital_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "titles" (
+    "id" bigserial,
+    "hospital_id" bigint,
+    "name" text NOT NULL,
+    "profession_group_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "titles" ADD COLUMN IF NOT EXISTS "hospital_id" bigint;
+CREATE INDEX IF NOT EXISTS "idx_titles_hospital_id" ON "titles" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "clinic_types" (
+    "id" bigserial,
+    "hospital_id" bigint,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "clinic_types" ADD COLUMN IF NOT EXISTS "hospital_id" bigint;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_clinic_types_global_name" ON "clinic_types" ("name") WHERE hospital_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_clinic_types_hospital_name" ON "clinic_types" ("hospital_id","name") WHERE hospital_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "password_resets" (
+    "id" bigserial,
+    "phone" text NOT NULL,
+    "code" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used" boolean DEFAULT false,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "hospitals" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "tax_id" text NOT NULL,
+    "email" text NOT NULL,
+    "phone" text NOT NULL,
+    "province_id" bigint NOT NULL,
+    "district_id" bigint NOT NULL,
+    "address" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_hospitals_deleted_at" ON "hospitals" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_hospitals_phone" ON "hospitals" ("phone") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_hospitals_email" ON "hospitals" ("email") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_hospitals_tax_id" ON "hospitals" ("tax_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "national_id" text NOT NULL,
+    "email" text NOT NULL,
+    "phone" text NOT NULL,
+    "password" text NOT NULL,
+    "user_type" text NOT NULL DEFAULT 'employee',
+    "hospital_id" bigint NOT NULL,
+    "created_by_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_phone" ON "users" ("phone") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_national_id" ON "users" ("national_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "clinics" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "clinic_type_id" bigint NOT NULL,
+    "name" text,
+    "floor" text,
+    "building" text,
+    "phone_extension" text,
+    "head_staff_id" bigint,
+    "open_on_holidays" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "clinics" ADD COLUMN IF NOT EXISTS "name" text;
+ALTER TABLE "clinics" ADD COLUMN IF NOT EXISTS "floor" text;
+ALTER TABLE "clinics" ADD COLUMN IF NOT EXISTS "building" text;
+ALTER TABLE "clinics" ADD COLUMN IF NOT EXISTS "phone_extension" text;
+ALTER TABLE "clinics" ADD COLUMN IF NOT EXISTS "head_staff_id" bigint;
+ALTER TABLE "clinics" ADD COLUMN IF NOT EXISTS "open_on_holidays" boolean NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS "idx_clinics_deleted_at" ON "clinics" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_clinics_head_staff_id" ON "clinics" ("head_staff_id");
+
+CREATE TABLE IF NOT EXISTS "staffs" (
+    "id" bigserial,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "national_id" text NOT NULL,
+    "phone" text NOT NULL,
+    "profession_group_id" bigint NOT NULL,
+    "title_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "clinic_id" bigint,
+    "working_days" text,
+    "status" text NOT NULL DEFAULT 'active',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "staffs" ADD COLUMN IF NOT EXISTS "status" text NOT NULL DEFAULT 'active';
+CREATE INDEX IF NOT EXISTS "idx_staffs_deleted_at" ON "staffs" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_staffs_status" ON "staffs" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staffs_phone" ON "staffs" ("phone") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staffs_national_id" ON "staffs" ("national_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "clinic_rooms" (
+    "id" bigserial,
+    "clinic_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "kind" text NOT NULL,
+    "capacity" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_clinic_rooms_clinic_name" ON "clinic_rooms" ("clinic_id","name") WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_clinic_rooms_deleted_at" ON "clinic_rooms" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_clinic_rooms_hospital_id" ON "clinic_rooms" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "staff_clinic_assignments" (
+    "id" bigserial,
+    "staff_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "clinic_id" bigint NOT NULL,
+    "started_at" timestamptz NOT NULL,
+    "ended_at" timestamptz,
+    "reason" text,
+    "created_by_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_clinic_assignments_clinic_id" ON "staff_clinic_assignments" ("clinic_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_clinic_assignments_hospital_id" ON "staff_clinic_assignments" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_clinic_assignments_staff_id" ON "staff_clinic_assignments" ("staff_id");
+
+CREATE TABLE IF NOT EXISTS "attendance_kiosks" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "last_used_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_attendance_kiosks_token_hash" UNIQUE ("token_hash")
+);
+CREATE INDEX IF NOT EXISTS "idx_attendance_kiosks_deleted_at" ON "attendance_kiosks" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_attendance_kiosks_hospital_id" ON "attendance_kiosks" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "attendance_events" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "staff_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "source" text NOT NULL,
+    "occurred_at" timestamptz NOT NULL,
+    "kiosk_id" bigint,
+    "recorded_by_id" bigint,
+    "note" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_attendance_events_staff_time" ON "attendance_events" ("staff_id","occurred_at");
+CREATE INDEX IF NOT EXISTS "idx_attendance_events_hospital_id" ON "attendance_events" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "attendance_days" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "staff_id" bigint NOT NULL,
+    "date" date NOT NULL,
+    "scheduled" boolean,
+    "status" text NOT NULL,
+    "first_clock_in" timestamptz,
+    "last_clock_out" timestamptz,
+    "scheduled_minutes" bigint,
+    "worked_minutes" bigint,
+    "late_minutes" bigint,
+    "overtime_minutes" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_attendance_days_staff_date" ON "attendance_days" ("staff_id","date");
+CREATE INDEX IF NOT EXISTS "idx_attendance_days_hospital_id" ON "attendance_days" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "staff_import_jobs" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "created_by_id" bigint NOT NULL,
+    "file_name" text NOT NULL,
+    "dry_run" boolean,
+    "status" text NOT NULL,
+    "total_rows" bigint,
+    "processed_rows" bigint,
+    "report" jsonb,
+    "error" text,
+    "completed_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_import_jobs_hospital_id" ON "staff_import_jobs" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "staff_credentials" (
+    "id" bigserial,
+    "staff_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "name" text NOT NULL,
+    "number" text NOT NULL,
+    "issuing_body" text NOT NULL,
+    "issued_at" date NOT NULL,
+    "expires_at" date,
+    "document_ref" text,
+    "mandatory" boolean NOT NULL DEFAULT false,
+    "notified_status" text NOT NULL DEFAULT '',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_credentials_deleted_at" ON "staff_credentials" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_staff_credentials_expires_at" ON "staff_credentials" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_staff_credentials_hospital_id" ON "staff_credentials" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_credentials_staff_id" ON "staff_credentials" ("staff_id");
+
+CREATE TABLE IF NOT EXISTS "staffing_rules" (
+    "id" bigserial,
+    "hospital_id" bigint,
+    "type" text NOT NULL,
+    "scope" text NOT NULL,
+    "title_id" bigint,
+    "profession_group_id" bigint,
+    "clinic_id" bigint,
+    "threshold" bigint,
+    "enabled" boolean NOT NULL DEFAULT true,
+    "description" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staffing_rules_deleted_at" ON "staffing_rules" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_staffing_rules_hospital_id" ON "staffing_rules" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "staff_employments" (
+    "id" bigserial,
+    "staff_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "contract_type" text NOT NULL,
+    "hire_date" date NOT NULL,
+    "probation_ends_at" date,
+    "contract_ends_at" date,
+    "terminated_at" date,
+    "termination_reason" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_employments_hospital_id" ON "staff_employments" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_employments_staff_id" ON "staff_employments" ("staff_id");
+
+CREATE TABLE IF NOT EXISTS "staff_status_changes" (
+    "id" bigserial,
+    "staff_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "from_status" text NOT NULL,
+    "to_status" text NOT NULL,
+    "reason" text,
+    "effective_at" timestamptz NOT NULL,
+    "created_by_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_status_changes_hospital_id" ON "staff_status_changes" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_status_changes_staff_id" ON "staff_status_changes" ("staff_id");
+
+CREATE TABLE IF NOT EXISTS "clinic_opening_hours" (
+    "id" bigserial,
+    "clinic_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "weekday" text NOT NULL,
+    "opens_at" varchar(5) NOT NULL,
+    "closes_at" varchar(5) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_clinic_opening_hours_hospital_id" ON "clinic_opening_hours" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_clinic_opening_hours_clinic_id" ON "clinic_opening_hours" ("clinic_id");
+
+CREATE TABLE IF NOT EXISTS "hospital_holidays" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "date" date NOT NULL,
+    "name" text NOT NULL,
+    "closes_at" varchar(5) NOT NULL DEFAULT '',
+    "source" text NOT NULL DEFAULT 'manual',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_hospital_holidays_date" ON "hospital_holidays" ("hospital_id","date");
+
+CREATE TABLE IF NOT EXISTS "clinic_closures" (
+    "id" bigserial,
+    "clinic_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "reason" text NOT NULL,
+    "created_by_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_clinic_closures_hospital_id" ON "clinic_closures" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_clinic_closures_clinic_id" ON "clinic_closures" ("clinic_id");
+
+CREATE TABLE IF NOT EXISTS "headcount_snapshots" (
+    "id" bigserial,
+    "date" date NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "clinic_id" bigint,
+    "profession_group_id" bigint NOT NULL,
+    "title_id" bigint NOT NULL,
+    "count" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_headcount_snapshots_hospital_date" ON "headcount_snapshots" ("hospital_id","date");
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "hospital_id" bigint,
+    "actor_user_id" bigint,
+    "action" text NOT NULL,
+    "resource" text NOT NULL,
+    "resource_id" bigint,
+    "changes" text NOT NULL,
+    "request_id" text,
+    "ip" text,
+    "prev_hash" varchar(64) NOT NULL,
+    "hash" varchar(64) NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_request_id" ON "audit_logs" ("request_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_resource" ON "audit_logs" ("resource","resource_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor_user_id" ON "audit_logs" ("actor_user_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_hospital_id" ON "audit_logs" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_created_at" ON "audit_logs" ("created_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_audit_logs_hash" ON "audit_logs" ("hash");
+
+CREATE TABLE IF NOT EXISTS "staff_versions" (
+    "id" bigserial,
+    "staff_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "version" bigint NOT NULL,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "national_id" text NOT NULL,
+    "phone" text NOT NULL,
+    "profession_group_id" bigint NOT NULL,
+    "title_id" bigint NOT NULL,
+    "clinic_id" bigint,
+    "working_days" text,
+    "status" text NOT NULL,
+    "deleted" boolean NOT NULL DEFAULT false,
+    "valid_from" timestamptz NOT NULL,
+    "valid_to" timestamptz,
+    "changed_by_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_versions_hospital_id" ON "staff_versions" ("hospital_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staff_versions_staff_version" ON "staff_versions" ("staff_id","version");
+
+CREATE TABLE IF NOT EXISTS "webhook_subscriptions" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "url" text NOT NULL,
+    "secret" text NOT NULL,
+    "event_types" text,
+    "description" text,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_hospital_id" ON "webhook_subscriptions" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_deleted_at" ON "webhook_subscriptions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_events" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "payload" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_created_at" ON "webhook_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_hospital_id" ON "webhook_events" ("hospital_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" bigserial,
+    "event_id" bigint NOT NULL,
+    "subscription_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "status" text NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "response_status" bigint,
+    "last_error" text,
+    "delivered_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_event_id" ON "webhook_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_due" ON "webhook_deliveries" ("status","next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_hospital_id" ON "webhook_deliveries" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_subscription_id" ON "webhook_deliveries" ("subscription_id");
+
+CREATE TABLE IF NOT EXISTS "user_notifications" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "title" text NOT NULL,
+    "message" text,
+    "data" text,
+    "read_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_notifications_hospital_id" ON "user_notifications" ("hospital_id");
+CREATE INDEX IF NOT EXISTS "idx_user_notifications_inbox" ON "user_notifications" ("user_id","created_at");
+
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "in_app" boolean NOT NULL,
+    "email" boolean NOT NULL,
+    "sms" boolean NOT NULL,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_preferences_user_type" ON "notification_preferences" ("user_id","type");
+
+CREATE TABLE IF NOT EXISTS "job_runs" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "scheduled_for" timestamptz,
+    "payload" text,
+    "status" text NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "max_attempts" bigint NOT NULL,
+    "run_at" timestamptz NOT NULL,
+    "lease_until" timestamptz,
+    "worker" text,
+    "last_error" text,
+    "started_at" timestamptz,
+    "finished_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_job_runs_name" ON "job_runs" ("name");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_job_runs_schedule" ON "job_runs" ("name","scheduled_for");
+CREATE INDEX IF NOT EXISTS "idx_job_runs_status" ON "job_runs" ("status");
+
+-- foreign keys, skipped when AutoMigrate already added them
+DO $$ BEGIN
+    ALTER TABLE "districts" ADD CONSTRAINT "fk_provinces_districts" FOREIGN KEY ("province_id") REFERENCES "provinces"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "titles" ADD CONSTRAINT "fk_profession_groups_titles" FOREIGN KEY ("profession_group_id") REFERENCES "profession_groups"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "hospitals" ADD CONSTRAINT "fk_hospitals_province" FOREIGN KEY ("province_id") REFERENCES "provinces"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "hospitals" ADD CONSTRAINT "fk_hospitals_district" FOREIGN KEY ("district_id") REFERENCES "districts"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "users" ADD CONSTRAINT "fk_hospitals_users" FOREIGN KEY ("hospital_id") REFERENCES "hospitals"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "clinics" ADD CONSTRAINT "fk_hospitals_clinics" FOREIGN KEY ("hospital_id") REFERENCES "hospitals"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffs" ADD CONSTRAINT "fk_hospitals_staff" FOREIGN KEY ("hospital_id") REFERENCES "hospitals"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "users" ADD CONSTRAINT "fk_users_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffs" ADD CONSTRAINT "fk_clinics_staff" FOREIGN KEY ("clinic_id") REFERENCES "clinics"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "clinics" ADD CONSTRAINT "fk_clinics_clinic_type" FOREIGN KEY ("clinic_type_id") REFERENCES "clinic_types"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "clinic_rooms" ADD CONSTRAINT "fk_clinics_rooms" FOREIGN KEY ("clinic_id") REFERENCES "clinics"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffs" ADD CONSTRAINT "fk_staffs_profession_group" FOREIGN KEY ("profession_group_id") REFERENCES "profession_groups"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffs" ADD CONSTRAINT "fk_staffs_title" FOREIGN KEY ("title_id") REFERENCES "titles"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staff_clinic_assignments" ADD CONSTRAINT "fk_staff_clinic_assignments_clinic" FOREIGN KEY ("clinic_id") REFERENCES "clinics"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staff_credentials" ADD CONSTRAINT "fk_staff_credentials_staff" FOREIGN KEY ("staff_id") REFERENCES "staffs"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffing_rules" ADD CONSTRAINT "fk_staffing_rules_title" FOREIGN KEY ("title_id") REFERENCES "titles"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffing_rules" ADD CONSTRAINT "fk_staffing_rules_profession_group" FOREIGN KEY ("profession_group_id") REFERENCES "profession_groups"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "staffing_rules" ADD CONSTRAINT "fk_staffing_rules_clinic" FOREIGN KEY ("clinic_id") REFERENCES "clinics"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$ BEGIN
+    ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "fk_webhook_deliveries_event" FOREIGN KEY ("event_id") REFERENCES "webhook_events"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+-- name search: tr_fold maps Turkish letters to their ASCII base before
+-- lowering, so "İ", "I", "ı" and "i" all fold to "i" regardless of the
+-- database locale
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION tr_fold(value text) RETURNS text
+    LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+    AS $fn$ SELECT lower(translate(value, 'ÇĞİIÖŞÜÂÎÛçğıöşüâîû', 'cgiiosuaiucgiosuaiu')) $fn$;
+
+CREATE INDEX IF NOT EXISTS "idx_staffs_name_search" ON "staffs"
+    USING gin (tr_fold(first_name || ' ' || last_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_users_name_search" ON "users"
+    USING gin (tr_fold(first_name || ' ' || last_name) gin_trgm_ops);
+
+-- open an assignment for staff placed in a clinic before assignment history
+-- was recorded
+INSERT INTO staff_clinic_assignments (staff_id, hospital_id, clinic_id, started_at, reason, created_at, updated_at)
+SELECT s.id, s.hospital_id, s.clinic_id, s.created_at, 'backfilled from staff record', NOW(), NOW()
+FROM staffs s
+WHERE s.clinic_id IS NOT NULL AND s.deleted_at IS NULL
+AND NOT EXISTS (SELECT 1 FROM staff_clinic_assignments a WHERE a.staff_id = s.id);
+
+-- open a first version for staff created before version history was recorded
+INSERT INTO staff_versions (staff_id, hospital_id, version, first_name, last_name, national_id, phone,
+    profession_group_id, title_id, clinic_id, working_days, status, deleted, valid_from, created_at)
+SELECT s.id, s.hospital_id, 1, s.first_name, s.last_name, s.national_id, s.phone,
+    s.profession_group_id, s.title_id, s.clinic_id, s.working_days, s.status, s.deleted_at IS NOT NULL, s.created_at, NOW()
+FROM staffs s
+WHERE NOT EXISTS (SELECT 1 FROM staff_versions v WHERE v.staff_id = s.id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "seed_versions";
+DROP INDEX IF EXISTS "idx_titles_global_name";
+DROP INDEX IF EXISTS "idx_districts_province_name";
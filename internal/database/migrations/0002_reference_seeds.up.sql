@@ -0,0 +1,14 @@
+-- Natural keys the reference data seeder upserts on, and the versions of
+-- the seed files it has applied.
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_districts_province_name" ON "districts" ("province_id","name");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_titles_global_name" ON "titles" ("profession_group_id","name") WHERE hospital_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS "seed_versions" (
+    "dataset" text,
+    "version" bigint NOT NULL,
+    "checksum" text NOT NULL,
+    "applied_at" timestamptz NOT NULL,
+    PRIMARY KEY ("dataset")
+);
+
@@ -0,0 +1,11 @@
+ALTER TABLE "hospitals" DROP CONSTRAINT IF EXISTS "fk_hospitals_neighbourhood";
+ALTER TABLE "hospitals" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "hospitals" DROP COLUMN IF EXISTS "latitude";
+ALTER TABLE "hospitals" DROP COLUMN IF EXISTS "neighbourhood_id";
+
+DROP TABLE IF EXISTS "neighbourhoods";
+
+DROP INDEX IF EXISTS "idx_provinces_plate_code";
+ALTER TABLE "provinces" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "provinces" DROP COLUMN IF EXISTS "latitude";
+ALTER TABLE "provinces" DROP COLUMN IF EXISTS "plate_code";
@@ -0,0 +1,29 @@
+-- Plate codes and centre coordinates for provinces, neighbourhoods
+-- (mahalle) under districts, and an optional neighbourhood and position
+-- for hospitals. Existing provinces get their plate code when the
+-- locations seed is next loaded.
+
+ALTER TABLE "provinces" ADD COLUMN IF NOT EXISTS "plate_code" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "provinces" ADD COLUMN IF NOT EXISTS "latitude" double precision;
+ALTER TABLE "provinces" ADD COLUMN IF NOT EXISTS "longitude" double precision;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_provinces_plate_code" ON "provinces" ("plate_code") WHERE plate_code <> 0;
+
+CREATE TABLE IF NOT EXISTS "neighbourhoods" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "district_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_districts_neighbourhoods" FOREIGN KEY ("district_id") REFERENCES "districts"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_neighbourhoods_district_name" ON "neighbourhoods" ("district_id","name");
+
+ALTER TABLE "hospitals" ADD COLUMN IF NOT EXISTS "neighbourhood_id" bigint;
+ALTER TABLE "hospitals" ADD COLUMN IF NOT EXISTS "latitude" double precision;
+ALTER TABLE "hospitals" ADD COLUMN IF NOT EXISTS "longitude" double precision;
+
+DO $$ BEGIN
+    ALTER TABLE "hospitals" ADD CONSTRAINT "fk_hospitals_neighbourhood" FOREIGN KEY ("neighbourhood_id") REFERENCES "neighbourhoods"("id");
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
@@ -0,0 +1 @@
+ALTER TABLE "staff_import_jobs" DROP COLUMN IF EXISTS "file";
@@ -0,0 +1,5 @@
+-- Large staff imports run on the job queue, which may pick them up in
+-- another process, so the uploaded file is kept with the import job until
+-- it has run.
+
+ALTER TABLE "staff_import_jobs" ADD COLUMN IF NOT EXISTS "file" bytea;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "idx_staff_clinic_assignments_open";
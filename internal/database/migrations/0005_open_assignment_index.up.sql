@@ -0,0 +1,17 @@
+-- A staff member holds at most one open clinic assignment. Transfers that
+-- raced before the staff row was locked may have left several open, so all
+-- but the latest are closed where the latest one starts.
+
+UPDATE "staff_clinic_assignments" a
+SET "ended_at" = latest."started_at"
+FROM (
+    SELECT DISTINCT ON ("staff_id") "id", "staff_id", "started_at"
+    FROM "staff_clinic_assignments"
+    WHERE "ended_at" IS NULL
+    ORDER BY "staff_id", "started_at" DESC, "id" DESC
+) latest
+WHERE a."staff_id" = latest."staff_id"
+  AND a."ended_at" IS NULL
+  AND a."id" <> latest."id";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staff_clinic_assignments_open" ON "staff_clinic_assignments" ("staff_id") WHERE ended_at IS NULL;
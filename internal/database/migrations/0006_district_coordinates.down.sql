@@ -0,0 +1,2 @@
+ALTER TABLE "districts" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "districts" DROP COLUMN IF EXISTS "latitude";
@@ -0,0 +1,5 @@
+-- Centre coordinates for districts, loaded from the locations seed when it
+-- carries them.
+
+ALTER TABLE "districts" ADD COLUMN IF NOT EXISTS "latitude" double precision;
+ALTER TABLE "districts" ADD COLUMN IF NOT EXISTS "longitude" double precision;
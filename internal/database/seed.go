@@ -0,0 +1,491 @@
+package database
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:embed seeds/*.json
+var seedFiles embed.FS
+
+// SeedVersion records the version and checksum of the seed file a dataset
+// was last loaded from, so unchanged datasets are skipped on start.
+type SeedVersion struct {
+	Dataset   string    `gorm:"primaryKey"`
+	Version   int       `gorm:"not null"`
+	Checksum  string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// referenceDataset is a seed file and the function that upserts its
+// contents. Datasets are loaded in order, since titles need their
+// profession groups, districts their provinces and neighbourhoods their
+// districts.
+type referenceDataset struct {
+	name  string
+	file  string
+	apply func(tx *gorm.DB, data []byte) error
+}
+
+var referenceDatasets = []referenceDataset{
+	{name: "locations", file: "seeds/locations.json", apply: seedLocations},
+	{name: "neighbourhoods", file: "seeds/neighbourhoods.json", apply: seedNeighbourhoods},
+	{name: "profession_groups", file: "seeds/profession_groups.json", apply: seedProfessionGroups},
+	{name: "clinic_types", file: "seeds/clinic_types.json", apply: seedClinicTypes},
+}
+
+// globalCatalogue targets the partial unique indexes over the global
+// catalogue entries, leaving hospitals' private additions alone.
+var globalCatalogue = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "hospital_id IS NULL"}}}
+
+// SeedReference upserts the reference datasets whose embedded file changed
+// since they were last loaded, or all of them when force is set, then the
+// default staffing rules. Rows are matched by natural key and never deleted,
+// since hospitals may still reference entries a newer file dropped.
+func SeedReference(db *gorm.DB, force bool) error {
+	for _, dataset := range referenceDatasets {
+		data, err := seedFiles.ReadFile(dataset.file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s seed", dataset.name)
+		}
+		version, err := seedFileVersion(data)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s seed", dataset.name)
+		}
+		sum := sha256.Sum256(data)
+		checksum := hex.EncodeToString(sum[:])
+
+		var recorded SeedVersion
+		err = db.Where("dataset = ?", dataset.name).Limit(1).Find(&recorded).Error
+		if err != nil {
+			return errors.Wrap(err, "failed to load seed versions")
+		}
+		if !force && recorded.Dataset != "" {
+			if recorded.Version == version && recorded.Checksum == checksum {
+				continue
+			}
+			if recorded.Version > version {
+				log.Warn().Str("dataset", dataset.name).Int("applied", recorded.Version).Int("embedded", version).
+					Msg("Database holds a newer seed than this build, skipping")
+				continue
+			}
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := dataset.apply(tx, data); err != nil {
+				return err
+			}
+			return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&SeedVersion{
+				Dataset:   dataset.name,
+				Version:   version,
+				Checksum:  checksum,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to seed %s", dataset.name)
+		}
+		log.Info().Str("dataset", dataset.name).Int("version", version).Msg("Reference data seeded")
+	}
+	return seedStaffingRules(db)
+}
+
+func seedFileVersion(data []byte) (int, error) {
+	var file struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, err
+	}
+	if file.Version < 1 {
+		return 0, fmt.Errorf("version must be at least 1")
+	}
+	return file.Version, nil
+}
+
+type locationSeed struct {
+	Provinces []struct {
+		PlateCode int                    `json:"plate_code"`
+		Name      string                 `json:"name"`
+		Latitude  *float64               `json:"latitude"`
+		Longitude *float64               `json:"longitude"`
+		Districts []districtLocationSeed `json:"districts"`
+	} `json:"provinces"`
+}
+
+// districtLocationSeed is a district of the locations file, given either by
+// name alone or as an object with its centre coordinates.
+type districtLocationSeed struct {
+	Name      string   `json:"name"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+func (d *districtLocationSeed) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &d.Name)
+	}
+	type plain districtLocationSeed
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+func seedLocations(tx *gorm.DB, data []byte) error {
+	var file locationSeed
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+
+	for _, entry := range file.Provinces {
+		province := models.Province{
+			PlateCode: entry.PlateCode,
+			Name:      entry.Name,
+			Latitude:  entry.Latitude,
+			Longitude: entry.Longitude,
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"plate_code", "latitude", "longitude", "updated_at"}),
+		}).Create(&province).Error
+		if err != nil {
+			return err
+		}
+		if len(entry.Districts) == 0 {
+			continue
+		}
+
+		districts := make([]models.District, 0, len(entry.Districts))
+		for _, district := range entry.Districts {
+			districts = append(districts, models.District{
+				Name:       district.Name,
+				ProvinceID: province.ID,
+				Latitude:   district.Latitude,
+				Longitude:  district.Longitude,
+			})
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "province_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"latitude", "longitude", "updated_at"}),
+		}).Create(&districts).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type neighbourhoodSeed struct {
+	Districts []struct {
+		Province       string   `json:"province"`
+		District       string   `json:"district"`
+		Neighbourhoods []string `json:"neighbourhoods"`
+	} `json:"districts"`
+}
+
+func seedNeighbourhoods(tx *gorm.DB, data []byte) error {
+	var file neighbourhoodSeed
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+
+	for _, entry := range file.Districts {
+		var district models.District
+		err := tx.Joins("JOIN provinces ON provinces.id = districts.province_id").
+			Where("provinces.name = ? AND districts.name = ?", entry.Province, entry.District).
+			First(&district).Error
+		if err != nil {
+			return errors.Wrapf(err, "district %s/%s", entry.Province, entry.District)
+		}
+		if len(entry.Neighbourhoods) == 0 {
+			continue
+		}
+
+		neighbourhoods := make([]models.Neighbourhood, 0, len(entry.Neighbourhoods))
+		for _, name := range entry.Neighbourhoods {
+			neighbourhoods = append(neighbourhoods, models.Neighbourhood{Name: name, DistrictID: district.ID})
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "district_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		}).Create(&neighbourhoods).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type professionGroupSeed struct {
+	ProfessionGroups []struct {
+		Name   string   `json:"name"`
+		Titles []string `json:"titles"`
+	} `json:"profession_groups"`
+}
+
+func seedProfessionGroups(tx *gorm.DB, data []byte) error {
+	var file professionGroupSeed
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+
+	for _, entry := range file.ProfessionGroups {
+		group := models.ProfessionGroup{Name: entry.Name}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "name"}},
+			TargetWhere: globalCatalogue,
+			DoUpdates:   clause.AssignmentColumns([]string{"updated_at"}),
+		}).Create(&group).Error
+		if err != nil {
+			return err
+		}
+		if len(entry.Titles) == 0 {
+			continue
+		}
+
+		titles := make([]models.Title, 0, len(entry.Titles))
+		for _, name := range entry.Titles {
+			titles = append(titles, models.Title{Name: name, ProfessionGroupID: group.ID})
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "profession_group_id"}, {Name: "name"}},
+			TargetWhere: globalCatalogue,
+			DoUpdates:   clause.AssignmentColumns([]string{"updated_at"}),
+		}).Create(&titles).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seedClinicTypes(tx *gorm.DB, data []byte) error {
+	var file struct {
+		ClinicTypes []string `json:"clinic_types"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if len(file.ClinicTypes) == 0 {
+		return nil
+	}
+
+	clinicTypes := make([]models.ClinicType, 0, len(file.ClinicTypes))
+	for _, name := range file.ClinicTypes {
+		clinicTypes = append(clinicTypes, models.ClinicType{Name: name})
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "name"}},
+		TargetWhere: globalCatalogue,
+		DoUpdates:   clause.AssignmentColumns([]string{"updated_at"}),
+	}).Create(&clinicTypes).Error
+}
+
+type demoStaffSeed struct {
+	FirstName       string              `json:"first_name"`
+	LastName        string              `json:"last_name"`
+	NationalID      string              `json:"national_id"`
+	Phone           string              `json:"phone"`
+	ProfessionGroup string              `json:"profession_group"`
+	Title           string              `json:"title"`
+	WorkingDays     []models.WorkingDay `json:"working_days"`
+}
+
+type demoSeed struct {
+	Hospitals []struct {
+		Name     string `json:"name"`
+		TaxID    string `json:"tax_id"`
+		Email    string `json:"email"`
+		Phone    string `json:"phone"`
+		Province string `json:"province"`
+		District string `json:"district"`
+		Address  string `json:"address"`
+		Users    []struct {
+			FirstName  string          `json:"first_name"`
+			LastName   string          `json:"last_name"`
+			NationalID string          `json:"national_id"`
+			Email      string          `json:"email"`
+			Phone      string          `json:"phone"`
+			Password   string          `json:"password"`
+			UserType   models.UserType `json:"user_type"`
+		} `json:"users"`
+		Clinics []struct {
+			ClinicType string          `json:"clinic_type"`
+			Floor      string          `json:"floor"`
+			Building   string          `json:"building"`
+			Staff      []demoStaffSeed `json:"staff"`
+		} `json:"clinics"`
+		Staff []demoStaffSeed `json:"staff"`
+	} `json:"hospitals"`
+}
+
+// SeedDemo creates the demo hospitals from seeds/demo.json with their users,
+// clinics and staff, for local development. Records that already exist,
+// matched by tax ID, email, clinic type or national ID, are left as they are,
+// so running it again does not undo changes made through the API.
+func SeedDemo(db *gorm.DB) error {
+	data, err := seedFiles.ReadFile("seeds/demo.json")
+	if err != nil {
+		return errors.Wrap(err, "failed to read demo seed")
+	}
+	var file demoSeed
+	if err := json.Unmarshal(data, &file); err != nil {
+		return errors.Wrap(err, "invalid demo seed")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range file.Hospitals {
+			var district models.District
+			err := tx.Joins("JOIN provinces ON provinces.id = districts.province_id").
+				Where("provinces.name = ? AND districts.name = ?", entry.Province, entry.District).
+				First(&district).Error
+			if err != nil {
+				return errors.Wrapf(err, "demo hospital %s: district %s/%s", entry.Name, entry.Province, entry.District)
+			}
+
+			hospital := models.Hospital{
+				Name:       entry.Name,
+				TaxID:      entry.TaxID,
+				Email:      entry.Email,
+				Phone:      entry.Phone,
+				ProvinceID: district.ProvinceID,
+				DistrictID: district.ID,
+				Address:    entry.Address,
+			}
+			if err := tx.Where("tax_id = ?", entry.TaxID).FirstOrCreate(&hospital).Error; err != nil {
+				return errors.Wrapf(err, "demo hospital %s", entry.Name)
+			}
+
+			for _, seed := range entry.Users {
+				password, err := bcrypt.GenerateFromPassword([]byte(seed.Password), bcrypt.DefaultCost)
+				if err != nil {
+					return errors.Wrap(err, "failed to hash demo password")
+				}
+				user := models.User{
+					FirstName:  seed.FirstName,
+					LastName:   seed.LastName,
+					NationalID: seed.NationalID,
+					Email:      seed.Email,
+					Phone:      seed.Phone,
+					Password:   string(password),
+					UserType:   seed.UserType,
+					HospitalID: hospital.ID,
+				}
+				if err := tx.Where("email = ?", seed.Email).FirstOrCreate(&user).Error; err != nil {
+					return errors.Wrapf(err, "demo user %s", seed.Email)
+				}
+			}
+
+			for _, seed := range entry.Clinics {
+				var clinicType models.ClinicType
+				err := tx.Where("name = ? AND hospital_id IS NULL", seed.ClinicType).First(&clinicType).Error
+				if err != nil {
+					return errors.Wrapf(err, "demo clinic type %s", seed.ClinicType)
+				}
+				clinic := models.Clinic{
+					HospitalID:   hospital.ID,
+					ClinicTypeID: clinicType.ID,
+					Name:         clinicType.Name,
+					Floor:        seed.Floor,
+					Building:     seed.Building,
+				}
+				err = tx.Where("hospital_id = ? AND clinic_type_id = ?", hospital.ID, clinicType.ID).FirstOrCreate(&clinic).Error
+				if err != nil {
+					return errors.Wrapf(err, "demo clinic %s", seed.ClinicType)
+				}
+				for _, staff := range seed.Staff {
+					if err := seedDemoStaff(tx, hospital.ID, &clinic.ID, staff); err != nil {
+						return err
+					}
+				}
+			}
+
+			for _, staff := range entry.Staff {
+				if err := seedDemoStaff(tx, hospital.ID, nil, staff); err != nil {
+					return err
+				}
+			}
+			log.Info().Str("hospital", hospital.Name).Msg("Demo hospital seeded")
+		}
+		return nil
+	})
+}
+
+// seedDemoStaff creates a staff member with the first version and clinic
+// assignment the staff service would have recorded.
+func seedDemoStaff(tx *gorm.DB, hospitalID uint, clinicID *uint, seed demoStaffSeed) error {
+	var title models.Title
+	err := tx.Joins("JOIN profession_groups ON profession_groups.id = titles.profession_group_id").
+		Where("titles.name = ? AND profession_groups.name = ?", seed.Title, seed.ProfessionGroup).
+		Where("titles.hospital_id IS NULL AND profession_groups.hospital_id IS NULL").
+		First(&title).Error
+	if err != nil {
+		return errors.Wrapf(err, "demo staff %s: title %s/%s", seed.NationalID, seed.ProfessionGroup, seed.Title)
+	}
+	workingDays, err := json.Marshal(seed.WorkingDays)
+	if err != nil {
+		return err
+	}
+
+	staff := models.Staff{
+		FirstName:         seed.FirstName,
+		LastName:          seed.LastName,
+		NationalID:        seed.NationalID,
+		Phone:             seed.Phone,
+		ProfessionGroupID: title.ProfessionGroupID,
+		TitleID:           title.ID,
+		HospitalID:        hospitalID,
+		ClinicID:          clinicID,
+		WorkingDays:       string(workingDays),
+		Status:            models.StaffActive,
+	}
+	result := tx.Where("national_id = ?", seed.NationalID).FirstOrCreate(&staff)
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, "demo staff %s", seed.NationalID)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	version := models.StaffVersion{
+		StaffID:           staff.ID,
+		HospitalID:        hospitalID,
+		Version:           1,
+		FirstName:         staff.FirstName,
+		LastName:          staff.LastName,
+		NationalID:        staff.NationalID,
+		Phone:             staff.Phone,
+		ProfessionGroupID: staff.ProfessionGroupID,
+		TitleID:           staff.TitleID,
+		ClinicID:          clinicID,
+		WorkingDays:       staff.WorkingDays,
+		Status:            staff.Status,
+		ValidFrom:         staff.CreatedAt,
+	}
+	if err := tx.Create(&version).Error; err != nil {
+		return errors.Wrapf(err, "demo staff %s version", seed.NationalID)
+	}
+	if clinicID == nil {
+		return nil
+	}
+	assignment := models.StaffClinicAssignment{
+		StaffID:    staff.ID,
+		HospitalID: hospitalID,
+		ClinicID:   *clinicID,
+		StartedAt:  staff.CreatedAt,
+		Reason:     "demo data",
+	}
+	if err := tx.Create(&assignment).Error; err != nil {
+		return errors.Wrapf(err, "demo staff %s clinic assignment", seed.NationalID)
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const kioskTokenHeader = "X-Kiosk-Token"
+
+type AttendanceHandler struct {
+	attendanceService *services.AttendanceService
+}
+
+func NewAttendanceHandler(attendanceService *services.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{
+		attendanceService: attendanceService,
+	}
+}
+
+// ClockIn godoc
+// @Summary Clock in a staff member
+// @Description Record a clock-in event for a staff member of the hospital
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.ClockRequest true "Clock-in data"
+// @Success 201 {object} models.AttendanceEvent "Clock-in recorded"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /attendance/clock-in [post]
+func (h *AttendanceHandler) ClockIn(c *gin.Context) {
+	var req models.ClockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	event, err := h.attendanceService.WithContext(requestContext(c)).ClockIn(&req, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event":   event,
+		"message": "Clock-in recorded successfully",
+	})
+}
+
+// ClockOut godoc
+// @Summary Clock out a staff member
+// @Description Record a clock-out event for a staff member of the hospital
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.ClockRequest true "Clock-out data"
+// @Success 201 {object} models.AttendanceEvent "Clock-out recorded"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /attendance/clock-out [post]
+func (h *AttendanceHandler) ClockOut(c *gin.Context) {
+	var req models.ClockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	event, err := h.attendanceService.WithContext(requestContext(c)).ClockOut(&req, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event":   event,
+		"message": "Clock-out recorded successfully",
+	})
+}
+
+// KioskClockIn godoc
+// @Summary Clock in from a kiosk
+// @Description Record a clock-in event from a registered kiosk identified by its token
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param X-Kiosk-Token header string true "Kiosk token"
+// @Param request body models.KioskClockRequest true "Staff identification"
+// @Success 201 {object} models.AttendanceEvent "Clock-in recorded"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Invalid kiosk token"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /attendance/kiosk/clock-in [post]
+func (h *AttendanceHandler) KioskClockIn(c *gin.Context) {
+	h.kioskClock(c, models.AttendanceClockIn)
+}
+
+// KioskClockOut godoc
+// @Summary Clock out from a kiosk
+// @Description Record a clock-out event from a registered kiosk identified by its token
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param X-Kiosk-Token header string true "Kiosk token"
+// @Param request body models.KioskClockRequest true "Staff identification"
+// @Success 201 {object} models.AttendanceEvent "Clock-out recorded"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Invalid kiosk token"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /attendance/kiosk/clock-out [post]
+func (h *AttendanceHandler) KioskClockOut(c *gin.Context) {
+	h.kioskClock(c, models.AttendanceClockOut)
+}
+
+func (h *AttendanceHandler) kioskClock(c *gin.Context, eventType models.AttendanceEventType) {
+	token := c.GetHeader(kioskTokenHeader)
+	if token == "" {
+		errors.HandleAppError(c, errors.NewInvalidTokenError())
+		return
+	}
+
+	var req models.KioskClockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	event, err := h.attendanceService.WithContext(requestContext(c)).KioskClock(token, &req, eventType)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event":   event,
+		"message": "Attendance recorded successfully",
+	})
+}
+
+// CorrectAttendance godoc
+// @Summary Record an attendance correction
+// @Description Record a back-dated clock event on behalf of a staff member (requires authorization)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.AttendanceCorrectionRequest true "Correction data"
+// @Success 201 {object} models.AttendanceEvent "Correction recorded"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /attendance/corrections [post]
+func (h *AttendanceHandler) CorrectAttendance(c *gin.Context) {
+	var req models.AttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	event, err := h.attendanceService.WithContext(requestContext(c)).CorrectAttendance(&req, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event":   event,
+		"message": "Attendance correction recorded successfully",
+	})
+}
+
+// GetEvents godoc
+// @Summary Get attendance events
+// @Description Get clock events of the hospital, optionally filtered by staff member and date range
+// @Tags Attendance
+// @Produce json
+// @Security Bearer
+// @Param staff_id query int false "Filter by staff ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date, inclusive (YYYY-MM-DD)"
+// @Success 200 {array} models.AttendanceEvent "List of attendance events"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /attendance/events [get]
+func (h *AttendanceHandler) GetEvents(c *gin.Context) {
+	var filter models.AttendanceEventFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	events, err := h.attendanceService.GetEvents(&filter, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+	})
+}
+
+// ReconcileDay godoc
+// @Summary Reconcile attendance for a day
+// @Description Compare clock events against scheduled working days and store lateness, absence and overtime flags (requires authorization)
+// @Tags Attendance
+// @Produce json
+// @Security Bearer
+// @Param date query string true "Date to reconcile (YYYY-MM-DD)"
+// @Success 200 {array} models.AttendanceDay "Reconciled attendance days"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /attendance/reconcile [post]
+func (h *AttendanceHandler) ReconcileDay(c *gin.Context) {
+	days, err := h.attendanceService.WithContext(requestContext(c)).ReconcileDay(c.GetUint("hospital_id"), c.Query("date"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"days": days,
+	})
+}
+
+// GetDailyAttendance godoc
+// @Summary Get reconciled attendance for a day
+// @Description Get the stored reconciliation results of a day
+// @Tags Attendance
+// @Produce json
+// @Security Bearer
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Success 200 {array} models.AttendanceDay "Reconciled attendance days"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /attendance/daily [get]
+func (h *AttendanceHandler) GetDailyAttendance(c *gin.Context) {
+	days, err := h.attendanceService.GetDailyAttendance(c.GetUint("hospital_id"), c.Query("date"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"days": days,
+	})
+}
+
+// GetTimesheet godoc
+// @Summary Get monthly timesheet
+// @Description Get worked, late and overtime totals of every staff member for a month
+// @Tags Attendance
+// @Produce json
+// @Security Bearer
+// @Param month query string true "Month (YYYY-MM)"
+// @Success 200 {object} models.TimesheetResponse "Monthly timesheet"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /attendance/timesheet [get]
+func (h *AttendanceHandler) GetTimesheet(c *gin.Context) {
+	timesheet, err := h.attendanceService.GetTimesheet(c.GetUint("hospital_id"), c.Query("month"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheet)
+}
+
+// ExportTimesheet godoc
+// @Summary Export monthly timesheet as CSV
+// @Description Export the monthly timesheet of the hospital as CSV for payroll (requires authorization)
+// @Tags Attendance
+// @Produce text/csv
+// @Security Bearer
+// @Param month query string true "Month (YYYY-MM)"
+// @Success 200 {file} file "Timesheet CSV"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /attendance/timesheet/export [get]
+func (h *AttendanceHandler) ExportTimesheet(c *gin.Context) {
+	timesheet, err := h.attendanceService.GetTimesheet(c.GetUint("hospital_id"), c.Query("month"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.attendanceService.WriteTimesheetCSV(&buf, timesheet); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=timesheet-"+timesheet.Month+".csv")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// CreateKiosk godoc
+// @Summary Register an attendance kiosk
+// @Description Register a kiosk and return its token; the token is only shown once (requires authorization)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.CreateKioskRequest true "Kiosk data"
+// @Success 201 {object} models.CreateKioskResponse "Kiosk registered"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /attendance/kiosks [post]
+func (h *AttendanceHandler) CreateKiosk(c *gin.Context) {
+	var req models.CreateKioskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	response, err := h.attendanceService.WithContext(requestContext(c)).CreateKiosk(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetKiosks godoc
+// @Summary Get attendance kiosks
+// @Description Get the registered attendance kiosks of the hospital (requires authorization)
+// @Tags Attendance
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.AttendanceKiosk "List of kiosks"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /attendance/kiosks [get]
+func (h *AttendanceHandler) GetKiosks(c *gin.Context) {
+	kiosks, err := h.attendanceService.GetKiosks(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"kiosks": kiosks,
+	})
+}
+
+// DeleteKiosk godoc
+// @Summary Revoke an attendance kiosk
+// @Description Revoke a kiosk so its token can no longer record attendance (requires authorization)
+// @Tags Attendance
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Kiosk ID"
+// @Success 200 {object} map[string]string "Kiosk revoked successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Kiosk not found"
+// @Router /attendance/kiosks/{id} [delete]
+func (h *AttendanceHandler) DeleteKiosk(c *gin.Context) {
+	kioskID, ok := parseUintParam(c, "id", "invalid kiosk ID")
+	if !ok {
+		return
+	}
+
+	if err := h.attendanceService.WithContext(requestContext(c)).DeleteKiosk(kioskID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Kiosk revoked successfully",
+	})
+}
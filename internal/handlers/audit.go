@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAuditLogs godoc
+// @Summary List audit log entries
+// @Description List who created, updated or deleted the hospital's records, newest first, with the changed values before and after, the request ID and the client IP. Entries are hash-chained to detect tampering (requires authorization)
+// @Tags Audit
+// @Produce json
+// @Security Bearer
+// @Param actor_user_id query int false "User who made the change"
+// @Param action query string false "Action" Enums(create, update, delete, bulk_write)
+// @Param resource query string false "Table name, e.g. staffs"
+// @Param resource_id query int false "Record ID"
+// @Param request_id query string false "Request ID from the X-Request-ID header"
+// @Param from query string false "On or after (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "On or before (YYYY-MM-DD or RFC 3339)"
+// @Param page query int false "Page number for pagination"
+// @Param limit query int false "Number of items per page (default 50, max 200)"
+// @Success 200 {object} models.AuditLogPaginatedResponse "Audit log entries"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	var filter models.AuditFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	entries, err := h.auditService.GetAuditLogs(&filter, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
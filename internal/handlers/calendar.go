@@ -0,0 +1,390 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetOpeningHours godoc
+// @Summary Get clinic opening hours
+// @Description Get the weekly opening periods of a clinic in hospital local time and whether it stays open on holidays
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Success 200 {object} models.OpeningHoursResponse "Opening hours"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/hours [get]
+func (h *CalendarHandler) GetOpeningHours(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	hours, err := h.calendarService.GetOpeningHours(clinicID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// SetOpeningHours godoc
+// @Summary Replace clinic opening hours
+// @Description Replace the weekly schedule of a clinic. Each period has a weekday and HH:MM opening and closing times; "24:00" closes at midnight. Weekdays without periods are closed, and periods of the same day must not overlap (requires authorization)
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param request body models.OpeningHoursRequest true "Weekly schedule"
+// @Success 200 {object} models.OpeningHoursResponse "Opening hours updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/hours [put]
+func (h *CalendarHandler) SetOpeningHours(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var req models.OpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	hours, err := h.calendarService.WithContext(requestContext(c)).SetOpeningHours(clinicID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hours":   hours,
+		"message": "Opening hours updated successfully",
+	})
+}
+
+// GetClosures godoc
+// @Summary List clinic closures
+// @Description List the ad-hoc closures of a clinic, latest first
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Success 200 {array} models.ClinicClosure "Closures"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/closures [get]
+func (h *CalendarHandler) GetClosures(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	closures, err := h.calendarService.GetClosures(clinicID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"closures": closures,
+	})
+}
+
+// CreateClosure godoc
+// @Summary Close a clinic temporarily
+// @Description Record an ad-hoc closure of a clinic between two instants, e.g. for renovation. Closures override opening hours and holidays (requires authorization)
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param request body models.ClinicClosureRequest true "Closure"
+// @Success 201 {object} models.ClinicClosure "Closure created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/closures [post]
+func (h *CalendarHandler) CreateClosure(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var req models.ClinicClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	closure, err := h.calendarService.WithContext(requestContext(c)).CreateClosure(clinicID, &req, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"closure": closure,
+		"message": "Closure created successfully",
+	})
+}
+
+// DeleteClosure godoc
+// @Summary Delete a clinic closure
+// @Description Remove an ad-hoc closure of a clinic (requires authorization)
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param closure_id path int true "Closure ID"
+// @Success 200 {object} map[string]string "Closure deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Closure not found"
+// @Router /clinics/{id}/closures/{closure_id} [delete]
+func (h *CalendarHandler) DeleteClosure(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+	closureID, ok := parseUintParam(c, "closure_id", "invalid closure ID")
+	if !ok {
+		return
+	}
+
+	if err := h.calendarService.WithContext(requestContext(c)).DeleteClosure(clinicID, closureID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Closure deleted successfully",
+	})
+}
+
+// GetCalendar godoc
+// @Summary Get clinic calendar
+// @Description Resolve the open periods of a clinic for each date between from and to (at most 92 days), applying opening hours, holidays and closures
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param from query string true "First date (YYYY-MM-DD)"
+// @Param to query string true "Last date (YYYY-MM-DD)"
+// @Success 200 {array} models.ClinicCalendarDay "Calendar"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/calendar [get]
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var req models.ClinicCalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	days, err := h.calendarService.GetCalendar(clinicID, c.GetUint("hospital_id"), req.From, req.To)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"days": days,
+	})
+}
+
+// IsOpen godoc
+// @Summary Check whether a clinic is open
+// @Description Check whether a clinic is open at an instant (RFC 3339, now by default), with the matching opening period or the holiday or closure keeping it shut
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param at query string false "Instant (RFC 3339)"
+// @Success 200 {object} models.ClinicOpenStatus "Open status"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/open [get]
+func (h *CalendarHandler) IsOpen(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var req models.ClinicOpenRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	at := time.Now()
+	if req.At != "" {
+		parsed, err := time.Parse(time.RFC3339, req.At)
+		if err != nil {
+			errors.RespondWithValidationError(c, "at", "must be an RFC 3339 timestamp")
+			return
+		}
+		at = parsed
+	}
+
+	status, err := h.calendarService.IsOpenAt(clinicID, c.GetUint("hospital_id"), at)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetHolidays godoc
+// @Summary List hospital holidays
+// @Description List the public holidays of the hospital in a year, the current year by default
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param year query int false "Year"
+// @Success 200 {array} models.HospitalHoliday "Holidays"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /holidays [get]
+func (h *CalendarHandler) GetHolidays(c *gin.Context) {
+	var filter models.HolidayFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	holidays, err := h.calendarService.GetHolidays(&filter, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"holidays": holidays,
+	})
+}
+
+// CreateHoliday godoc
+// @Summary Add a hospital holiday
+// @Description Add a hospital-wide holiday. Set closes_at (HH:MM) for a half day; clinics close at that time instead of staying closed all day (requires authorization)
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.HolidayRequest true "Holiday"
+// @Success 201 {object} models.HospitalHoliday "Holiday created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Date already has a holiday"
+// @Router /holidays [post]
+func (h *CalendarHandler) CreateHoliday(c *gin.Context) {
+	var req models.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	holiday, err := h.calendarService.WithContext(requestContext(c)).CreateHoliday(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"holiday": holiday,
+		"message": "Holiday created successfully",
+	})
+}
+
+// ImportHolidays godoc
+// @Summary Import Turkish national holidays
+// @Description Import the Turkish national holidays of a year, including religious holidays and half-day eves, from the bundled data file. Dates that already have a holiday are skipped (requires authorization)
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.HolidayImportRequest true "Year"
+// @Success 200 {object} models.HolidayImportResponse "Holidays imported"
+// @Failure 400 {object} models.ErrorResponse "Bad request or year not bundled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /holidays/import [post]
+func (h *CalendarHandler) ImportHolidays(c *gin.Context) {
+	var req models.HolidayImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	result, err := h.calendarService.WithContext(requestContext(c)).ImportNationalHolidays(req.Year, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteHoliday godoc
+// @Summary Delete a hospital holiday
+// @Description Remove a holiday from the hospital calendar (requires authorization)
+// @Tags Calendar
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} map[string]string "Holiday deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Holiday not found"
+// @Router /holidays/{id} [delete]
+func (h *CalendarHandler) DeleteHoliday(c *gin.Context) {
+	holidayID, ok := parseUintParam(c, "id", "invalid holiday ID")
+	if !ok {
+		return
+	}
+
+	if err := h.calendarService.WithContext(requestContext(c)).DeleteHoliday(holidayID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Holiday deleted successfully",
+	})
+}
@@ -0,0 +1,491 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogHandler struct {
+	catalogService *services.CatalogService
+}
+
+func NewCatalogHandler(catalogService *services.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+	}
+}
+
+// GetClinicTypes godoc
+// @Summary List clinic types of the hospital catalogue
+// @Description List the global clinic types followed by the hospital's own additions. Entries with a hospital_id are private to the hospital
+// @Tags Catalog
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.ClinicType "Clinic types"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /catalog/clinic-types [get]
+func (h *CatalogHandler) GetClinicTypes(c *gin.Context) {
+	clinicTypes, err := h.catalogService.GetClinicTypes(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinic_types": clinicTypes,
+	})
+}
+
+// CreateClinicType godoc
+// @Summary Add a private clinic type
+// @Description Add a clinic type only the hospital can use. The name must not match a global clinic type or another of the hospital's, ignoring case and Turkish letters (requires authorization)
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.CatalogEntryRequest true "Clinic type"
+// @Success 201 {object} models.ClinicType "Clinic type created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Name already in use"
+// @Router /catalog/clinic-types [post]
+func (h *CatalogHandler) CreateClinicType(c *gin.Context) {
+	var req models.CatalogEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	clinicType, err := h.catalogService.WithContext(requestContext(c)).CreateClinicType(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"clinic_type": clinicType,
+		"message":     "Clinic type created successfully",
+	})
+}
+
+// UpdateClinicType godoc
+// @Summary Rename a private clinic type
+// @Description Rename one of the hospital's own clinic types. Global clinic types cannot be changed (requires authorization)
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic type ID"
+// @Param request body models.CatalogEntryRequest true "Clinic type"
+// @Success 200 {object} models.ClinicType "Clinic type updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clinic type not found"
+// @Failure 409 {object} models.ErrorResponse "Name already in use"
+// @Router /catalog/clinic-types/{id} [put]
+func (h *CatalogHandler) UpdateClinicType(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid clinic type ID")
+	if !ok {
+		return
+	}
+
+	var req models.CatalogEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	clinicType, err := h.catalogService.WithContext(requestContext(c)).UpdateClinicType(id, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinic_type": clinicType,
+		"message":     "Clinic type updated successfully",
+	})
+}
+
+// DeleteClinicType godoc
+// @Summary Delete a private clinic type
+// @Description Delete one of the hospital's own clinic types. Clinic types used by a clinic, including deleted clinics that can still be restored, cannot be deleted (requires authorization)
+// @Tags Catalog
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic type ID"
+// @Success 200 {object} map[string]string "Clinic type deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request or clinic type in use"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clinic type not found"
+// @Router /catalog/clinic-types/{id} [delete]
+func (h *CatalogHandler) DeleteClinicType(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid clinic type ID")
+	if !ok {
+		return
+	}
+
+	if err := h.catalogService.WithContext(requestContext(c)).DeleteClinicType(id, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Clinic type deleted successfully",
+	})
+}
+
+// GetProfessionGroups godoc
+// @Summary List profession groups of the hospital catalogue
+// @Description List the global profession groups followed by the hospital's own additions, each with its global and private titles
+// @Tags Catalog
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.ProfessionGroup "Profession groups"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /catalog/profession-groups [get]
+func (h *CatalogHandler) GetProfessionGroups(c *gin.Context) {
+	professionGroups, err := h.catalogService.GetProfessionGroups(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profession_groups": professionGroups,
+	})
+}
+
+// CreateProfessionGroup godoc
+// @Summary Add a private profession group
+// @Description Add a profession group only the hospital can use (requires authorization)
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.CatalogEntryRequest true "Profession group"
+// @Success 201 {object} models.ProfessionGroup "Profession group created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Name already in use"
+// @Router /catalog/profession-groups [post]
+func (h *CatalogHandler) CreateProfessionGroup(c *gin.Context) {
+	var req models.CatalogEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	professionGroup, err := h.catalogService.WithContext(requestContext(c)).CreateProfessionGroup(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"profession_group": professionGroup,
+		"message":          "Profession group created successfully",
+	})
+}
+
+// UpdateProfessionGroup godoc
+// @Summary Rename a private profession group
+// @Description Rename one of the hospital's own profession groups (requires authorization)
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Profession group ID"
+// @Param request body models.CatalogEntryRequest true "Profession group"
+// @Success 200 {object} models.ProfessionGroup "Profession group updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Profession group not found"
+// @Failure 409 {object} models.ErrorResponse "Name already in use"
+// @Router /catalog/profession-groups/{id} [put]
+func (h *CatalogHandler) UpdateProfessionGroup(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid profession group ID")
+	if !ok {
+		return
+	}
+
+	var req models.CatalogEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	professionGroup, err := h.catalogService.WithContext(requestContext(c)).UpdateProfessionGroup(id, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profession_group": professionGroup,
+		"message":          "Profession group updated successfully",
+	})
+}
+
+// DeleteProfessionGroup godoc
+// @Summary Delete a private profession group
+// @Description Delete one of the hospital's own profession groups. Groups that still have titles, staff or staffing rules cannot be deleted (requires authorization)
+// @Tags Catalog
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Profession group ID"
+// @Success 200 {object} map[string]string "Profession group deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request or profession group in use"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Profession group not found"
+// @Router /catalog/profession-groups/{id} [delete]
+func (h *CatalogHandler) DeleteProfessionGroup(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid profession group ID")
+	if !ok {
+		return
+	}
+
+	if err := h.catalogService.WithContext(requestContext(c)).DeleteProfessionGroup(id, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profession group deleted successfully",
+	})
+}
+
+// CreateTitle godoc
+// @Summary Add a private title
+// @Description Add a title only the hospital can use, under a global profession group or one of the hospital's own (requires authorization)
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.CreateTitleRequest true "Title"
+// @Success 201 {object} models.Title "Title created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Name already in use in the profession group"
+// @Router /catalog/titles [post]
+func (h *CatalogHandler) CreateTitle(c *gin.Context) {
+	var req models.CreateTitleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	title, err := h.catalogService.WithContext(requestContext(c)).CreateTitle(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"title":   title,
+		"message": "Title created successfully",
+	})
+}
+
+// UpdateTitle godoc
+// @Summary Rename a private title
+// @Description Rename one of the hospital's own titles (requires authorization)
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Title ID"
+// @Param request body models.CatalogEntryRequest true "Title"
+// @Success 200 {object} models.Title "Title updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Title not found"
+// @Failure 409 {object} models.ErrorResponse "Name already in use in the profession group"
+// @Router /catalog/titles/{id} [put]
+func (h *CatalogHandler) UpdateTitle(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid title ID")
+	if !ok {
+		return
+	}
+
+	var req models.CatalogEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	title, err := h.catalogService.WithContext(requestContext(c)).UpdateTitle(id, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"title":   title,
+		"message": "Title updated successfully",
+	})
+}
+
+// DeleteTitle godoc
+// @Summary Delete a private title
+// @Description Delete one of the hospital's own titles. Titles held by staff or used by staffing rules cannot be deleted (requires authorization)
+// @Tags Catalog
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Title ID"
+// @Success 200 {object} map[string]string "Title deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request or title in use"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Title not found"
+// @Router /catalog/titles/{id} [delete]
+func (h *CatalogHandler) DeleteTitle(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid title ID")
+	if !ok {
+		return
+	}
+
+	if err := h.catalogService.WithContext(requestContext(c)).DeleteTitle(id, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Title deleted successfully",
+	})
+}
+
+// GetPromotionCandidates godoc
+// @Summary List catalogue entries worth promoting
+// @Description List private clinic types, profession groups and titles that at least min_hospitals hospitals added under the same name (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param min_hospitals query int false "Minimum number of hospitals (default 2)"
+// @Success 200 {array} models.CatalogPromotionCandidate "Candidates"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Router /admin/catalog/candidates [get]
+func (h *CatalogHandler) GetPromotionCandidates(c *gin.Context) {
+	var filter models.CatalogCandidateFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	candidates, err := h.catalogService.GetPromotionCandidates(filter.MinHospitals)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"candidates": candidates,
+	})
+}
+
+// PromoteClinicType godoc
+// @Summary Promote a private clinic type to global
+// @Description Make a private clinic type global, or merge it into the global clinic type of the same name. Private clinic types of the same name in other hospitals are merged into it and their clinics moved over (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Clinic type ID"
+// @Success 200 {object} models.ClinicType "Global clinic type"
+// @Failure 400 {object} models.ErrorResponse "Bad request or already global"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Failure 404 {object} models.ErrorResponse "Clinic type not found"
+// @Router /admin/catalog/clinic-types/{id}/promote [post]
+func (h *CatalogHandler) PromoteClinicType(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid clinic type ID")
+	if !ok {
+		return
+	}
+
+	clinicType, err := h.catalogService.WithContext(requestContext(c)).PromoteClinicType(id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinic_type": clinicType,
+		"message":     "Clinic type promoted successfully",
+	})
+}
+
+// PromoteProfessionGroup godoc
+// @Summary Promote a private profession group to global
+// @Description Make a private profession group global, merging the private groups of the same name of other hospitals into it. Their titles stay private to each hospital (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Profession group ID"
+// @Success 200 {object} models.ProfessionGroup "Global profession group"
+// @Failure 400 {object} models.ErrorResponse "Bad request or already global"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Failure 404 {object} models.ErrorResponse "Profession group not found"
+// @Router /admin/catalog/profession-groups/{id}/promote [post]
+func (h *CatalogHandler) PromoteProfessionGroup(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid profession group ID")
+	if !ok {
+		return
+	}
+
+	professionGroup, err := h.catalogService.WithContext(requestContext(c)).PromoteProfessionGroup(id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profession_group": professionGroup,
+		"message":          "Profession group promoted successfully",
+	})
+}
+
+// PromoteTitle godoc
+// @Summary Promote a private title to global
+// @Description Make a private title of a global profession group global, merging the private titles of the same name in that group into it (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Title ID"
+// @Success 200 {object} models.Title "Global title"
+// @Failure 400 {object} models.ErrorResponse "Bad request, already global or profession group not global"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Failure 404 {object} models.ErrorResponse "Title not found"
+// @Router /admin/catalog/titles/{id}/promote [post]
+func (h *CatalogHandler) PromoteTitle(c *gin.Context) {
+	id, ok := parseUintParam(c, "id", "invalid title ID")
+	if !ok {
+		return
+	}
+
+	title, err := h.catalogService.WithContext(requestContext(c)).PromoteTitle(id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"title":   title,
+		"message": "Title promoted successfully",
+	})
+}
@@ -42,7 +42,7 @@ func (h *ClinicHandler) CreateClinic(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	clinic, err := h.clinicService.CreateClinic(&req, hospitalID)
+	clinic, err := h.clinicService.WithContext(requestContext(c)).CreateClinic(&req, hospitalID)
 	if err != nil {
 		errors.HandleError(c, err)
 		return
@@ -56,26 +56,33 @@ func (h *ClinicHandler) CreateClinic(c *gin.Context) {
 
 // GetClinics godoc
 // @Summary Get all clinics
-// @Description Get all clinics in the hospital
+// @Description Get a page of the clinics in the hospital, ordered by ID, with their rooms, head of clinic and staff counts per profession group
 // @Tags Clinics
 // @Produce json
 // @Security Bearer
-// @Success 200 {array} models.Clinic "List of clinics"
+// @Param page query int false "Page number for pagination"
+// @Param limit query int false "Number of items per page (default 20, max 100)"
+// @Success 200 {object} models.ClinicPaginatedResponse "List of clinics"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /clinics [get]
 func (h *ClinicHandler) GetClinics(c *gin.Context) {
+	var filter models.ClinicFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
 	hospitalID := c.GetUint("hospital_id")
 
-	clinics, err := h.clinicService.GetClinics(hospitalID)
+	result, err := h.clinicService.GetClinics(&filter, hospitalID)
 	if err != nil {
 		errors.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"clinics": clinics,
-	})
+	c.JSON(http.StatusOK, result)
 }
 
 // GetClinicTypes godoc
@@ -121,7 +128,7 @@ func (h *ClinicHandler) DeleteClinic(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	err = h.clinicService.DeleteClinic(uint(clinicID), hospitalID)
+	err = h.clinicService.WithContext(requestContext(c)).DeleteClinic(uint(clinicID), hospitalID)
 	if err != nil {
 		errors.HandleError(c, err)
 		return
@@ -131,3 +138,219 @@ func (h *ClinicHandler) DeleteClinic(c *gin.Context) {
 		"message": "Clinic deleted successfully",
 	})
 }
+
+// GetClinic godoc
+// @Summary Get clinic details
+// @Description Get a clinic with its display name, location, phone extension, head of clinic and rooms
+// @Tags Clinics
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Success 200 {object} models.Clinic "Clinic"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id} [get]
+func (h *ClinicHandler) GetClinic(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	clinic, err := h.clinicService.GetClinic(clinicID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinic": clinic,
+	})
+}
+
+// UpdateClinic godoc
+// @Summary Update clinic details
+// @Description Replace the display name, floor, building, phone extension and head of a clinic. The head of clinic must be a staff member of the clinic and is cleared automatically when they leave it; omit head_staff_id to remove the head (requires authorization)
+// @Tags Clinics
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param request body models.UpdateClinicRequest true "Clinic details"
+// @Success 200 {object} models.Clinic "Clinic updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request or head is not a staff member of the clinic"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clinic or staff not found"
+// @Router /clinics/{id} [put]
+func (h *ClinicHandler) UpdateClinic(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateClinicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	clinic, err := h.clinicService.WithContext(requestContext(c)).UpdateClinic(clinicID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinic":  clinic,
+		"message": "Clinic updated successfully",
+	})
+}
+
+// GetRooms godoc
+// @Summary List clinic rooms
+// @Description List the rooms and examination desks of a clinic with their capacity
+// @Tags Clinics
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Success 200 {array} models.ClinicRoom "Rooms"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/rooms [get]
+func (h *ClinicHandler) GetRooms(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	rooms, err := h.clinicService.GetRooms(clinicID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rooms": rooms,
+	})
+}
+
+// CreateRoom godoc
+// @Summary Add a clinic room
+// @Description Add a room, ward or examination desk to a clinic. Room names are unique within a clinic (requires authorization)
+// @Tags Clinics
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param request body models.ClinicRoomRequest true "Room data"
+// @Success 201 {object} models.ClinicRoom "Room created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Failure 409 {object} models.ErrorResponse "Room name already in use"
+// @Router /clinics/{id}/rooms [post]
+func (h *ClinicHandler) CreateRoom(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var req models.ClinicRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	room, err := h.clinicService.WithContext(requestContext(c)).CreateRoom(clinicID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"room":    room,
+		"message": "Room created successfully",
+	})
+}
+
+// UpdateRoom godoc
+// @Summary Update a clinic room
+// @Description Replace the name, kind and capacity of a clinic room (requires authorization)
+// @Tags Clinics
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param room_id path int true "Room ID"
+// @Param request body models.ClinicRoomRequest true "Room data"
+// @Success 200 {object} models.ClinicRoom "Room updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Room not found"
+// @Failure 409 {object} models.ErrorResponse "Room name already in use"
+// @Router /clinics/{id}/rooms/{room_id} [put]
+func (h *ClinicHandler) UpdateRoom(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+	roomID, ok := parseUintParam(c, "room_id", "invalid room ID")
+	if !ok {
+		return
+	}
+
+	var req models.ClinicRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	room, err := h.clinicService.WithContext(requestContext(c)).UpdateRoom(clinicID, roomID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"room":    room,
+		"message": "Room updated successfully",
+	})
+}
+
+// DeleteRoom godoc
+// @Summary Delete a clinic room
+// @Description Remove a room from a clinic (requires authorization)
+// @Tags Clinics
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param room_id path int true "Room ID"
+// @Success 200 {object} map[string]string "Room deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Room not found"
+// @Router /clinics/{id}/rooms/{room_id} [delete]
+func (h *ClinicHandler) DeleteRoom(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+	roomID, ok := parseUintParam(c, "room_id", "invalid room ID")
+	if !ok {
+		return
+	}
+
+	if err := h.clinicService.WithContext(requestContext(c)).DeleteRoom(clinicID, roomID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Room deleted successfully",
+	})
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CredentialHandler struct {
+	credentialService *services.CredentialService
+}
+
+func NewCredentialHandler(credentialService *services.CredentialService) *CredentialHandler {
+	return &CredentialHandler{
+		credentialService: credentialService,
+	}
+}
+
+// GetCredentials godoc
+// @Summary Get licenses and certifications of a staff member
+// @Description Get the credentials of a staff member with their expiry status, soonest expiry first
+// @Tags Credentials
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Success 200 {array} models.StaffCredential "Credentials"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/credentials [get]
+func (h *CredentialHandler) GetCredentials(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	credentials, err := h.credentialService.GetCredentials(staffID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"credentials": credentials,
+	})
+}
+
+// CreateCredential godoc
+// @Summary Add a license or certification to a staff member
+// @Description Record a diploma registration, specialty certificate, certification or license. Credentials without an expiry date never expire. Mandatory credentials that have expired can block clinic assignments when the server is configured to do so (requires authorization)
+// @Tags Credentials
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Param request body models.CredentialRequest true "Credential data"
+// @Success 201 {object} models.StaffCredential "Credential created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/credentials [post]
+func (h *CredentialHandler) CreateCredential(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	var req models.CredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	credential, err := h.credentialService.WithContext(requestContext(c)).CreateCredential(staffID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"credential": credential,
+		"message":    "Credential created successfully",
+	})
+}
+
+// UpdateCredential godoc
+// @Summary Replace a license or certification
+// @Description Replace all fields of a credential, e.g. after a renewal. Changing the expiry date re-arms the expiry notifications (requires authorization)
+// @Tags Credentials
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Param credential_id path int true "Credential ID"
+// @Param request body models.CredentialRequest true "Credential data"
+// @Success 200 {object} models.StaffCredential "Credential updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Credential not found"
+// @Router /staff/{id}/credentials/{credential_id} [put]
+func (h *CredentialHandler) UpdateCredential(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+	credentialID, ok := parseUintParam(c, "credential_id", "invalid credential ID")
+	if !ok {
+		return
+	}
+
+	var req models.CredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	credential, err := h.credentialService.WithContext(requestContext(c)).UpdateCredential(staffID, credentialID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"credential": credential,
+		"message":    "Credential updated successfully",
+	})
+}
+
+// DeleteCredential godoc
+// @Summary Delete a license or certification
+// @Description Delete a credential of a staff member (requires authorization)
+// @Tags Credentials
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Param credential_id path int true "Credential ID"
+// @Success 200 {object} map[string]string "Credential deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Credential not found"
+// @Router /staff/{id}/credentials/{credential_id} [delete]
+func (h *CredentialHandler) DeleteCredential(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+	credentialID, ok := parseUintParam(c, "credential_id", "invalid credential ID")
+	if !ok {
+		return
+	}
+
+	if err := h.credentialService.WithContext(requestContext(c)).DeleteCredential(staffID, credentialID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Credential deleted successfully",
+	})
+}
+
+// GetExpiringCredentials godoc
+// @Summary Get expired and soon expiring credentials
+// @Description Get the hospital's credentials that have expired or expire within the given number of days
+// @Tags Credentials
+// @Produce json
+// @Security Bearer
+// @Param days query int false "Days ahead to include" default(30)
+// @Success 200 {array} models.StaffCredential "Credentials"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /credentials/expiring [get]
+func (h *CredentialHandler) GetExpiringCredentials(c *gin.Context) {
+	var filter models.ExpiringCredentialFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	credentials, err := h.credentialService.GetExpiringCredentials(&filter, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"credentials": credentials,
+	})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type DashboardHandler struct {
+	dashboardService *services.DashboardService
+}
+
+func NewDashboardHandler(dashboardService *services.DashboardService) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+	}
+}
+
+// GetDashboard godoc
+// @Summary Get the hospital overview
+// @Description Get the headcount by profession group, title and clinic, the clinics without doctors, the unassigned staff, the staff added and removed in the last 30 days and the user counts by type. Terminated staff are not counted. The overview is cached and rebuilt after staff, clinics or users change (requires authorization)
+// @Tags Dashboard
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.DashboardResponse "Dashboard"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /dashboard [get]
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	dashboard, err := h.dashboardService.GetDashboard(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
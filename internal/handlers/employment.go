@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type EmploymentHandler struct {
+	employmentService *services.EmploymentService
+}
+
+func NewEmploymentHandler(employmentService *services.EmploymentService) *EmploymentHandler {
+	return &EmploymentHandler{
+		employmentService: employmentService,
+	}
+}
+
+// GetEmployment godoc
+// @Summary Get employment records and status history of a staff member
+// @Description Get the lifecycle state, the employment contracts and the status changes of a staff member, newest first. Terminated staff remain available here
+// @Tags Employment
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Success 200 {object} models.StaffEmploymentResponse "Employment"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/employment [get]
+func (h *EmploymentHandler) GetEmployment(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	employment, err := h.employmentService.GetEmployment(staffID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, employment)
+}
+
+// UpdateEmployment godoc
+// @Summary Replace the current contract of a staff member
+// @Description Replace the contract type, hire date, probation end and contract end of the current employment, or record one for staff without an employment record. Contracted and locum staff need a contract end date, permanent staff must not have one (requires authorization)
+// @Tags Employment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Param request body models.EmploymentRequest true "Contract data"
+// @Success 200 {object} models.StaffEmployment "Employment updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/employment [put]
+func (h *EmploymentHandler) UpdateEmployment(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	var req models.EmploymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	employment, err := h.employmentService.WithContext(requestContext(c)).UpdateEmployment(staffID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"employment": employment,
+		"message":    "Employment updated successfully",
+	})
+}
+
+// ChangeStatus godoc
+// @Summary Change the lifecycle state of a staff member
+// @Description Move a staff member between onboarding, active, on_leave and terminated. Onboarding staff can become active, active staff can go on leave and back, and anyone can be terminated with a reason. Termination closes the clinic assignment and the current contract but keeps the staff record for reporting; terminated staff can be rehired with a new contract. A back-dated effective_at must not precede the last status change or the current clinic assignment (requires authorization)
+// @Tags Employment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Param request body models.ChangeStaffStatusRequest true "Status change"
+// @Success 200 {object} models.Staff "Status changed"
+// @Failure 400 {object} models.ErrorResponse "Bad request or invalid transition"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/status [post]
+func (h *EmploymentHandler) ChangeStatus(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	var req models.ChangeStaffStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	staff, err := h.employmentService.WithContext(requestContext(c)).ChangeStatus(staffID, &req, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"staff":   staff,
+		"message": "Staff status changed successfully",
+	})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	eventBacklogPage       = 500
+	eventHeartbeatInterval = 15 * time.Second
+)
+
+type EventHandler struct {
+	eventService *services.EventService
+}
+
+func NewEventHandler(eventService *services.EventService) *EventHandler {
+	return &EventHandler{
+		eventService: eventService,
+	}
+}
+
+// Stream godoc
+// @Summary Stream hospital events
+// @Description Server-Sent Events stream of staff, clinic and user changes in the caller's hospital (staff.created, clinic.updated, user.deleted, ...). Each event's id is its position in the stream; a client that reconnects with Last-Event-ID (or last_event_id) first receives the events it missed. The JWT can be passed as access_token for EventSource clients
+// @Tags Events
+// @Produce text/event-stream
+// @Security Bearer
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param last_event_id query int false "ID of the last event received"
+// @Param access_token query string false "JWT, for clients that cannot set the Authorization header"
+// @Success 200 {object} models.DomainEvent "Event stream"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /events/stream [get]
+func (h *EventHandler) Stream(c *gin.Context) {
+	var req models.EventStreamRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+	lastID := req.LastEventID
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := services.ParseLastEventID(header)
+		if err != nil {
+			errors.HandleError(c, err)
+			return
+		}
+		lastID = id
+	}
+	resume := lastID != 0
+
+	hospitalID := c.GetUint("hospital_id")
+	ctx := c.Request.Context()
+
+	// subscribe before reading the backlog so nothing is lost in between;
+	// live events already sent from the backlog are skipped by ID
+	events, unsubscribe, err := h.eventService.Subscribe(ctx, hospitalID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for resume {
+		backlog, err := h.eventService.EventsSince(hospitalID, lastID, eventBacklogPage)
+		if err != nil {
+			return
+		}
+		for _, event := range backlog {
+			if writeEvent(c, event) != nil {
+				return
+			}
+			lastID = event.ID
+		}
+		if len(backlog) < eventBacklogPage {
+			break
+		}
+	}
+	// an initial comment lets clients see the stream is open
+	if _, err := fmt.Fprint(c.Writer, ": connected\n\n"); err != nil {
+		return
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.ID <= lastID {
+				continue
+			}
+			if writeEvent(c, event) != nil {
+				return
+			}
+			lastID = event.ID
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeEvent(c *gin.Context, event models.DomainEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportStaff godoc
+// @Summary Export staff
+// @Description Export the staff of the hospital as CSV or XLSX using the same filters as the staff listing, or as a PDF staffing report with a profession breakdown per clinic. Available columns: id, first_name, last_name, national_id, phone, profession_group, title, clinic, working_days, status, created_at
+// @Tags Staff
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Security Bearer
+// @Param format query string false "Export format" Enums(csv, xlsx, pdf) default(csv)
+// @Param columns query string false "Comma separated columns, ignored for PDF"
+// @Param q query string false "Search by name or national ID"
+// @Param first_name query string false "Filter by first name"
+// @Param last_name query string false "Filter by last name"
+// @Param national_id query string false "Filter by national ID"
+// @Param profession_group_id query int false "Filter by profession group ID"
+// @Param title_id query int false "Filter by title ID"
+// @Param clinic_id query int false "Filter by clinic ID"
+// @Success 200 {file} file "Staff export"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /staff/export [get]
+func (h *ExportHandler) ExportStaff(c *gin.Context) {
+	var req models.StaffExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	if err := h.exportService.ValidateStaffExport(&req); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	streamExport(c, "staff", req.Format, func() error {
+		return h.exportService.ExportStaff(c.Writer, &req, c.GetUint("hospital_id"))
+	})
+}
+
+// ExportClinics godoc
+// @Summary Export clinics
+// @Description Export the clinics of the hospital with staff counts per profession group as CSV or XLSX
+// @Tags Clinics
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security Bearer
+// @Param format query string false "Export format" Enums(csv, xlsx) default(csv)
+// @Success 200 {file} file "Clinic export"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /clinics/export [get]
+func (h *ExportHandler) ExportClinics(c *gin.Context) {
+	var req models.ClinicExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	streamExport(c, "clinics", req.Format, func() error {
+		return h.exportService.ExportClinics(c.Writer, req.Format, c.GetUint("hospital_id"))
+	})
+}
+
+// streamExport writes the download headers and runs the export. Once the
+// first bytes have been sent the status can no longer change, so later
+// failures are only logged and the client receives a truncated file.
+func streamExport(c *gin.Context, name, format string, export func() error) {
+	contentType, extension := services.ExportContentType(format)
+	fileName := name + "-" + time.Now().UTC().Format("20060102") + "." + extension
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", "attachment; filename="+fileName)
+	c.Status(http.StatusOK)
+
+	if err := export(); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			errors.HandleError(c, err)
+			return
+		}
+		log.Error().Err(err).Str("export", name).Msg("Export failed after streaming started")
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/caner-cetin/hospital-tracker/internal/errors"
@@ -16,3 +17,11 @@ func parseUintParam(c *gin.Context, paramName, errorMessage string) (uint, bool)
 	}
 	return uint(id), true
 }
+
+// requestContext returns the context for the writes of a request. It carries
+// the audit actor set by the middleware but not the request's cancellation,
+// so work that outlives the response, such as a background import, is not
+// cut short.
+func requestContext(c *gin.Context) context.Context {
+	return context.WithoutCancel(c.Request.Context())
+}
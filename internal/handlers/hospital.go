@@ -36,7 +36,7 @@ func (h *HospitalHandler) Register(c *gin.Context) {
 		return
 	}
 
-	hospital, user, err := h.hospitalService.RegisterHospital(&req)
+	hospital, user, err := h.hospitalService.WithContext(requestContext(c)).RegisterHospital(&req)
 	if err != nil {
 		errors.HandleError(c, err)
 		return
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobService *services.JobService
+}
+
+func NewJobHandler(jobService *services.JobService) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+// GetJobs godoc
+// @Summary List background jobs
+// @Description List the registered background jobs with their cron schedule, next scheduled run and latest run (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {array} models.JobSummary "Background jobs"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Router /admin/jobs [get]
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	jobs, err := h.jobService.GetJobs()
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs": jobs,
+	})
+}
+
+// GetRuns godoc
+// @Summary List job runs
+// @Description List job runs newest first with their attempts and last error. status=failed lists the runs that failed every attempt (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param name query string false "Job name"
+// @Param status query string false "queued, running, succeeded or failed"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} models.JobRunPaginatedResponse "Job runs"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Router /admin/jobs/runs [get]
+func (h *JobHandler) GetRuns(c *gin.Context) {
+	var filter models.JobRunFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	result, err := h.jobService.GetRuns(&filter)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Enqueue godoc
+// @Summary Run a job now
+// @Description Queue a run of a background job for the worker, outside its schedule. The optional JSON body is passed to the job as its payload (requires the admin token)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param name path string true "Job name"
+// @Param payload body object false "Job payload"
+// @Success 202 {object} models.JobRun "Job queued"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Router /admin/jobs/{name}/run [post]
+func (h *JobHandler) Enqueue(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+	if len(payload) > 0 && !json.Valid(payload) {
+		errors.RespondWithValidationError(c, "request", "payload must be JSON")
+		return
+	}
+
+	run, err := h.jobService.Enqueue(c.Request.Context(), c.Param("name"), payload)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"run":     run,
+		"message": "Job queued successfully",
+	})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
 	"github.com/caner-cetin/hospital-tracker/internal/models"
 	"github.com/caner-cetin/hospital-tracker/internal/services"
 	"github.com/gin-gonic/gin"
@@ -20,7 +21,7 @@ func NewLocationHandler(locationService *services.LocationService) *LocationHand
 
 // GetProvinces godoc
 // @Summary Get all provinces
-// @Description Get all provinces in the country
+// @Description Get all 81 provinces ordered by plate code, with their centre coordinates
 // @Tags Reference Data
 // @Produce json
 // @Success 200 {array} models.Province "List of provinces"
@@ -82,3 +83,58 @@ func (h *LocationHandler) GetDistricts(c *gin.Context) {
 		"districts": districts,
 	})
 }
+
+// GetProvinceDistricts godoc
+// @Summary Get a province's districts
+// @Description Get the districts of one province
+// @Tags Reference Data
+// @Produce json
+// @Param id path int true "Province ID"
+// @Success 200 {array} models.District "List of districts"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 404 {object} models.ErrorResponse "Province not found"
+// @Router /provinces/{id}/districts [get]
+func (h *LocationHandler) GetProvinceDistricts(c *gin.Context) {
+	provinceID, ok := parseUintParam(c, "id", "invalid province ID")
+	if !ok {
+		return
+	}
+
+	districts, err := h.locationService.GetProvinceDistricts(provinceID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"districts": districts,
+	})
+}
+
+// GetNeighbourhoods godoc
+// @Summary Get a district's neighbourhoods
+// @Description Get the neighbourhoods (mahalle) of one district, optionally only those matching a name; matching ignores Turkish casing and diacritics
+// @Tags Reference Data
+// @Produce json
+// @Param id path int true "District ID"
+// @Param q query string false "Neighbourhood name to search for"
+// @Success 200 {array} models.Neighbourhood "List of neighbourhoods"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 404 {object} models.ErrorResponse "District not found"
+// @Router /districts/{id}/neighbourhoods [get]
+func (h *LocationHandler) GetNeighbourhoods(c *gin.Context) {
+	districtID, ok := parseUintParam(c, "id", "invalid district ID")
+	if !ok {
+		return
+	}
+
+	neighbourhoods, err := h.locationService.GetNeighbourhoods(districtID, c.Query("q"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"neighbourhoods": neighbourhoods,
+	})
+}
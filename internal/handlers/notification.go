@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description List the caller's in-app notifications newest first, with the number still unread
+// @Tags Notifications
+// @Produce json
+// @Security Bearer
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} models.NotificationPaginatedResponse "Notifications"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	var filter models.NotificationFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	result, err := h.notificationService.GetNotifications(c.GetUint("user_id"), &filter)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MarkRead godoc
+// @Summary Mark a notification as read
+// @Description Mark one of the caller's notifications as read
+// @Tags Notifications
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Notification ID"
+// @Success 200 {object} models.UserNotification "Notification marked as read"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Notification not found"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	notificationID, ok := parseUintParam(c, "id", "invalid notification ID")
+	if !ok {
+		return
+	}
+
+	notification, err := h.notificationService.WithContext(requestContext(c)).MarkRead(notificationID, c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notification": notification,
+		"message":      "Notification marked as read successfully",
+	})
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of the caller as read
+// @Tags Notifications
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} map[string]interface{} "Notifications marked as read"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	updated, err := h.notificationService.WithContext(requestContext(c)).MarkAllRead(c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"updated": updated,
+		"message": "Notifications marked as read successfully",
+	})
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Get the channels (in-app, email, SMS) the caller receives each notification type on. Types never set are in-app only
+// @Tags Notifications
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.NotificationPreference "Notification preferences"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	preferences, err := h.notificationService.GetPreferences(c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": preferences,
+	})
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Set the channels the caller receives the given notification types on. Types left out keep their current channels
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.UpdateNotificationPreferencesRequest true "Preferences to set"
+// @Success 200 {array} models.NotificationPreference "Notification preferences"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /notifications/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req models.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	preferences, err := h.notificationService.WithContext(requestContext(c)).UpdatePreferences(c.GetUint("user_id"), &req)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": preferences,
+		"message":     "Notification preferences updated successfully",
+	})
+}
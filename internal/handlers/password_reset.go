@@ -38,7 +38,7 @@ func (h *PasswordResetHandler) RequestReset(c *gin.Context) {
 		return
 	}
 
-	code, err := h.passwordResetService.RequestPasswordReset(req.Phone)
+	code, err := h.passwordResetService.WithContext(requestContext(c)).RequestPasswordReset(req.Phone)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "reset request failed",
@@ -72,7 +72,7 @@ func (h *PasswordResetHandler) ConfirmReset(c *gin.Context) {
 		return
 	}
 
-	err := h.passwordResetService.ResetPassword(req.Phone, req.Code, req.NewPassword, req.ConfirmPassword)
+	err := h.passwordResetService.WithContext(requestContext(c)).ResetPassword(req.Phone, req.Code, req.NewPassword, req.ConfirmPassword)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "password reset failed",
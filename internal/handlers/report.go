@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// GetHeadcountTrend godoc
+// @Summary Get the headcount trend
+// @Description Get the headcount per day, week or month from the daily snapshots. Each period uses its last snapshotted day. The trend can be narrowed to a clinic (or unassigned staff), profession group or title and split into one series per clinic, profession group or title
+// @Tags Reports
+// @Produce json
+// @Security Bearer
+// @Param from query string true "First day (YYYY-MM-DD)"
+// @Param to query string true "Last day (YYYY-MM-DD)"
+// @Param granularity query string false "Period length (default month)" Enums(day, week, month)
+// @Param group_by query string false "Split into series" Enums(clinic, profession_group, title)
+// @Param clinic_id query string false "Clinic ID or unassigned"
+// @Param profession_group_id query int false "Profession group ID"
+// @Param title_id query int false "Title ID"
+// @Success 200 {object} models.HeadcountTrendResponse "Headcount trend"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /reports/headcount-trend [get]
+func (h *ReportHandler) GetHeadcountTrend(c *gin.Context) {
+	var filter models.HeadcountTrendRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	trend, err := h.reportService.GetHeadcountTrend(&filter, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trend)
+}
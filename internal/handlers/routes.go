@@ -12,11 +12,30 @@ import (
 func SetupRoutes(router *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
 	authService := services.NewAuthService(db, cfg)
 	hospitalService := services.NewHospitalService(db, authService)
+	notificationService := services.NewNotificationService(db)
 	passwordResetService := services.NewPasswordResetService(db, authService)
+	passwordResetService.SetNotifier(notificationService)
 	userService := services.NewUserService(db, authService)
 	clinicService := services.NewClinicService(db)
 	staffService := services.NewStaffService(db, redisClient)
+	staffService.SetBlockExpiredCredentials(cfg.Credentials.BlockExpiredAssignment)
 	locationService := services.NewLocationService(db, redisClient)
+	attendanceService := services.NewAttendanceService(db, cfg)
+	staffImportService := services.NewStaffImportService(db, staffService)
+	exportService := services.NewExportService(db, clinicService)
+	credentialService := services.NewCredentialService(db, notificationService, cfg)
+	staffingRuleService := services.NewStaffingRuleService(db)
+	employmentService := services.NewEmploymentService(db)
+	trashService := services.NewTrashService(db, cfg)
+	calendarService := services.NewCalendarService(db, cfg)
+	catalogService := services.NewCatalogService(db, redisClient)
+	dashboardService := services.NewDashboardService(db, redisClient)
+	reportService := services.NewReportService(db, cfg)
+	auditService := services.NewAuditService(db)
+	webhookService := services.NewWebhookService(db, cfg)
+	eventService := services.NewEventService(db, redisClient)
+	jobService := services.NewJobService(db, redisClient, cfg)
+	staffImportService.SetJobQueue(jobService)
 
 	authHandler := NewAuthHandler(authService)
 	hospitalHandler := NewHospitalHandler(hospitalService)
@@ -25,6 +44,22 @@ func SetupRoutes(router *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client
 	clinicHandler := NewClinicHandler(clinicService)
 	staffHandler := NewStaffHandler(staffService)
 	locationHandler := NewLocationHandler(locationService)
+	attendanceHandler := NewAttendanceHandler(attendanceService)
+	staffImportHandler := NewStaffImportHandler(staffImportService)
+	exportHandler := NewExportHandler(exportService)
+	credentialHandler := NewCredentialHandler(credentialService)
+	staffingRuleHandler := NewStaffingRuleHandler(staffingRuleService)
+	employmentHandler := NewEmploymentHandler(employmentService)
+	trashHandler := NewTrashHandler(trashService)
+	calendarHandler := NewCalendarHandler(calendarService)
+	catalogHandler := NewCatalogHandler(catalogService)
+	dashboardHandler := NewDashboardHandler(dashboardService)
+	reportHandler := NewReportHandler(reportService)
+	auditHandler := NewAuditHandler(auditService)
+	webhookHandler := NewWebhookHandler(webhookService)
+	eventHandler := NewEventHandler(eventService)
+	notificationHandler := NewNotificationHandler(notificationService)
+	jobHandler := NewJobHandler(jobService)
 
 	router.POST("/register", hospitalHandler.Register)
 	router.POST("/login", authHandler.Login)
@@ -32,10 +67,17 @@ func SetupRoutes(router *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client
 	router.POST("/password-reset/confirm", passwordResetHandler.ConfirmReset)
 
 	router.GET("/provinces", locationHandler.GetProvinces)
+	router.GET("/provinces/:id/districts", locationHandler.GetProvinceDistricts)
 	router.GET("/districts", locationHandler.GetDistricts)
+	router.GET("/districts/:id/neighbourhoods", locationHandler.GetNeighbourhoods)
 	router.GET("/clinic-types", clinicHandler.GetClinicTypes)
 	router.GET("/profession-groups", staffHandler.GetProfessionGroups)
 
+	router.POST("/attendance/kiosk/clock-in", attendanceHandler.KioskClockIn)
+	router.POST("/attendance/kiosk/clock-out", attendanceHandler.KioskClockOut)
+
+	router.GET("/events/stream", middleware.QueryToken(), middleware.AuthRequired(authService), eventHandler.Stream)
+
 	protected := router.Group("/")
 	protected.Use(middleware.AuthRequired(authService))
 	{
@@ -43,23 +85,128 @@ func SetupRoutes(router *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client
 		protected.GET("/users/:id", userHandler.GetUser)
 
 		protected.GET("/clinics", clinicHandler.GetClinics)
+		protected.GET("/clinics/export", exportHandler.ExportClinics)
+		protected.GET("/clinics/:id", clinicHandler.GetClinic)
+		protected.GET("/clinics/:id/staff", staffHandler.GetClinicStaff)
+		protected.GET("/clinics/:id/rooms", clinicHandler.GetRooms)
+		protected.GET("/clinics/:id/hours", calendarHandler.GetOpeningHours)
+		protected.GET("/clinics/:id/closures", calendarHandler.GetClosures)
+		protected.GET("/clinics/:id/calendar", calendarHandler.GetCalendar)
+		protected.GET("/clinics/:id/open", calendarHandler.IsOpen)
+		protected.GET("/holidays", calendarHandler.GetHolidays)
+
+		protected.GET("/catalog/clinic-types", catalogHandler.GetClinicTypes)
+		protected.GET("/catalog/profession-groups", catalogHandler.GetProfessionGroups)
+
+		protected.GET("/reports/headcount-trend", reportHandler.GetHeadcountTrend)
 
 		protected.GET("/staff", staffHandler.GetStaff)
+		protected.GET("/staff/export", exportHandler.ExportStaff)
 		protected.GET("/staff/:id", staffHandler.GetStaffByID)
+		protected.GET("/staff/:id/assignments", staffHandler.GetStaffAssignments)
+		protected.GET("/staff/:id/history", staffHandler.GetStaffHistory)
+		protected.GET("/staff/:id/credentials", credentialHandler.GetCredentials)
+		protected.GET("/staff/:id/employment", employmentHandler.GetEmployment)
+		protected.GET("/credentials/expiring", credentialHandler.GetExpiringCredentials)
+		protected.GET("/staffing-rules", staffingRuleHandler.GetRules)
+
+		protected.POST("/attendance/clock-in", attendanceHandler.ClockIn)
+		protected.POST("/attendance/clock-out", attendanceHandler.ClockOut)
+		protected.GET("/attendance/events", attendanceHandler.GetEvents)
+		protected.GET("/attendance/daily", attendanceHandler.GetDailyAttendance)
+		protected.GET("/attendance/timesheet", attendanceHandler.GetTimesheet)
+
+		protected.GET("/notifications", notificationHandler.GetNotifications)
+		protected.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+		protected.POST("/notifications/:id/read", notificationHandler.MarkRead)
+		protected.GET("/notifications/preferences", notificationHandler.GetPreferences)
+		protected.PUT("/notifications/preferences", notificationHandler.UpdatePreferences)
 	}
 
 	authorized := protected.Group("/")
 	authorized.Use(middleware.AuthorizedOnly())
 	{
+		authorized.GET("/dashboard", dashboardHandler.GetDashboard)
+		authorized.GET("/audit", auditHandler.GetAuditLogs)
+
+		authorized.GET("/webhooks", webhookHandler.GetSubscriptions)
+		authorized.POST("/webhooks", webhookHandler.CreateSubscription)
+		authorized.GET("/webhooks/deliveries", webhookHandler.GetDeliveries)
+		authorized.POST("/webhooks/replay", webhookHandler.Replay)
+		authorized.PUT("/webhooks/:id", webhookHandler.UpdateSubscription)
+		authorized.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription)
+		authorized.POST("/webhooks/:id/rotate-secret", webhookHandler.RotateSecret)
+
 		authorized.POST("/users", userHandler.CreateUser)
 		authorized.PUT("/users/:id", userHandler.UpdateUser)
 		authorized.DELETE("/users/:id", userHandler.DeleteUser)
+		authorized.GET("/users/trash", trashHandler.GetDeletedUsers)
+		authorized.POST("/users/:id/restore", trashHandler.RestoreUser)
 
 		authorized.POST("/clinics", clinicHandler.CreateClinic)
+		authorized.PUT("/clinics/:id", clinicHandler.UpdateClinic)
 		authorized.DELETE("/clinics/:id", clinicHandler.DeleteClinic)
+		authorized.POST("/clinics/:id/rooms", clinicHandler.CreateRoom)
+		authorized.PUT("/clinics/:id/rooms/:room_id", clinicHandler.UpdateRoom)
+		authorized.DELETE("/clinics/:id/rooms/:room_id", clinicHandler.DeleteRoom)
+		authorized.PUT("/clinics/:id/hours", calendarHandler.SetOpeningHours)
+		authorized.POST("/clinics/:id/closures", calendarHandler.CreateClosure)
+		authorized.DELETE("/clinics/:id/closures/:closure_id", calendarHandler.DeleteClosure)
+
+		authorized.POST("/holidays", calendarHandler.CreateHoliday)
+		authorized.POST("/holidays/import", calendarHandler.ImportHolidays)
+		authorized.DELETE("/holidays/:id", calendarHandler.DeleteHoliday)
+
+		authorized.POST("/catalog/clinic-types", catalogHandler.CreateClinicType)
+		authorized.PUT("/catalog/clinic-types/:id", catalogHandler.UpdateClinicType)
+		authorized.DELETE("/catalog/clinic-types/:id", catalogHandler.DeleteClinicType)
+		authorized.POST("/catalog/profession-groups", catalogHandler.CreateProfessionGroup)
+		authorized.PUT("/catalog/profession-groups/:id", catalogHandler.UpdateProfessionGroup)
+		authorized.DELETE("/catalog/profession-groups/:id", catalogHandler.DeleteProfessionGroup)
+		authorized.POST("/catalog/titles", catalogHandler.CreateTitle)
+		authorized.PUT("/catalog/titles/:id", catalogHandler.UpdateTitle)
+		authorized.DELETE("/catalog/titles/:id", catalogHandler.DeleteTitle)
+		authorized.GET("/clinics/trash", trashHandler.GetDeletedClinics)
+		authorized.POST("/clinics/:id/restore", trashHandler.RestoreClinic)
 
 		authorized.POST("/staff", staffHandler.CreateStaff)
 		authorized.PUT("/staff/:id", staffHandler.UpdateStaff)
 		authorized.DELETE("/staff/:id", staffHandler.DeleteStaff)
+		authorized.GET("/staff/trash", trashHandler.GetDeletedStaff)
+		authorized.POST("/staff/:id/restore", trashHandler.RestoreStaff)
+		authorized.POST("/staff/:id/transfer", staffHandler.TransferStaff)
+		authorized.POST("/staff/:id/status", employmentHandler.ChangeStatus)
+		authorized.PUT("/staff/:id/employment", employmentHandler.UpdateEmployment)
+		authorized.POST("/staff/:id/credentials", credentialHandler.CreateCredential)
+		authorized.PUT("/staff/:id/credentials/:credential_id", credentialHandler.UpdateCredential)
+		authorized.DELETE("/staff/:id/credentials/:credential_id", credentialHandler.DeleteCredential)
+		authorized.POST("/staff/imports", staffImportHandler.ImportStaff)
+		authorized.GET("/staff/imports/:id", staffImportHandler.GetImportJob)
+
+		authorized.POST("/staffing-rules", staffingRuleHandler.CreateRule)
+		authorized.PUT("/staffing-rules/:id", staffingRuleHandler.UpdateRule)
+		authorized.DELETE("/staffing-rules/:id", staffingRuleHandler.DeleteRule)
+
+		authorized.POST("/attendance/corrections", attendanceHandler.CorrectAttendance)
+		authorized.POST("/attendance/reconcile", attendanceHandler.ReconcileDay)
+		authorized.GET("/attendance/timesheet/export", attendanceHandler.ExportTimesheet)
+		authorized.POST("/attendance/kiosks", attendanceHandler.CreateKiosk)
+		authorized.GET("/attendance/kiosks", attendanceHandler.GetKiosks)
+		authorized.DELETE("/attendance/kiosks/:id", attendanceHandler.DeleteKiosk)
+	}
+
+	admin := router.Group("/admin")
+	admin.Use(middleware.AdminTokenRequired(cfg.Admin.Token))
+	{
+		admin.GET("/trash/hospitals", trashHandler.GetDeletedHospitals)
+		admin.POST("/hospitals/:id/restore", trashHandler.RestoreHospital)
+		admin.GET("/catalog/candidates", catalogHandler.GetPromotionCandidates)
+		admin.POST("/catalog/clinic-types/:id/promote", catalogHandler.PromoteClinicType)
+		admin.POST("/catalog/profession-groups/:id/promote", catalogHandler.PromoteProfessionGroup)
+		admin.POST("/catalog/titles/:id/promote", catalogHandler.PromoteTitle)
+
+		admin.GET("/jobs", jobHandler.GetJobs)
+		admin.GET("/jobs/runs", jobHandler.GetRuns)
+		admin.POST("/jobs/:name/run", jobHandler.Enqueue)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
 	"github.com/caner-cetin/hospital-tracker/internal/models"
 	"github.com/caner-cetin/hospital-tracker/internal/services"
 	"github.com/gin-gonic/gin"
@@ -21,7 +22,7 @@ func NewStaffHandler(staffService *services.StaffService) *StaffHandler {
 
 // CreateStaff godoc
 // @Summary Create a new staff member
-// @Description Create a new staff member in the hospital (requires authorization)
+// @Description Create a new staff member in the hospital, active unless status is onboarding, optionally with the employment contract (requires authorization)
 // @Tags Staff
 // @Accept json
 // @Produce json
@@ -44,12 +45,9 @@ func (h *StaffHandler) CreateStaff(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	staff, err := h.staffService.CreateStaff(&req, hospitalID)
+	staff, err := h.staffService.WithContext(requestContext(c)).CreateStaff(&req, hospitalID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "staff creation failed",
-			Message: err.Error(),
-		})
+		respondStaffMutationError(c, "staff creation failed", err)
 		return
 	}
 
@@ -91,12 +89,9 @@ func (h *StaffHandler) UpdateStaff(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	staff, err := h.staffService.UpdateStaff(staffID, &req, hospitalID)
+	staff, err := h.staffService.WithContext(requestContext(c)).UpdateStaff(staffID, &req, hospitalID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "staff update failed",
-			Message: err.Error(),
-		})
+		respondStaffMutationError(c, "staff update failed", err)
 		return
 	}
 
@@ -108,13 +103,13 @@ func (h *StaffHandler) UpdateStaff(c *gin.Context) {
 
 // DeleteStaff godoc
 // @Summary Delete a staff member
-// @Description Delete a staff member from the hospital (requires authorization)
+// @Description Delete an onboarding staff record entered by mistake (requires authorization). Staff with employment or status history are refused and should be terminated through the status endpoint instead, which keeps them available for reporting
 // @Tags Staff
 // @Produce json
 // @Security Bearer
 // @Param id path int true "Staff ID"
 // @Success 200 {object} map[string]string "Staff deleted successfully"
-// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 400 {object} models.ErrorResponse "Bad request or staff with employment history"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
 // @Failure 404 {object} models.ErrorResponse "Staff not found"
@@ -131,12 +126,9 @@ func (h *StaffHandler) DeleteStaff(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	err = h.staffService.DeleteStaff(uint(staffID), hospitalID)
+	err = h.staffService.WithContext(requestContext(c)).DeleteStaff(uint(staffID), hospitalID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "staff deletion failed",
-			Message: err.Error(),
-		})
+		respondStaffMutationError(c, "staff deletion failed", err)
 		return
 	}
 
@@ -147,14 +139,25 @@ func (h *StaffHandler) DeleteStaff(c *gin.Context) {
 
 // GetStaff godoc
 // @Summary Get staff members with filtering
-// @Description Get staff members with optional filtering by clinic, profession group, etc.
+// @Description Get staff members with optional filtering by clinic, profession group, etc. The q parameter searches names and national IDs ignoring Turkish casing and diacritics, matches prefixes and close misspellings, and ranks the best matches first. Results can be sorted by id, first_name, last_name, national_id, created_at and updated_at (prefix with - for descending) and paged by offset or with the returned cursors. The limit is capped at 100
 // @Tags Staff
 // @Produce json
 // @Security Bearer
-// @Param clinic_id query int false "Filter by clinic ID"
+// @Param q query string false "Search by name or national ID"
+// @Param clinic_id query string false "Filter by clinic ID, or unassigned for staff without a clinic"
 // @Param profession_group_id query int false "Filter by profession group ID"
+// @Param title_id query int false "Filter by title ID"
+// @Param working_day query string false "Filter by working day" Enums(monday, tuesday, wednesday, thursday, friday, saturday, sunday)
+// @Param status query string false "Comma separated lifecycle states (onboarding, active, on_leave, terminated) or all; terminated staff are left out by default"
+// @Param created_from query string false "Created on or after (YYYY-MM-DD or RFC 3339)"
+// @Param created_to query string false "Created on or before (YYYY-MM-DD or RFC 3339)"
+// @Param updated_from query string false "Updated on or after (YYYY-MM-DD or RFC 3339)"
+// @Param updated_to query string false "Updated on or before (YYYY-MM-DD or RFC 3339)"
+// @Param include_deleted query bool false "Include deleted staff"
+// @Param sort query string false "Comma separated sort fields, e.g. last_name,-created_at"
+// @Param cursor query string false "Cursor from next_cursor or prev_cursor of a previous page"
 // @Param page query int false "Page number for pagination"
-// @Param limit query int false "Number of items per page"
+// @Param limit query int false "Number of items per page (max 100)"
 // @Success 200 {object} models.StaffPaginatedResponse "List of staff members"
 // @Failure 400 {object} models.ErrorResponse "Bad request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
@@ -174,10 +177,7 @@ func (h *StaffHandler) GetStaff(c *gin.Context) {
 
 	result, err := h.staffService.GetStaff(&filter, hospitalID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "failed to fetch staff",
-			Message: err.Error(),
-		})
+		errors.HandleError(c, err)
 		return
 	}
 
@@ -186,11 +186,12 @@ func (h *StaffHandler) GetStaff(c *gin.Context) {
 
 // GetStaffByID godoc
 // @Summary Get a staff member by ID
-// @Description Get detailed information about a specific staff member
+// @Description Get detailed information about a specific staff member, or the record as it was at a point in time when as_of is given
 // @Tags Staff
 // @Produce json
 // @Security Bearer
 // @Param id path int true "Staff ID"
+// @Param as_of query string false "Date (YYYY-MM-DD) or RFC 3339 timestamp"
 // @Success 200 {object} models.Staff "Staff member information"
 // @Failure 400 {object} models.ErrorResponse "Bad request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
@@ -208,6 +209,23 @@ func (h *StaffHandler) GetStaffByID(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
+	var filter models.StaffAsOfRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+	if filter.AsOf != "" {
+		staff, err := h.staffService.GetStaffAsOf(uint(staffID), hospitalID, filter.AsOf)
+		if err != nil {
+			errors.HandleError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"staff": staff,
+		})
+		return
+	}
+
 	staff, err := h.staffService.GetStaffByID(uint(staffID), hospitalID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
@@ -222,6 +240,137 @@ func (h *StaffHandler) GetStaffByID(c *gin.Context) {
 	})
 }
 
+// TransferStaff godoc
+// @Summary Transfer a staff member to another clinic
+// @Description Close the current clinic assignment and open the next one; a null clinic_id removes the staff member from any clinic (requires authorization)
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Param request body models.TransferStaffRequest true "Transfer data"
+// @Success 200 {object} models.Staff "Staff transferred successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/transfer [post]
+func (h *StaffHandler) TransferStaff(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	var req models.TransferStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	staff, err := h.staffService.WithContext(requestContext(c)).TransferStaff(staffID, &req, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"staff":   staff,
+		"message": "Staff transferred successfully",
+	})
+}
+
+// GetStaffAssignments godoc
+// @Summary Get clinic assignment history of a staff member
+// @Description Get the dated clinic assignments of a staff member, newest first
+// @Tags Staff
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Success 200 {array} models.StaffClinicAssignment "Assignment history"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/assignments [get]
+func (h *StaffHandler) GetStaffAssignments(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	assignments, err := h.staffService.GetStaffAssignments(staffID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"assignments": assignments,
+	})
+}
+
+// GetStaffHistory godoc
+// @Summary Get version history of a staff member
+// @Description Get every version of a staff record, newest first, with the fields that changed from the version before
+// @Tags Staff
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Success 200 {object} models.StaffHistoryResponse "Version history"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Staff not found"
+// @Router /staff/{id}/history [get]
+func (h *StaffHandler) GetStaffHistory(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	history, err := h.staffService.GetStaffHistory(staffID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetClinicStaff godoc
+// @Summary Get staff of a clinic at a point in time
+// @Description Get the staff assigned to a clinic now, on a given date or at a given instant
+// @Tags Clinics
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Param as_of query string false "Date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Success 200 {array} models.Staff "Staff assigned to the clinic"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Clinic not found"
+// @Router /clinics/{id}/staff [get]
+func (h *StaffHandler) GetClinicStaff(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	var filter models.ClinicStaffFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	staff, err := h.staffService.GetClinicStaff(clinicID, c.GetUint("hospital_id"), filter.AsOf)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"staff": staff,
+	})
+}
+
 // GetProfessionGroups godoc
 // @Summary Get all profession groups
 // @Description Get all available profession groups for staff
@@ -244,3 +393,16 @@ func (h *StaffHandler) GetProfessionGroups(c *gin.Context) {
 		"profession_groups": professionGroups,
 	})
 }
+
+// respondStaffMutationError reports staffing rule violations and other typed
+// errors with their code and context, and everything else as a bad request.
+func respondStaffMutationError(c *gin.Context, message string, err error) {
+	if _, ok := errors.IsAppError(err); ok {
+		errors.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const maxStaffImportFileSize = 10 << 20
+
+type StaffImportHandler struct {
+	staffImportService *services.StaffImportService
+}
+
+func NewStaffImportHandler(staffImportService *services.StaffImportService) *StaffImportHandler {
+	return &StaffImportHandler{
+		staffImportService: staffImportService,
+	}
+}
+
+// ImportStaff godoc
+// @Summary Bulk import staff from CSV or XLSX
+// @Description Validate and import staff rows from a CSV or XLSX file. Columns: first_name, last_name, national_id, phone, profession_group, title, clinic, working_days; profession group, title and clinic accept a name or an ID. In dry-run mode nothing is stored. Rows are imported in one transaction only when every row is valid. Large files are processed in the background and a job is returned (requires authorization)
+// @Tags Staff
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run query bool false "Validate only, do not import"
+// @Success 200 {object} models.StaffImportResponse "Import report"
+// @Success 202 {object} models.StaffImportResponse "Import job accepted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /staff/imports [post]
+func (h *StaffImportHandler) ImportStaff(c *gin.Context) {
+	var req models.StaffImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		errors.RespondWithValidationError(c, "file", "file is required")
+		return
+	}
+	if fileHeader.Size > maxStaffImportFileSize {
+		errors.RespondWithValidationError(c, "file", "file must not exceed 10 MB")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError("failed to open uploaded file", err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError("failed to read uploaded file", err))
+		return
+	}
+
+	response, err := h.staffImportService.WithContext(requestContext(c)).Import(fileHeader.Filename, data, req.DryRun, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	if response.Job != nil {
+		c.JSON(http.StatusAccepted, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetImportJob godoc
+// @Summary Get a staff import job
+// @Description Poll the progress and report of a background staff import (requires authorization)
+// @Tags Staff
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Import job ID"
+// @Success 200 {object} models.StaffImportJob "Import job"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Import job not found"
+// @Router /staff/imports/{id} [get]
+func (h *StaffImportHandler) GetImportJob(c *gin.Context) {
+	jobID, ok := parseUintParam(c, "id", "invalid import job ID")
+	if !ok {
+		return
+	}
+
+	job, err := h.staffImportService.GetJob(jobID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": job,
+	})
+}
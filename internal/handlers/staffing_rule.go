@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type StaffingRuleHandler struct {
+	staffingRuleService *services.StaffingRuleService
+}
+
+func NewStaffingRuleHandler(staffingRuleService *services.StaffingRuleService) *StaffingRuleHandler {
+	return &StaffingRuleHandler{
+		staffingRuleService: staffingRuleService,
+	}
+}
+
+// GetRules godoc
+// @Summary Get staffing rules
+// @Description Get the platform default rules and the hospital's own rules that are checked on every staff change
+// @Tags Staffing Rules
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.StaffingRule "Staffing rules"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Router /staffing-rules [get]
+func (h *StaffingRuleHandler) GetRules(c *gin.Context) {
+	rules, err := h.staffingRuleService.GetRules(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+	})
+}
+
+// CreateRule godoc
+// @Summary Create a staffing rule
+// @Description Add a rule to the hospital. max_title_count caps the staff with a title in the hospital or in each clinic (clinic scope, optionally one clinic), min_profession_count keeps a minimum number of a profession group in each clinic once reached, and title_requires_clinic requires staff with a title to be assigned to a clinic. Staff changes that break a rule fail with BUSINESS_RULE_VIOLATION and the rule ID in the context (requires authorization)
+// @Tags Staffing Rules
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.StaffingRuleRequest true "Rule data"
+// @Success 201 {object} models.StaffingRule "Rule created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /staffing-rules [post]
+func (h *StaffingRuleHandler) CreateRule(c *gin.Context) {
+	var req models.StaffingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	rule, err := h.staffingRuleService.WithContext(requestContext(c)).CreateRule(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"rule":    rule,
+		"message": "Staffing rule created successfully",
+	})
+}
+
+// UpdateRule godoc
+// @Summary Replace a staffing rule
+// @Description Replace all fields of one of the hospital's rules. Platform default rules cannot be changed (requires authorization)
+// @Tags Staffing Rules
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Rule ID"
+// @Param request body models.StaffingRuleRequest true "Rule data"
+// @Success 200 {object} models.StaffingRule "Rule updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Rule not found"
+// @Router /staffing-rules/{id} [put]
+func (h *StaffingRuleHandler) UpdateRule(c *gin.Context) {
+	ruleID, ok := parseUintParam(c, "id", "invalid rule ID")
+	if !ok {
+		return
+	}
+
+	var req models.StaffingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	rule, err := h.staffingRuleService.WithContext(requestContext(c)).UpdateRule(ruleID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule":    rule,
+		"message": "Staffing rule updated successfully",
+	})
+}
+
+// DeleteRule godoc
+// @Summary Delete a staffing rule
+// @Description Delete one of the hospital's rules. Platform default rules cannot be deleted (requires authorization)
+// @Tags Staffing Rules
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Rule ID"
+// @Success 200 {object} map[string]string "Rule deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Rule not found"
+// @Router /staffing-rules/{id} [delete]
+func (h *StaffingRuleHandler) DeleteRule(c *gin.Context) {
+	ruleID, ok := parseUintParam(c, "id", "invalid rule ID")
+	if !ok {
+		return
+	}
+
+	if err := h.staffingRuleService.WithContext(requestContext(c)).DeleteRule(ruleID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Staffing rule deleted successfully",
+	})
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetDeletedStaff godoc
+// @Summary List deleted staff
+// @Description List the hospital's soft-deleted staff, most recently deleted first. Deleted staff are permanently erased once their retention period passes (requires authorization)
+// @Tags Trash
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.Staff "Deleted staff"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /staff/trash [get]
+func (h *TrashHandler) GetDeletedStaff(c *gin.Context) {
+	staff, err := h.trashService.GetDeletedStaff(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"staff":     staff,
+		"retention": h.trashService.Retention(),
+	})
+}
+
+// RestoreStaff godoc
+// @Summary Restore a deleted staff member
+// @Description Restore a soft-deleted staff member. Fails with a conflict when their national ID or phone has since been taken. The staff member returns to their last clinic if it still exists, subject to the staffing rules (requires authorization)
+// @Tags Trash
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Staff ID"
+// @Success 200 {object} models.Staff "Staff restored"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Deleted staff not found"
+// @Failure 409 {object} models.ErrorResponse "National ID or phone already in use"
+// @Router /staff/{id}/restore [post]
+func (h *TrashHandler) RestoreStaff(c *gin.Context) {
+	staffID, ok := parseUintParam(c, "id", "invalid staff ID")
+	if !ok {
+		return
+	}
+
+	staff, err := h.trashService.WithContext(requestContext(c)).RestoreStaff(staffID, c.GetUint("hospital_id"), c.GetUint("user_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"staff":   staff,
+		"message": "Staff restored successfully",
+	})
+}
+
+// GetDeletedUsers godoc
+// @Summary List deleted users
+// @Description List the hospital's soft-deleted users, most recently deleted first (requires authorization)
+// @Tags Trash
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.User "Deleted users"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /users/trash [get]
+func (h *TrashHandler) GetDeletedUsers(c *gin.Context) {
+	users, err := h.trashService.GetDeletedUsers(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":     users,
+		"retention": h.trashService.Retention(),
+	})
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Restore a soft-deleted user. Fails with a conflict when their national ID, email or phone has since been taken (requires authorization)
+// @Tags Trash
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} models.User "User restored"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Deleted user not found"
+// @Failure 409 {object} models.ErrorResponse "National ID, email or phone already in use"
+// @Router /users/{id}/restore [post]
+func (h *TrashHandler) RestoreUser(c *gin.Context) {
+	userID, ok := parseUintParam(c, "id", "invalid user ID")
+	if !ok {
+		return
+	}
+
+	user, err := h.trashService.WithContext(requestContext(c)).RestoreUser(userID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":    user,
+		"message": "User restored successfully",
+	})
+}
+
+// GetDeletedClinics godoc
+// @Summary List deleted clinics
+// @Description List the hospital's soft-deleted clinics, most recently deleted first (requires authorization)
+// @Tags Trash
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.Clinic "Deleted clinics"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /clinics/trash [get]
+func (h *TrashHandler) GetDeletedClinics(c *gin.Context) {
+	clinics, err := h.trashService.GetDeletedClinics(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinics":   clinics,
+		"retention": h.trashService.Retention(),
+	})
+}
+
+// RestoreClinic godoc
+// @Summary Restore a deleted clinic
+// @Description Restore a soft-deleted clinic. Fails when the hospital has since created another clinic of the same type. Staff are not moved back automatically (requires authorization)
+// @Tags Trash
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Clinic ID"
+// @Success 200 {object} models.Clinic "Clinic restored"
+// @Failure 400 {object} models.ErrorResponse "Bad request or clinic type already in use"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Deleted clinic not found"
+// @Router /clinics/{id}/restore [post]
+func (h *TrashHandler) RestoreClinic(c *gin.Context) {
+	clinicID, ok := parseUintParam(c, "id", "invalid clinic ID")
+	if !ok {
+		return
+	}
+
+	clinic, err := h.trashService.WithContext(requestContext(c)).RestoreClinic(clinicID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"clinic":  clinic,
+		"message": "Clinic restored successfully",
+	})
+}
+
+// GetDeletedHospitals godoc
+// @Summary List deleted hospitals
+// @Description List soft-deleted hospitals across the platform (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {array} models.Hospital "Deleted hospitals"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Router /admin/trash/hospitals [get]
+func (h *TrashHandler) GetDeletedHospitals(c *gin.Context) {
+	hospitals, err := h.trashService.GetDeletedHospitals()
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hospitals": hospitals,
+		"retention": h.trashService.Retention(),
+	})
+}
+
+// RestoreHospital godoc
+// @Summary Restore a deleted hospital
+// @Description Restore a soft-deleted hospital. Fails with a conflict when its tax ID, email or phone has since been taken (requires the admin token)
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Hospital ID"
+// @Success 200 {object} models.Hospital "Hospital restored"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} models.ErrorResponse "Admin endpoints disabled"
+// @Failure 404 {object} models.ErrorResponse "Deleted hospital not found"
+// @Failure 409 {object} models.ErrorResponse "Tax ID, email or phone already in use"
+// @Router /admin/hospitals/{id}/restore [post]
+func (h *TrashHandler) RestoreHospital(c *gin.Context) {
+	hospitalID, ok := parseUintParam(c, "id", "invalid hospital ID")
+	if !ok {
+		return
+	}
+
+	hospital, err := h.trashService.WithContext(requestContext(c)).RestoreHospital(hospitalID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hospital": hospital,
+		"message":  "Hospital restored successfully",
+	})
+}
@@ -45,7 +45,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	userID := c.GetUint("user_id")
 	hospitalID := c.GetUint("hospital_id")
 
-	user, err := h.userService.CreateUser(&req, userID, hospitalID)
+	user, err := h.userService.WithContext(requestContext(c)).CreateUser(&req, userID, hospitalID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "user creation failed",
@@ -92,7 +92,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	user, err := h.userService.UpdateUser(userIDToUpdate, &req, hospitalID)
+	user, err := h.userService.WithContext(requestContext(c)).UpdateUser(userIDToUpdate, &req, hospitalID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "user update failed",
@@ -132,7 +132,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	hospitalID := c.GetUint("hospital_id")
 
-	err = h.userService.DeleteUser(uint(userIDToDelete), hospitalID)
+	err = h.userService.WithContext(requestContext(c)).DeleteUser(uint(userIDToDelete), hospitalID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "user deletion failed",
@@ -148,18 +148,29 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 // GetUsers godoc
 // @Summary Get all users
-// @Description Get all users in the hospital
+// @Description Get all users in the hospital, optionally searched by name, email or national ID. Search ignores Turkish casing and diacritics, matches prefixes and close misspellings, and ranks the best matches first
 // @Tags Users
 // @Produce json
 // @Security Bearer
+// @Param q query string false "Search by name, email or national ID"
 // @Success 200 {array} models.User "List of users"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /users [get]
 func (h *UserHandler) GetUsers(c *gin.Context) {
 	hospitalID := c.GetUint("hospital_id")
 
-	users, err := h.userService.GetUsers(hospitalID)
+	var filter models.UserFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid query parameters",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	users, err := h.userService.GetUsers(hospitalID, &filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "failed to fetch users",
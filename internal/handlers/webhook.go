@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetSubscriptions godoc
+// @Summary Get webhook subscriptions
+// @Description Get the hospital's webhook subscriptions. Secrets are never returned (requires authorization)
+// @Tags Webhooks
+// @Produce json
+// @Security Bearer
+// @Success 200 {array} models.WebhookSubscription "Webhook subscriptions"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetSubscriptions(c *gin.Context) {
+	subscriptions, err := h.webhookService.GetSubscriptions(c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscriptions": subscriptions,
+	})
+}
+
+// CreateSubscription godoc
+// @Summary Create a webhook subscription
+// @Description Send the hospital's events to a URL. event_types filters the events; empty subscribes to all of them. Every request is signed with HMAC-SHA256 over "<X-Webhook-Timestamp>.<body>" in X-Webhook-Signature. The secret is only shown in this response (requires authorization)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.CreateWebhookRequest true "Subscription data"
+// @Success 201 {object} models.WebhookSecretResponse "Subscription created"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	result, err := h.webhookService.WithContext(requestContext(c)).CreateSubscription(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// UpdateSubscription godoc
+// @Summary Update a webhook subscription
+// @Description Change the URL, event filter, description or active flag of a subscription. Deliveries of an inactive subscription wait until it is active again (requires authorization)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Subscription ID"
+// @Param request body models.UpdateWebhookRequest true "Fields to change"
+// @Success 200 {object} models.WebhookSubscription "Subscription updated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	subscriptionID, ok := parseUintParam(c, "id", "invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	subscription, err := h.webhookService.WithContext(requestContext(c)).UpdateSubscription(subscriptionID, &req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscription": subscription,
+		"message":      "Webhook subscription updated successfully",
+	})
+}
+
+// RotateSecret godoc
+// @Summary Rotate a webhook secret
+// @Description Replace the signing secret of a subscription. The new secret is only shown in this response (requires authorization)
+// @Tags Webhooks
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} models.WebhookSecretResponse "Secret rotated"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	subscriptionID, ok := parseUintParam(c, "id", "invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.webhookService.WithContext(requestContext(c)).RotateSecret(subscriptionID, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook subscription
+// @Description Delete a subscription and drop its pending deliveries (requires authorization)
+// @Tags Webhooks
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} map[string]string "Subscription deleted"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	subscriptionID, ok := parseUintParam(c, "id", "invalid subscription ID")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.WithContext(requestContext(c)).DeleteSubscription(subscriptionID, c.GetUint("hospital_id")); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook subscription deleted successfully",
+	})
+}
+
+// GetDeliveries godoc
+// @Summary Get webhook deliveries
+// @Description List deliveries newest first with their attempts and last error. status=dead lists the dead letters that failed every attempt (requires authorization)
+// @Tags Webhooks
+// @Produce json
+// @Security Bearer
+// @Param status query string false "pending, delivered, dead or replayed"
+// @Param subscription_id query int false "Subscription ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} models.WebhookDeliveryPaginatedResponse "Webhook deliveries"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	var filter models.WebhookDeliveryFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errors.RespondWithValidationError(c, "query", err.Error())
+		return
+	}
+
+	result, err := h.webhookService.GetDeliveries(&filter, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Replay godoc
+// @Summary Replay webhook events
+// @Description Queue events again, chosen by event_ids or by a from/to time range (at most 1000), for one subscription or every active subscription that accepts them. Dead letters of the replayed events are marked replayed (requires authorization)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.WebhookReplayRequest true "Events to replay"
+// @Success 202 {object} models.WebhookReplayResponse "Events queued"
+// @Failure 400 {object} models.ErrorResponse "Bad request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Subscription not found"
+// @Router /webhooks/replay [post]
+func (h *WebhookHandler) Replay(c *gin.Context) {
+	var req models.WebhookReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.RespondWithValidationError(c, "request", err.Error())
+		return
+	}
+
+	result, err := h.webhookService.WithContext(requestContext(c)).Replay(&req, c.GetUint("hospital_id"))
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, result)
+}
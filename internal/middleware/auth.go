@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"strings"
 
@@ -41,6 +42,26 @@ func AuthRequired(authService *services.AuthService) gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("hospital_id", claims.HospitalID)
 		c.Set("user_type", claims.UserType)
+
+		actor := services.AuditActorFrom(c.Request.Context())
+		actor.UserID = &claims.UserID
+		actor.HospitalID = &claims.HospitalID
+		if actor.IP == "" {
+			actor.IP = c.ClientIP()
+		}
+		c.Request = c.Request.WithContext(services.WithAuditActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// QueryToken lets clients that cannot set headers, such as the browser's
+// EventSource, pass the JWT in the access_token query parameter. It runs
+// before AuthRequired and never overrides an Authorization header.
+func QueryToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query("access_token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
 		c.Next()
 	}
 }
@@ -67,3 +88,29 @@ func AuthorizedOnly() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminTokenRequired guards platform administration endpoints with a shared
+// token sent in the X-Admin-Token header. The endpoints are disabled when no
+// token is configured.
+func AdminTokenRequired(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error: "admin endpoints are disabled",
+			})
+			c.Abort()
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error: "invalid admin token",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceEventType string
+
+const (
+	AttendanceClockIn  AttendanceEventType = "clock_in"
+	AttendanceClockOut AttendanceEventType = "clock_out"
+)
+
+type AttendanceSource string
+
+const (
+	AttendanceSourceAPI             AttendanceSource = "api"
+	AttendanceSourceKiosk           AttendanceSource = "kiosk"
+	AttendanceSourceAdminCorrection AttendanceSource = "admin_correction"
+)
+
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent    AttendanceStatus = "present"
+	AttendanceStatusAbsent     AttendanceStatus = "absent"
+	AttendanceStatusIncomplete AttendanceStatus = "incomplete"
+	AttendanceStatusPending    AttendanceStatus = "pending"
+	AttendanceStatusOff        AttendanceStatus = "off"
+)
+
+type AttendanceKiosk struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	HospitalID uint           `json:"hospital_id" gorm:"not null;index"`
+	Name       string         `json:"name" gorm:"not null"`
+	TokenHash  string         `json:"-" gorm:"not null;unique"`
+	LastUsedAt *time.Time     `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+type AttendanceEvent struct {
+	ID           uint                `json:"id" gorm:"primaryKey"`
+	HospitalID   uint                `json:"hospital_id" gorm:"not null;index"`
+	StaffID      uint                `json:"staff_id" gorm:"not null;index:idx_attendance_events_staff_time"`
+	Type         AttendanceEventType `json:"type" gorm:"not null"`
+	Source       AttendanceSource    `json:"source" gorm:"not null"`
+	OccurredAt   time.Time           `json:"occurred_at" gorm:"not null;index:idx_attendance_events_staff_time"`
+	KioskID      *uint               `json:"kiosk_id,omitempty"`
+	RecordedByID *uint               `json:"recorded_by_id,omitempty"`
+	Note         string              `json:"note,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// AttendanceDay is the reconciled outcome of one staff member's day compared
+// with their scheduled working days.
+type AttendanceDay struct {
+	ID               uint             `json:"id" gorm:"primaryKey"`
+	HospitalID       uint             `json:"hospital_id" gorm:"not null;index"`
+	StaffID          uint             `json:"staff_id" gorm:"not null;uniqueIndex:idx_attendance_days_staff_date"`
+	Date             time.Time        `json:"date" gorm:"type:date;not null;uniqueIndex:idx_attendance_days_staff_date"`
+	Scheduled        bool             `json:"scheduled"`
+	Status           AttendanceStatus `json:"status" gorm:"not null"`
+	FirstClockIn     *time.Time       `json:"first_clock_in,omitempty"`
+	LastClockOut     *time.Time       `json:"last_clock_out,omitempty"`
+	ScheduledMinutes int              `json:"scheduled_minutes"`
+	WorkedMinutes    int              `json:"worked_minutes"`
+	LateMinutes      int              `json:"late_minutes"`
+	OvertimeMinutes  int              `json:"overtime_minutes"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+type ClockRequest struct {
+	StaffID uint   `json:"staff_id" binding:"required"`
+	Note    string `json:"note"`
+}
+
+type KioskClockRequest struct {
+	NationalID string `json:"national_id" binding:"required"`
+}
+
+type AttendanceCorrectionRequest struct {
+	StaffID    uint                `json:"staff_id" binding:"required"`
+	Type       AttendanceEventType `json:"type" binding:"required,oneof=clock_in clock_out"`
+	OccurredAt time.Time           `json:"occurred_at" binding:"required"`
+	Note       string              `json:"note" binding:"required"`
+}
+
+type CreateKioskRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type CreateKioskResponse struct {
+	Kiosk AttendanceKiosk `json:"kiosk"`
+	Token string          `json:"token"`
+}
+
+type AttendanceEventFilterRequest struct {
+	StaffID uint   `form:"staff_id"`
+	From    string `form:"from"`
+	To      string `form:"to"`
+}
+
+type TimesheetEntry struct {
+	StaffID          uint   `json:"staff_id"`
+	FirstName        string `json:"first_name"`
+	LastName         string `json:"last_name"`
+	NationalID       string `json:"national_id"`
+	ScheduledDays    int    `json:"scheduled_days"`
+	PresentDays      int    `json:"present_days"`
+	AbsentDays       int    `json:"absent_days"`
+	LateDays         int    `json:"late_days"`
+	IncompleteDays   int    `json:"incomplete_days"`
+	ScheduledMinutes int    `json:"scheduled_minutes"`
+	WorkedMinutes    int    `json:"worked_minutes"`
+	LateMinutes      int    `json:"late_minutes"`
+	OvertimeMinutes  int    `json:"overtime_minutes"`
+}
+
+type TimesheetResponse struct {
+	HospitalID uint             `json:"hospital_id"`
+	Month      string           `json:"month"`
+	Entries    []TimesheetEntry `json:"entries"`
+}
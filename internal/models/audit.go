@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditCreate    AuditAction = "create"
+	AuditUpdate    AuditAction = "update"
+	AuditDelete    AuditAction = "delete"
+	AuditBulkWrite AuditAction = "bulk_write"
+)
+
+// AuditChanges is the JSON document of an audit entry's before and after
+// values. It is stored as text so the hashed bytes survive unchanged, and
+// embedded as JSON when the entry is rendered.
+type AuditChanges string
+
+func (c AuditChanges) MarshalJSON() ([]byte, error) {
+	if c == "" {
+		return []byte("null"), nil
+	}
+	return []byte(c), nil
+}
+
+// AuditLog is one write to an audited table. Each entry stores the hash of
+// the previous one and its own hash over both, so altering or removing an
+// entry breaks the chain from there on.
+type AuditLog struct {
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	HospitalID  *uint        `json:"hospital_id,omitempty" gorm:"index"`
+	ActorUserID *uint        `json:"actor_user_id,omitempty" gorm:"index"`
+	Action      AuditAction  `json:"action" gorm:"not null"`
+	Resource    string       `json:"resource" gorm:"not null;index:idx_audit_logs_resource,priority:1"`
+	ResourceID  *uint        `json:"resource_id,omitempty" gorm:"index:idx_audit_logs_resource,priority:2"`
+	Changes     AuditChanges `json:"changes" gorm:"type:text;not null"`
+	RequestID   string       `json:"request_id,omitempty" gorm:"index"`
+	IP          string       `json:"ip,omitempty"`
+	PrevHash    string       `json:"prev_hash" gorm:"type:varchar(64);not null"`
+	Hash        string       `json:"hash" gorm:"type:varchar(64);not null;uniqueIndex"`
+	CreatedAt   time.Time    `json:"created_at" gorm:"index"`
+}
+
+type AuditFilterRequest struct {
+	ActorUserID uint        `form:"actor_user_id"`
+	Action      AuditAction `form:"action" binding:"omitempty,oneof=create update delete bulk_write"`
+	Resource    string      `form:"resource"`
+	ResourceID  uint        `form:"resource_id"`
+	RequestID   string      `form:"request_id"`
+	From        string      `form:"from"`
+	To          string      `form:"to"`
+	Page        int         `form:"page,default=1"`
+	Limit       int         `form:"limit,default=50"`
+}
+
+type AuditLogPaginatedResponse struct {
+	Data []AuditLog `json:"data"`
+	BasePagination
+}
+
+// AuditVerification is the outcome of walking the hash chain. BrokenAt is
+// the first entry whose link or hash does not match.
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Checked  int64  `json:"checked"`
+	BrokenAt *uint  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
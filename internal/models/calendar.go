@@ -0,0 +1,130 @@
+package models
+
+import (
+	"time"
+)
+
+type HolidaySource string
+
+const (
+	HolidayManual   HolidaySource = "manual"
+	HolidayNational HolidaySource = "national"
+)
+
+// ClinicOpeningHours is one opening period of a clinic on a weekday, in the
+// hospital's local time. A day can have several periods, e.g. around a lunch
+// break, and "24:00" closes a period at midnight.
+type ClinicOpeningHours struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	ClinicID   uint       `json:"clinic_id" gorm:"not null;index"`
+	HospitalID uint       `json:"hospital_id" gorm:"not null;index"`
+	Weekday    WorkingDay `json:"weekday" gorm:"not null"`
+	OpensAt    string     `json:"opens_at" gorm:"type:varchar(5);not null"`
+	ClosesAt   string     `json:"closes_at" gorm:"type:varchar(5);not null"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// HospitalHoliday closes every clinic of the hospital that is not marked to
+// stay open on holidays. Half-day holidays such as bayram eves set ClosesAt,
+// and clinics close at that time instead of staying closed all day.
+type HospitalHoliday struct {
+	ID         uint          `json:"id" gorm:"primaryKey"`
+	HospitalID uint          `json:"hospital_id" gorm:"not null;uniqueIndex:idx_hospital_holidays_date"`
+	Date       time.Time     `json:"date" gorm:"type:date;not null;uniqueIndex:idx_hospital_holidays_date"`
+	Name       string        `json:"name" gorm:"not null"`
+	ClosesAt   string        `json:"closes_at,omitempty" gorm:"type:varchar(5);not null;default:''"`
+	Source     HolidaySource `json:"source" gorm:"not null;default:'manual'"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// ClinicClosure is an ad-hoc closure of a clinic, e.g. for renovation or a
+// staff shortage. It overrides opening hours and holidays.
+type ClinicClosure struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ClinicID    uint      `json:"clinic_id" gorm:"not null;index"`
+	HospitalID  uint      `json:"hospital_id" gorm:"not null;index"`
+	StartsAt    time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt      time.Time `json:"ends_at" gorm:"not null"`
+	Reason      string    `json:"reason" gorm:"not null"`
+	CreatedByID *uint     `json:"created_by_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type OpeningHoursPeriod struct {
+	Weekday  WorkingDay `json:"weekday" binding:"required,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	OpensAt  string     `json:"opens_at" binding:"required"`
+	ClosesAt string     `json:"closes_at" binding:"required"`
+}
+
+// OpeningHoursRequest replaces the whole weekly schedule of a clinic.
+// Weekdays without a period are closed.
+type OpeningHoursRequest struct {
+	OpenOnHolidays bool                 `json:"open_on_holidays"`
+	Periods        []OpeningHoursPeriod `json:"periods" binding:"dive"`
+}
+
+type OpeningHoursResponse struct {
+	OpenOnHolidays bool                 `json:"open_on_holidays"`
+	Periods        []ClinicOpeningHours `json:"periods"`
+}
+
+type HolidayRequest struct {
+	Date     time.Time `json:"date" binding:"required" example:"2026-04-23T00:00:00Z"`
+	Name     string    `json:"name" binding:"required"`
+	ClosesAt string    `json:"closes_at,omitempty"`
+}
+
+type HolidayImportRequest struct {
+	Year int `json:"year" binding:"required"`
+}
+
+type HolidayFilterRequest struct {
+	Year int `form:"year"`
+}
+
+type ClinicClosureRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason" binding:"required"`
+}
+
+type ClinicCalendarRequest struct {
+	From string `form:"from" binding:"required"`
+	To   string `form:"to" binding:"required"`
+}
+
+type ClinicOpenRequest struct {
+	At string `form:"at"`
+}
+
+type OpenPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ClinicCalendarDay is the resolved schedule of a clinic on one local date
+// after holidays and closures are applied.
+type ClinicCalendarDay struct {
+	Date     string           `json:"date"`
+	Weekday  WorkingDay       `json:"weekday"`
+	Open     []OpenPeriod     `json:"open"`
+	Holiday  *HospitalHoliday `json:"holiday,omitempty"`
+	Closures []ClinicClosure  `json:"closures,omitempty"`
+}
+
+type ClinicOpenStatus struct {
+	ClinicID uint             `json:"clinic_id"`
+	At       time.Time        `json:"at"`
+	Open     bool             `json:"open"`
+	Period   *OpenPeriod      `json:"period,omitempty"`
+	Holiday  *HospitalHoliday `json:"holiday,omitempty"`
+	Closure  *ClinicClosure   `json:"closure,omitempty"`
+}
+
+type HolidayImportResponse struct {
+	Imported int               `json:"imported"`
+	Holidays []HospitalHoliday `json:"holidays"`
+}
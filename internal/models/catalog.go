@@ -0,0 +1,33 @@
+package models
+
+type CatalogKind string
+
+const (
+	CatalogClinicType      CatalogKind = "clinic_type"
+	CatalogProfessionGroup CatalogKind = "profession_group"
+	CatalogTitle           CatalogKind = "title"
+)
+
+type CatalogEntryRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type CreateTitleRequest struct {
+	Name              string `json:"name" binding:"required"`
+	ProfessionGroupID uint   `json:"profession_group_id" binding:"required"`
+}
+
+type CatalogCandidateFilterRequest struct {
+	MinHospitals int `form:"min_hospitals,default=2" binding:"min=1"`
+}
+
+// CatalogPromotionCandidate is a private catalogue entry that several
+// hospitals added under the same name. EntryID is the oldest of them and can
+// be promoted to a global entry, which merges the others into it.
+type CatalogPromotionCandidate struct {
+	Kind              CatalogKind `json:"kind"`
+	Name              string      `json:"name"`
+	ProfessionGroupID *uint       `json:"profession_group_id,omitempty"`
+	Hospitals         int64       `json:"hospitals"`
+	EntryID           uint        `json:"entry_id"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ClinicRoomKind string
+
+const (
+	ClinicRoomExamination ClinicRoomKind = "examination_room"
+	ClinicRoomDesk        ClinicRoomKind = "desk"
+	ClinicRoomWard        ClinicRoomKind = "ward"
+	ClinicRoomProcedure   ClinicRoomKind = "procedure_room"
+)
+
+// ClinicRoom is a room or examination desk of a clinic. Capacity is the
+// number of patients or beds it serves at once.
+type ClinicRoom struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	ClinicID   uint           `json:"clinic_id" gorm:"not null;uniqueIndex:idx_clinic_rooms_clinic_name,where:deleted_at IS NULL"`
+	HospitalID uint           `json:"hospital_id" gorm:"not null;index"`
+	Name       string         `json:"name" gorm:"not null;uniqueIndex:idx_clinic_rooms_clinic_name,where:deleted_at IS NULL"`
+	Kind       ClinicRoomKind `json:"kind" gorm:"not null"`
+	Capacity   int            `json:"capacity" gorm:"not null"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// UpdateClinicRequest replaces the editable details of a clinic. A nil
+// HeadStaffID removes the head of clinic.
+type UpdateClinicRequest struct {
+	Name           string `json:"name"`
+	Floor          string `json:"floor"`
+	Building       string `json:"building"`
+	PhoneExtension string `json:"phone_extension" binding:"omitempty,numeric,max=10"`
+	HeadStaffID    *uint  `json:"head_staff_id"`
+}
+
+type ClinicRoomRequest struct {
+	Name     string         `json:"name" binding:"required"`
+	Kind     ClinicRoomKind `json:"kind" binding:"required,oneof=examination_room desk ward procedure_room"`
+	Capacity int            `json:"capacity" binding:"required,min=1"`
+}
+
+type ClinicHeadSummary struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Title     string `json:"title"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CredentialType string
+
+const (
+	CredentialDiplomaRegistration  CredentialType = "diploma_registration"
+	CredentialSpecialtyCertificate CredentialType = "specialty_certificate"
+	CredentialCertification        CredentialType = "certification"
+	CredentialLicense              CredentialType = "license"
+)
+
+type CredentialStatus string
+
+const (
+	CredentialValid        CredentialStatus = "valid"
+	CredentialExpiringSoon CredentialStatus = "expiring_soon"
+	CredentialExpired      CredentialStatus = "expired"
+)
+
+// StaffCredential is a license or certification held by a staff member.
+// Credentials without an expiry date, such as a diploma registration, never
+// expire. NotifiedStatus records the last expiry notification so the daily
+// check sends each one only once.
+type StaffCredential struct {
+	ID             uint             `json:"id" gorm:"primaryKey"`
+	StaffID        uint             `json:"staff_id" gorm:"not null;index"`
+	HospitalID     uint             `json:"hospital_id" gorm:"not null;index"`
+	Type           CredentialType   `json:"type" gorm:"not null"`
+	Name           string           `json:"name" gorm:"not null"`
+	Number         string           `json:"number" gorm:"not null"`
+	IssuingBody    string           `json:"issuing_body" gorm:"not null"`
+	IssuedAt       time.Time        `json:"issued_at" gorm:"type:date;not null"`
+	ExpiresAt      *time.Time       `json:"expires_at,omitempty" gorm:"type:date;index"`
+	DocumentRef    string           `json:"document_ref,omitempty"`
+	Mandatory      bool             `json:"mandatory" gorm:"not null;default:false"`
+	Status         CredentialStatus `json:"status" gorm:"-"`
+	NotifiedStatus CredentialStatus `json:"-" gorm:"not null;default:''"`
+	Staff          *Staff           `json:"staff,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+type CredentialRequest struct {
+	Type        CredentialType `json:"type" binding:"required,oneof=diploma_registration specialty_certificate certification license"`
+	Name        string         `json:"name" binding:"required"`
+	Number      string         `json:"number" binding:"required"`
+	IssuingBody string         `json:"issuing_body" binding:"required"`
+	IssuedAt    time.Time      `json:"issued_at" binding:"required"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	DocumentRef string         `json:"document_ref,omitempty"`
+	Mandatory   bool           `json:"mandatory"`
+}
+
+type ExpiringCredentialFilterRequest struct {
+	Days int `form:"days,default=30" binding:"min=0,max=365"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type DashboardProfessionHeadcount struct {
+	ProfessionGroupID uint   `json:"profession_group_id"`
+	ProfessionGroup   string `json:"profession_group"`
+	Count             int64  `json:"count"`
+}
+
+type DashboardTitleHeadcount struct {
+	TitleID         uint   `json:"title_id"`
+	Title           string `json:"title"`
+	ProfessionGroup string `json:"profession_group"`
+	Count           int64  `json:"count"`
+}
+
+type DashboardClinicHeadcount struct {
+	ClinicID uint   `json:"clinic_id"`
+	Name     string `json:"name"`
+	Count    int64  `json:"count"`
+	Doctors  int64  `json:"doctors"`
+}
+
+type DashboardStaffSummary struct {
+	ID              uint        `json:"id"`
+	FirstName       string      `json:"first_name"`
+	LastName        string      `json:"last_name"`
+	Status          StaffStatus `json:"status"`
+	ProfessionGroup string      `json:"profession_group"`
+	Title           string      `json:"title"`
+}
+
+// DashboardStaffChanges counts the staff added and the staff deleted or
+// terminated since the start of the window.
+type DashboardStaffChanges struct {
+	Since   time.Time `json:"since"`
+	Added   int64     `json:"added"`
+	Removed int64     `json:"removed"`
+}
+
+// DashboardResponse is the hospital overview. Headcounts leave out
+// terminated staff.
+type DashboardResponse struct {
+	GeneratedAt           time.Time                      `json:"generated_at"`
+	Headcount             int64                          `json:"headcount"`
+	ByProfessionGroup     []DashboardProfessionHeadcount `json:"by_profession_group"`
+	ByTitle               []DashboardTitleHeadcount      `json:"by_title"`
+	ByClinic              []DashboardClinicHeadcount     `json:"by_clinic"`
+	ClinicsWithoutDoctors []DashboardClinicHeadcount     `json:"clinics_without_doctors"`
+	UnassignedStaff       []DashboardStaffSummary        `json:"unassigned_staff"`
+	StaffChanges          DashboardStaffChanges          `json:"staff_changes"`
+	UsersByType           map[UserType]int64             `json:"users_by_type"`
+}
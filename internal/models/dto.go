@@ -3,13 +3,16 @@ package models
 import "time"
 
 type HospitalRegistrationRequest struct {
-	HospitalName string `json:"hospital_name" binding:"required"`
-	TaxID        string `json:"tax_id" binding:"required"`
-	Email        string `json:"email" binding:"required,email"`
-	Phone        string `json:"phone" binding:"required"`
-	ProvinceID   uint   `json:"province_id" binding:"required"`
-	DistrictID   uint   `json:"district_id" binding:"required"`
-	Address      string `json:"address" binding:"required"`
+	HospitalName    string   `json:"hospital_name" binding:"required"`
+	TaxID           string   `json:"tax_id" binding:"required"`
+	Email           string   `json:"email" binding:"required,email"`
+	Phone           string   `json:"phone" binding:"required"`
+	ProvinceID      uint     `json:"province_id" binding:"required"`
+	DistrictID      uint     `json:"district_id" binding:"required"`
+	NeighbourhoodID *uint    `json:"neighbourhood_id"`
+	Address         string   `json:"address" binding:"required"`
+	Latitude        *float64 `json:"latitude" binding:"omitempty,gte=-90,lte=90"`
+	Longitude       *float64 `json:"longitude" binding:"omitempty,gte=-180,lte=180"`
 
 	FirstName  string `json:"first_name" binding:"required"`
 	LastName   string `json:"last_name" binding:"required"`
@@ -69,14 +72,16 @@ type CreateClinicRequest struct {
 }
 
 type CreateStaffRequest struct {
-	FirstName         string       `json:"first_name" binding:"required"`
-	LastName          string       `json:"last_name" binding:"required"`
-	NationalID        string       `json:"national_id" binding:"required"`
-	Phone             string       `json:"phone" binding:"required"`
-	ProfessionGroupID uint         `json:"profession_group_id" binding:"required"`
-	TitleID           uint         `json:"title_id" binding:"required"`
-	ClinicID          *uint        `json:"clinic_id,omitempty"`
-	WorkingDays       []WorkingDay `json:"working_days"`
+	FirstName         string             `json:"first_name" binding:"required"`
+	LastName          string             `json:"last_name" binding:"required"`
+	NationalID        string             `json:"national_id" binding:"required"`
+	Phone             string             `json:"phone" binding:"required"`
+	ProfessionGroupID uint               `json:"profession_group_id" binding:"required"`
+	TitleID           uint               `json:"title_id" binding:"required"`
+	ClinicID          *uint              `json:"clinic_id,omitempty"`
+	WorkingDays       []WorkingDay       `json:"working_days"`
+	Status            StaffStatus        `json:"status,omitempty" binding:"omitempty,oneof=onboarding active"`
+	Employment        *EmploymentRequest `json:"employment,omitempty"`
 }
 
 type UpdateStaffRequest struct {
@@ -90,14 +95,54 @@ type UpdateStaffRequest struct {
 	WorkingDays       []WorkingDay `json:"working_days"`
 }
 
+type TransferStaffRequest struct {
+	ClinicID    *uint      `json:"clinic_id"`
+	EffectiveAt *time.Time `json:"effective_at,omitempty"`
+	Reason      string     `json:"reason" binding:"required"`
+}
+
+type ClinicStaffFilterRequest struct {
+	AsOf string `form:"as_of"`
+}
+
+type ClinicFilterRequest struct {
+	Page  int `form:"page,default=1"`
+	Limit int `form:"limit,default=20"`
+}
+
 type StaffFilterRequest struct {
-	FirstName         string `form:"first_name"`
-	LastName          string `form:"last_name"`
-	NationalID        string `form:"national_id"`
-	ProfessionGroupID uint   `form:"profession_group_id"`
-	TitleID           uint   `form:"title_id"`
-	Page              int    `form:"page,default=1"`
-	Limit             int    `form:"limit,default=10"`
+	Q                 string     `form:"q"`
+	FirstName         string     `form:"first_name"`
+	LastName          string     `form:"last_name"`
+	NationalID        string     `form:"national_id"`
+	ProfessionGroupID uint       `form:"profession_group_id"`
+	TitleID           uint       `form:"title_id"`
+	ClinicID          string     `form:"clinic_id" binding:"omitempty,number|eq=unassigned"`
+	WorkingDay        WorkingDay `form:"working_day" binding:"omitempty,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	Status            string     `form:"status"`
+	CreatedFrom       string     `form:"created_from"`
+	CreatedTo         string     `form:"created_to"`
+	UpdatedFrom       string     `form:"updated_from"`
+	UpdatedTo         string     `form:"updated_to"`
+	IncludeDeleted    bool       `form:"include_deleted"`
+	Sort              string     `form:"sort"`
+	Cursor            string     `form:"cursor"`
+	Page              int        `form:"page,default=1"`
+	Limit             int        `form:"limit,default=10"`
+}
+
+type UserFilterRequest struct {
+	Q string `form:"q"`
+}
+
+type StaffExportRequest struct {
+	StaffFilterRequest
+	Format  string `form:"format,default=csv" binding:"omitempty,oneof=csv xlsx pdf"`
+	Columns string `form:"columns"`
+}
+
+type ClinicExportRequest struct {
+	Format string `form:"format,default=csv" binding:"omitempty,oneof=csv xlsx"`
 }
 
 type BasePagination struct {
@@ -110,6 +155,8 @@ type BasePagination struct {
 type StaffPaginatedResponse struct {
 	Data []Staff `json:"data"`
 	BasePagination
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 type UserPaginatedResponse struct {
@@ -118,13 +165,20 @@ type UserPaginatedResponse struct {
 }
 
 type ClinicPaginatedResponse struct {
-	Data []Clinic `json:"data"`
+	Data []ClinicSummary `json:"data"`
 	BasePagination
 }
 
 type ClinicSummary struct {
 	ID                uint                     `json:"id"`
 	ClinicType        ClinicType               `json:"clinic_type"`
+	Name              string                   `json:"name"`
+	Floor             string                   `json:"floor"`
+	Building          string                   `json:"building"`
+	PhoneExtension    string                   `json:"phone_extension"`
+	HeadOfClinic      *ClinicHeadSummary       `json:"head_of_clinic,omitempty"`
+	Rooms             []ClinicRoom             `json:"rooms"`
+	TotalCapacity     int                      `json:"total_capacity"`
 	TotalStaff        int64                    `json:"total_staff"`
 	StaffByProfession []StaffProfessionSummary `json:"staff_by_profession"`
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+)
+
+type StaffStatus string
+
+const (
+	StaffOnboarding StaffStatus = "onboarding"
+	StaffActive     StaffStatus = "active"
+	StaffOnLeave    StaffStatus = "on_leave"
+	StaffTerminated StaffStatus = "terminated"
+)
+
+type ContractType string
+
+const (
+	ContractPermanent  ContractType = "permanent"
+	ContractContracted ContractType = "contracted"
+	ContractLocum      ContractType = "locum"
+)
+
+// StaffEmployment is one period of employment of a staff member. The current
+// employment has no TerminatedAt; a rehired staff member gets a new record so
+// earlier contracts stay available for reporting.
+type StaffEmployment struct {
+	ID                uint         `json:"id" gorm:"primaryKey"`
+	StaffID           uint         `json:"staff_id" gorm:"not null;index"`
+	HospitalID        uint         `json:"hospital_id" gorm:"not null;index"`
+	ContractType      ContractType `json:"contract_type" gorm:"not null"`
+	HireDate          time.Time    `json:"hire_date" gorm:"type:date;not null"`
+	ProbationEndsAt   *time.Time   `json:"probation_ends_at,omitempty" gorm:"type:date"`
+	ContractEndsAt    *time.Time   `json:"contract_ends_at,omitempty" gorm:"type:date"`
+	TerminatedAt      *time.Time   `json:"terminated_at,omitempty" gorm:"type:date"`
+	TerminationReason string       `json:"termination_reason,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+// StaffStatusChange records a lifecycle transition of a staff member.
+type StaffStatusChange struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	StaffID     uint        `json:"staff_id" gorm:"not null;index"`
+	HospitalID  uint        `json:"hospital_id" gorm:"not null;index"`
+	FromStatus  StaffStatus `json:"from_status" gorm:"not null"`
+	ToStatus    StaffStatus `json:"to_status" gorm:"not null"`
+	Reason      string      `json:"reason,omitempty"`
+	EffectiveAt time.Time   `json:"effective_at" gorm:"not null"`
+	CreatedByID *uint       `json:"created_by_id,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type EmploymentRequest struct {
+	ContractType    ContractType `json:"contract_type" binding:"required,oneof=permanent contracted locum"`
+	HireDate        time.Time    `json:"hire_date" binding:"required"`
+	ProbationEndsAt *time.Time   `json:"probation_ends_at,omitempty"`
+	ContractEndsAt  *time.Time   `json:"contract_ends_at,omitempty"`
+}
+
+type ChangeStaffStatusRequest struct {
+	Status      StaffStatus        `json:"status" binding:"required,oneof=onboarding active on_leave terminated"`
+	Reason      string             `json:"reason"`
+	EffectiveAt *time.Time         `json:"effective_at,omitempty"`
+	Employment  *EmploymentRequest `json:"employment,omitempty"`
+}
+
+type StaffEmploymentResponse struct {
+	Status        StaffStatus         `json:"status"`
+	Employments   []StaffEmployment   `json:"employments"`
+	StatusChanges []StaffStatusChange `json:"status_changes"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// DomainEvent is a change to a hospital's staff, clinics or users pushed to
+// streaming clients. ID is the ID of the audit log entry it comes from, so
+// events are ordered and a client can resume after the last one it saw.
+type DomainEvent struct {
+	ID         uint      `json:"id"`
+	Type       string    `json:"type"`
+	HospitalID uint      `json:"hospital_id"`
+	Resource   string    `json:"resource"`
+	ResourceID uint      `json:"resource_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type EventStreamRequest struct {
+	LastEventID uint `form:"last_event_id"`
+}
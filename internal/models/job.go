@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// JobRun is one execution of a background job, from being queued through
+// its retries to success or failure. Runs queued by a schedule carry the
+// time they were scheduled for, which is unique per job so a schedule fires
+// once however many workers see it come due.
+type JobRun struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Name         string     `json:"name" gorm:"not null;uniqueIndex:idx_job_runs_schedule,priority:1;index"`
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty" gorm:"uniqueIndex:idx_job_runs_schedule,priority:2"`
+	Payload      string     `json:"payload,omitempty" gorm:"type:text"`
+	Status       JobStatus  `json:"status" gorm:"not null;index"`
+	Attempts     int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts  int        `json:"max_attempts" gorm:"not null"`
+	RunAt        time.Time  `json:"run_at" gorm:"not null"`
+	LeaseUntil   *time.Time `json:"-"`
+	Worker       string     `json:"worker,omitempty"`
+	LastError    string     `json:"last_error,omitempty" gorm:"type:text"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// JobSummary describes a registered job with its schedule and latest run.
+type JobSummary struct {
+	Name      string     `json:"name"`
+	Schedule  string     `json:"schedule,omitempty"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	LastRun   *JobRun    `json:"last_run,omitempty"`
+}
+
+type JobRunFilterRequest struct {
+	Name   string    `form:"name"`
+	Status JobStatus `form:"status" binding:"omitempty,oneof=queued running succeeded failed"`
+	Page   int       `form:"page,default=1"`
+	Limit  int       `form:"limit,default=20"`
+}
+
+type JobRunPaginatedResponse struct {
+	Data []JobRun `json:"data"`
+	BasePagination
+}
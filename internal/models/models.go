@@ -10,7 +10,10 @@ type DeletedAt *time.Time
 
 type Province struct {
 	ID        uint       `json:"id" gorm:"primaryKey"`
+	PlateCode int        `json:"plate_code" gorm:"not null;default:0;uniqueIndex:idx_provinces_plate_code,where:plate_code <> 0"`
 	Name      string     `json:"name" gorm:"not null;unique"`
+	Latitude  *float64   `json:"latitude,omitempty" gorm:"type:double precision"`
+	Longitude *float64   `json:"longitude,omitempty" gorm:"type:double precision"`
 	Districts []District `json:"districts,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
@@ -18,54 +21,76 @@ type Province struct {
 
 type District struct {
 	ID         uint      `json:"id" gorm:"primaryKey"`
-	Name       string    `json:"name" gorm:"not null"`
-	ProvinceID uint      `json:"province_id" gorm:"not null"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_districts_province_name,priority:2"`
+	ProvinceID uint      `json:"province_id" gorm:"not null;uniqueIndex:idx_districts_province_name,priority:1"`
+	Latitude   *float64  `json:"latitude,omitempty" gorm:"type:double precision"`
+	Longitude  *float64  `json:"longitude,omitempty" gorm:"type:double precision"`
 	Province   Province  `json:"province,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type Neighbourhood struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_neighbourhoods_district_name,priority:2"`
+	DistrictID uint      `json:"district_id" gorm:"not null;uniqueIndex:idx_neighbourhoods_district_name,priority:1"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ProfessionGroup, Title and ClinicType form a two-level catalogue: entries
+// without a HospitalID are global defaults visible to every hospital, the
+// others are private additions of one hospital.
 type ProfessionGroup struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"not null;unique"`
-	Titles    []Title   `json:"titles,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	HospitalID *uint     `json:"hospital_id,omitempty" gorm:"uniqueIndex:idx_profession_groups_hospital_name,where:hospital_id IS NOT NULL"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_profession_groups_hospital_name,where:hospital_id IS NOT NULL;uniqueIndex:idx_profession_groups_global_name,where:hospital_id IS NULL"`
+	Titles     []Title   `json:"titles,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type Title struct {
 	ID                uint            `json:"id" gorm:"primaryKey"`
-	Name              string          `json:"name" gorm:"not null"`
-	ProfessionGroupID uint            `json:"profession_group_id" gorm:"not null"`
+	HospitalID        *uint           `json:"hospital_id,omitempty" gorm:"index"`
+	Name              string          `json:"name" gorm:"not null;uniqueIndex:idx_titles_global_name,priority:2,where:hospital_id IS NULL"`
+	ProfessionGroupID uint            `json:"profession_group_id" gorm:"not null;uniqueIndex:idx_titles_global_name,priority:1,where:hospital_id IS NULL"`
 	ProfessionGroup   ProfessionGroup `json:"profession_group,omitempty"`
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
 }
 
 type ClinicType struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"not null;unique"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	HospitalID *uint     `json:"hospital_id,omitempty" gorm:"uniqueIndex:idx_clinic_types_hospital_name,where:hospital_id IS NOT NULL"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_clinic_types_hospital_name,where:hospital_id IS NOT NULL;uniqueIndex:idx_clinic_types_global_name,where:hospital_id IS NULL"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type Hospital struct {
-	ID         uint           `json:"id" gorm:"primaryKey"`
-	Name       string         `json:"name" gorm:"not null"`
-	TaxID      string         `json:"tax_id" gorm:"not null;unique"`
-	Email      string         `json:"email" gorm:"not null;unique"`
-	Phone      string         `json:"phone" gorm:"not null;unique"`
-	ProvinceID uint           `json:"province_id" gorm:"not null"`
-	DistrictID uint           `json:"district_id" gorm:"not null"`
-	Address    string         `json:"address" gorm:"not null"`
-	Province   Province       `json:"province,omitempty"`
-	District   District       `json:"district,omitempty"`
-	Users      []User         `json:"users,omitempty"`
-	Clinics    []Clinic       `json:"clinics,omitempty"`
-	Staff      []Staff        `json:"staff,omitempty"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	Name       string `json:"name" gorm:"not null"`
+	TaxID      string `json:"tax_id" gorm:"not null;uniqueIndex:idx_hospitals_tax_id,where:deleted_at IS NULL"`
+	Email      string `json:"email" gorm:"not null;uniqueIndex:idx_hospitals_email,where:deleted_at IS NULL"`
+	Phone      string `json:"phone" gorm:"not null;uniqueIndex:idx_hospitals_phone,where:deleted_at IS NULL"`
+	ProvinceID uint   `json:"province_id" gorm:"not null"`
+	DistrictID uint   `json:"district_id" gorm:"not null"`
+	Address    string `json:"address" gorm:"not null"`
+	// NeighbourhoodID and the coordinates are optional; the coordinates
+	// are the hospital's own position, not its neighbourhood's.
+	NeighbourhoodID *uint          `json:"neighbourhood_id,omitempty"`
+	Latitude        *float64       `json:"latitude,omitempty" gorm:"type:double precision"`
+	Longitude       *float64       `json:"longitude,omitempty" gorm:"type:double precision"`
+	Province        Province       `json:"province,omitempty"`
+	District        District       `json:"district,omitempty"`
+	Neighbourhood   *Neighbourhood `json:"neighbourhood,omitempty"`
+	Users           []User         `json:"users,omitempty"`
+	Clinics         []Clinic       `json:"clinics,omitempty"`
+	Staff           []Staff        `json:"staff,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
 type UserType string
@@ -79,9 +104,9 @@ type User struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	FirstName   string         `json:"first_name" gorm:"not null"`
 	LastName    string         `json:"last_name" gorm:"not null"`
-	NationalID  string         `json:"national_id" gorm:"not null;unique"`
-	Email       string         `json:"email" gorm:"not null;unique"`
-	Phone       string         `json:"phone" gorm:"not null;unique"`
+	NationalID  string         `json:"national_id" gorm:"not null;uniqueIndex:idx_users_national_id,where:deleted_at IS NULL"`
+	Email       string         `json:"email" gorm:"not null;uniqueIndex:idx_users_email,where:deleted_at IS NULL"`
+	Phone       string         `json:"phone" gorm:"not null;uniqueIndex:idx_users_phone,where:deleted_at IS NULL"`
 	Password    string         `json:"-" gorm:"not null"`
 	UserType    UserType       `json:"user_type" gorm:"not null;default:'employee'"`
 	HospitalID  uint           `json:"hospital_id" gorm:"not null"`
@@ -93,16 +118,36 @@ type User struct {
 	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
+// Clinic is a clinic type opened in a hospital. Name overrides the clinic
+// type name for display, and HeadStaffID points at a staff member of the
+// clinic designated as its head.
 type Clinic struct {
-	ID           uint           `json:"id" gorm:"primaryKey"`
-	HospitalID   uint           `json:"hospital_id" gorm:"not null"`
-	ClinicTypeID uint           `json:"clinic_type_id" gorm:"not null"`
-	Hospital     Hospital       `json:"hospital,omitempty"`
-	ClinicType   ClinicType     `json:"clinic_type,omitempty"`
-	Staff        []Staff        `json:"staff,omitempty"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	HospitalID     uint           `json:"hospital_id" gorm:"not null"`
+	ClinicTypeID   uint           `json:"clinic_type_id" gorm:"not null"`
+	Name           string         `json:"name"`
+	Floor          string         `json:"floor"`
+	Building       string         `json:"building"`
+	PhoneExtension string         `json:"phone_extension"`
+	HeadStaffID    *uint          `json:"head_staff_id,omitempty" gorm:"index"`
+	OpenOnHolidays bool           `json:"open_on_holidays" gorm:"not null;default:false"`
+	Hospital       Hospital       `json:"hospital,omitempty"`
+	ClinicType     ClinicType     `json:"clinic_type,omitempty"`
+	HeadStaff      *Staff         `json:"head_staff,omitempty" gorm:"foreignKey:HeadStaffID;constraint:-"`
+	Rooms          []ClinicRoom   `json:"rooms,omitempty"`
+	Staff          []Staff        `json:"staff,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// DisplayName returns the clinic's own name, or its type name when it has
+// none.
+func (c *Clinic) DisplayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.ClinicType.Name
 }
 
 type WorkingDay string
@@ -121,13 +166,14 @@ type Staff struct {
 	ID                uint            `json:"id" gorm:"primaryKey"`
 	FirstName         string          `json:"first_name" gorm:"not null"`
 	LastName          string          `json:"last_name" gorm:"not null"`
-	NationalID        string          `json:"national_id" gorm:"not null;unique"`
-	Phone             string          `json:"phone" gorm:"not null;unique"`
+	NationalID        string          `json:"national_id" gorm:"not null;uniqueIndex:idx_staffs_national_id,where:deleted_at IS NULL"`
+	Phone             string          `json:"phone" gorm:"not null;uniqueIndex:idx_staffs_phone,where:deleted_at IS NULL"`
 	ProfessionGroupID uint            `json:"profession_group_id" gorm:"not null"`
 	TitleID           uint            `json:"title_id" gorm:"not null"`
 	HospitalID        uint            `json:"hospital_id" gorm:"not null"`
 	ClinicID          *uint           `json:"clinic_id,omitempty"`
 	WorkingDays       string          `json:"working_days" gorm:"type:text"`
+	Status            StaffStatus     `json:"status" gorm:"not null;default:'active';index"`
 	ProfessionGroup   ProfessionGroup `json:"profession_group,omitempty"`
 	Title             Title           `json:"title,omitempty"`
 	Hospital          Hospital        `json:"hospital,omitempty"`
@@ -137,6 +183,22 @@ type Staff struct {
 	DeletedAt         gorm.DeletedAt  `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
+// StaffClinicAssignment is a dated record of a staff member working in a
+// clinic. The open assignment (EndedAt is nil) mirrors Staff.ClinicID.
+type StaffClinicAssignment struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	StaffID     uint       `json:"staff_id" gorm:"not null;index"`
+	HospitalID  uint       `json:"hospital_id" gorm:"not null;index"`
+	ClinicID    uint       `json:"clinic_id" gorm:"not null;index"`
+	StartedAt   time.Time  `json:"started_at" gorm:"not null"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	Reason      string     `json:"reason"`
+	CreatedByID *uint      `json:"created_by_id,omitempty"`
+	Clinic      *Clinic    `json:"clinic,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
 type PasswordReset struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
 	Phone     string    `json:"phone" gorm:"not null"`
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+type NotificationChannel string
+
+const (
+	ChannelInApp NotificationChannel = "in_app"
+	ChannelEmail NotificationChannel = "email"
+	ChannelSMS   NotificationChannel = "sms"
+)
+
+// NotificationData is the JSON document attached to a notification, stored
+// as text and embedded as JSON when rendered.
+type NotificationData string
+
+func (d NotificationData) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return []byte(d), nil
+}
+
+// UserNotification is an entry in a user's in-app inbox.
+type UserNotification struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	UserID     uint             `json:"user_id" gorm:"not null;index:idx_user_notifications_inbox,priority:1"`
+	HospitalID uint             `json:"hospital_id" gorm:"not null;index"`
+	Type       string           `json:"type" gorm:"not null"`
+	Title      string           `json:"title" gorm:"not null"`
+	Message    string           `json:"message"`
+	Data       NotificationData `json:"data" gorm:"type:text"`
+	ReadAt     *time.Time       `json:"read_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at" gorm:"index:idx_user_notifications_inbox,priority:2"`
+}
+
+// NotificationPreference is the channels a user receives one type of
+// notification on. Types without a row use the defaults.
+type NotificationPreference struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	UserID    uint      `json:"-" gorm:"not null;uniqueIndex:idx_notification_preferences_user_type,priority:1"`
+	Type      string    `json:"type" gorm:"not null;uniqueIndex:idx_notification_preferences_user_type,priority:2"`
+	InApp     bool      `json:"in_app" gorm:"not null"`
+	Email     bool      `json:"email" gorm:"not null"`
+	SMS       bool      `json:"sms" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NotificationFilterRequest struct {
+	Unread bool `form:"unread"`
+	Page   int  `form:"page,default=1"`
+	Limit  int  `form:"limit,default=20"`
+}
+
+type NotificationPaginatedResponse struct {
+	Data        []UserNotification `json:"data"`
+	UnreadCount int64              `json:"unread_count"`
+	BasePagination
+}
+
+type NotificationPreferenceItem struct {
+	Type  string `json:"type" binding:"required"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+	SMS   bool   `json:"sms"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceItem `json:"preferences" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+type HeadcountGranularity string
+
+const (
+	GranularityDay   HeadcountGranularity = "day"
+	GranularityWeek  HeadcountGranularity = "week"
+	GranularityMonth HeadcountGranularity = "month"
+)
+
+// HeadcountSnapshot is the number of non-terminated staff at the end of a
+// day for one combination of hospital, clinic, profession group and title.
+// A nil clinic counts the unassigned staff.
+type HeadcountSnapshot struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	Date              time.Time `json:"date" gorm:"type:date;not null;index:idx_headcount_snapshots_hospital_date,priority:2"`
+	HospitalID        uint      `json:"hospital_id" gorm:"not null;index:idx_headcount_snapshots_hospital_date,priority:1"`
+	ClinicID          *uint     `json:"clinic_id,omitempty"`
+	ProfessionGroupID uint      `json:"profession_group_id" gorm:"not null"`
+	TitleID           uint      `json:"title_id" gorm:"not null"`
+	Count             int64     `json:"count" gorm:"not null"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type HeadcountTrendRequest struct {
+	From              string               `form:"from" binding:"required"`
+	To                string               `form:"to" binding:"required"`
+	Granularity       HeadcountGranularity `form:"granularity,default=month" binding:"oneof=day week month"`
+	GroupBy           string               `form:"group_by" binding:"omitempty,oneof=clinic profession_group title"`
+	ClinicID          string               `form:"clinic_id" binding:"omitempty,number|eq=unassigned"`
+	ProfessionGroupID uint                 `form:"profession_group_id"`
+	TitleID           uint                 `form:"title_id"`
+}
+
+// HeadcountTrendPoint is the headcount of a period, taken from the last day
+// of the period that has a snapshot.
+type HeadcountTrendPoint struct {
+	Period string `json:"period"`
+	Date   string `json:"date"`
+	Count  int64  `json:"count"`
+}
+
+// HeadcountTrendSeries is the trend of one clinic, profession group or
+// title, or of the whole selection when the trend is not grouped. The
+// unassigned staff are the series with a zero ID when grouping by clinic.
+type HeadcountTrendSeries struct {
+	ID     uint                  `json:"id"`
+	Name   string                `json:"name"`
+	Points []HeadcountTrendPoint `json:"points"`
+}
+
+type HeadcountTrendResponse struct {
+	From        string                 `json:"from"`
+	To          string                 `json:"to"`
+	Granularity HeadcountGranularity   `json:"granularity"`
+	GroupBy     string                 `json:"group_by,omitempty"`
+	Series      []HeadcountTrendSeries `json:"series"`
+}
+
+type HeadcountBackfillResult struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Days int    `json:"days"`
+	Rows int64  `json:"rows"`
+}
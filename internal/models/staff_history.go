@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// StaffVersion is a snapshot of a staff record, valid from ValidFrom until
+// the next version starts. The open version (ValidTo is nil) matches the
+// current staff row; Deleted marks the span during which it was in the trash.
+type StaffVersion struct {
+	ID                uint        `json:"id" gorm:"primaryKey"`
+	StaffID           uint        `json:"staff_id" gorm:"not null;uniqueIndex:idx_staff_versions_staff_version,priority:1"`
+	HospitalID        uint        `json:"hospital_id" gorm:"not null;index"`
+	Version           int         `json:"version" gorm:"not null;uniqueIndex:idx_staff_versions_staff_version,priority:2"`
+	FirstName         string      `json:"first_name" gorm:"not null"`
+	LastName          string      `json:"last_name" gorm:"not null"`
+	NationalID        string      `json:"national_id" gorm:"not null"`
+	Phone             string      `json:"phone" gorm:"not null"`
+	ProfessionGroupID uint        `json:"profession_group_id" gorm:"not null"`
+	TitleID           uint        `json:"title_id" gorm:"not null"`
+	ClinicID          *uint       `json:"clinic_id,omitempty"`
+	WorkingDays       string      `json:"working_days" gorm:"type:text"`
+	Status            StaffStatus `json:"status" gorm:"not null"`
+	Deleted           bool        `json:"deleted" gorm:"not null;default:false"`
+	ValidFrom         time.Time   `json:"valid_from" gorm:"not null"`
+	ValidTo           *time.Time  `json:"valid_to,omitempty"`
+	ChangedByID       *uint       `json:"changed_by_id,omitempty"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+// StaffFieldChange is one field that differs between a version and the one
+// before it. From is nil for the first version.
+type StaffFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type StaffHistoryEntry struct {
+	StaffVersion
+	Changes []StaffFieldChange `json:"changes"`
+}
+
+type StaffHistoryResponse struct {
+	StaffID  uint                `json:"staff_id"`
+	Versions []StaffHistoryEntry `json:"versions"`
+}
+
+type StaffAsOfRequest struct {
+	AsOf string `form:"as_of"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+type StaffImportStatus string
+
+const (
+	StaffImportPending   StaffImportStatus = "pending"
+	StaffImportRunning   StaffImportStatus = "running"
+	StaffImportCompleted StaffImportStatus = "completed"
+	StaffImportFailed    StaffImportStatus = "failed"
+)
+
+type StaffImportRowError struct {
+	Row        int    `json:"row"`
+	NationalID string `json:"national_id,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Message    string `json:"message"`
+}
+
+type StaffImportReport struct {
+	DryRun       bool                  `json:"dry_run"`
+	Committed    bool                  `json:"committed"`
+	TotalRows    int                   `json:"total_rows"`
+	ValidRows    int                   `json:"valid_rows"`
+	ImportedRows int                   `json:"imported_rows"`
+	Errors       []StaffImportRowError `json:"errors"`
+}
+
+// StaffImportJob tracks an import that is too large to run within the
+// upload request.
+type StaffImportJob struct {
+	ID            uint               `json:"id" gorm:"primaryKey"`
+	HospitalID    uint               `json:"hospital_id" gorm:"not null;index"`
+	CreatedByID   uint               `json:"created_by_id" gorm:"not null"`
+	FileName      string             `json:"file_name" gorm:"not null"`
+	File          []byte             `json:"-" gorm:"type:bytea"`
+	DryRun        bool               `json:"dry_run"`
+	Status        StaffImportStatus  `json:"status" gorm:"not null"`
+	TotalRows     int                `json:"total_rows"`
+	ProcessedRows int                `json:"processed_rows"`
+	Report        *StaffImportReport `json:"report,omitempty" gorm:"type:jsonb;serializer:json"`
+	Error         string             `json:"error,omitempty"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type StaffImportRequest struct {
+	DryRun bool `form:"dry_run"`
+}
+
+type StaffImportResponse struct {
+	Report *StaffImportReport `json:"report,omitempty"`
+	Job    *StaffImportJob    `json:"job,omitempty"`
+}
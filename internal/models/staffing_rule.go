@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StaffingRuleType string
+
+const (
+	// StaffingRuleMaxTitleCount caps the staff holding a title in a hospital
+	// or in each clinic.
+	StaffingRuleMaxTitleCount StaffingRuleType = "max_title_count"
+	// StaffingRuleMinProfessionCount keeps at least Threshold staff of a
+	// profession group in each clinic once reached.
+	StaffingRuleMinProfessionCount StaffingRuleType = "min_profession_count"
+	// StaffingRuleTitleRequiresClinic requires staff holding a title to be
+	// assigned to a clinic.
+	StaffingRuleTitleRequiresClinic StaffingRuleType = "title_requires_clinic"
+)
+
+type StaffingRuleScope string
+
+const (
+	StaffingRuleScopeHospital StaffingRuleScope = "hospital"
+	StaffingRuleScopeClinic   StaffingRuleScope = "clinic"
+)
+
+// StaffingRule is a staffing constraint checked on every staff mutation.
+// Rules without a hospital are platform defaults that apply to every
+// hospital and cannot be changed by hospital users.
+type StaffingRule struct {
+	ID                uint              `json:"id" gorm:"primaryKey"`
+	HospitalID        *uint             `json:"hospital_id,omitempty" gorm:"index"`
+	Type              StaffingRuleType  `json:"type" gorm:"not null"`
+	Scope             StaffingRuleScope `json:"scope" gorm:"not null"`
+	TitleID           *uint             `json:"title_id,omitempty"`
+	ProfessionGroupID *uint             `json:"profession_group_id,omitempty"`
+	ClinicID          *uint             `json:"clinic_id,omitempty"`
+	Threshold         int               `json:"threshold"`
+	Enabled           bool              `json:"enabled" gorm:"not null;default:true"`
+	Description       string            `json:"description,omitempty"`
+	Title             *Title            `json:"title,omitempty"`
+	ProfessionGroup   *ProfessionGroup  `json:"profession_group,omitempty"`
+	Clinic            *Clinic           `json:"clinic,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt    `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+type StaffingRuleRequest struct {
+	Type              StaffingRuleType  `json:"type" binding:"required,oneof=max_title_count min_profession_count title_requires_clinic"`
+	Scope             StaffingRuleScope `json:"scope" binding:"omitempty,oneof=hospital clinic"`
+	TitleID           *uint             `json:"title_id,omitempty"`
+	ProfessionGroupID *uint             `json:"profession_group_id,omitempty"`
+	ClinicID          *uint             `json:"clinic_id,omitempty"`
+	Threshold         int               `json:"threshold" binding:"min=0"`
+	Enabled           *bool             `json:"enabled,omitempty"`
+	Description       string            `json:"description,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// TrashRetention holds how many days soft-deleted records stay restorable.
+// Zero keeps them forever.
+type TrashRetention struct {
+	StaffDays    int `json:"staff_days"`
+	UserDays     int `json:"user_days"`
+	ClinicDays   int `json:"clinic_days"`
+	HospitalDays int `json:"hospital_days"`
+}
+
+type TrashPurgeResult struct {
+	Hospitals int64 `json:"hospitals"`
+	Clinics   int64 `json:"clinics"`
+	Users     int64 `json:"users"`
+	Staff     int64 `json:"staff"`
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookStaffCreated     WebhookEventType = "staff.created"
+	WebhookStaffUpdated     WebhookEventType = "staff.updated"
+	WebhookStaffTransferred WebhookEventType = "staff.transferred"
+	WebhookStaffDeleted     WebhookEventType = "staff.deleted"
+	WebhookStaffRestored    WebhookEventType = "staff.restored"
+)
+
+var WebhookEventTypes = []WebhookEventType{
+	WebhookStaffCreated,
+	WebhookStaffUpdated,
+	WebhookStaffTransferred,
+	WebhookStaffDeleted,
+	WebhookStaffRestored,
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead is a delivery that failed every attempt; it stays
+	// in the dead-letter list until the event is replayed to its
+	// subscription, which marks it replayed.
+	WebhookDeliveryDead     WebhookDeliveryStatus = "dead"
+	WebhookDeliveryReplayed WebhookDeliveryStatus = "replayed"
+)
+
+// WebhookSubscription sends a hospital's events to URL, signed with Secret.
+// EventTypes is a comma separated filter; empty subscribes to every event.
+type WebhookSubscription struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	HospitalID  uint           `json:"hospital_id" gorm:"not null;index"`
+	URL         string         `json:"url" gorm:"not null"`
+	Secret      string         `json:"-" gorm:"not null"`
+	EventTypes  string         `json:"event_types"`
+	Description string         `json:"description,omitempty"`
+	Active      bool           `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// WebhookEvent is an outbox entry, written in the transaction of the change
+// it describes. Payload is the JSON of the event's data; the envelope with
+// the event ID, type and time is added when it is sent.
+type WebhookEvent struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	HospitalID uint             `json:"hospital_id" gorm:"not null;index"`
+	Type       WebhookEventType `json:"type" gorm:"not null"`
+	Payload    string           `json:"-" gorm:"type:text;not null"`
+	CreatedAt  time.Time        `json:"created_at" gorm:"index"`
+}
+
+// WebhookDelivery is one attempt chain of sending an event to a
+// subscription. Pending deliveries are picked up once NextAttemptAt passes.
+type WebhookDelivery struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	EventID        uint                  `json:"event_id" gorm:"not null;index"`
+	SubscriptionID uint                  `json:"subscription_id" gorm:"not null;index"`
+	HospitalID     uint                  `json:"hospital_id" gorm:"not null;index"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"not null;index:idx_webhook_deliveries_due,priority:2"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	Event          *WebhookEvent         `json:"event,omitempty" gorm:"foreignKey:EventID"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// WebhookStaffData is the data of staff events. PreviousClinicID is set on
+// transfers.
+type WebhookStaffData struct {
+	ID                uint        `json:"id"`
+	FirstName         string      `json:"first_name"`
+	LastName          string      `json:"last_name"`
+	NationalID        string      `json:"national_id"`
+	Phone             string      `json:"phone"`
+	ProfessionGroupID uint        `json:"profession_group_id"`
+	ProfessionGroup   string      `json:"profession_group"`
+	TitleID           uint        `json:"title_id"`
+	Title             string      `json:"title"`
+	ClinicID          *uint       `json:"clinic_id"`
+	PreviousClinicID  *uint       `json:"previous_clinic_id,omitempty"`
+	WorkingDays       []string    `json:"working_days"`
+	Status            StaffStatus `json:"status"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL         string             `json:"url" binding:"required,url"`
+	EventTypes  []WebhookEventType `json:"event_types" binding:"dive,oneof=staff.created staff.updated staff.transferred staff.deleted staff.restored"`
+	Description string             `json:"description"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         string             `json:"url" binding:"omitempty,url"`
+	EventTypes  []WebhookEventType `json:"event_types" binding:"omitempty,dive,oneof=staff.created staff.updated staff.transferred staff.deleted staff.restored"`
+	Description *string            `json:"description,omitempty"`
+	Active      *bool              `json:"active,omitempty"`
+}
+
+// WebhookSecretResponse is returned when a subscription is created or its
+// secret rotated; the secret is not shown again.
+type WebhookSecretResponse struct {
+	Subscription WebhookSubscription `json:"subscription"`
+	Secret       string              `json:"secret"`
+}
+
+type WebhookDeliveryFilterRequest struct {
+	Status         WebhookDeliveryStatus `form:"status" binding:"omitempty,oneof=pending delivered dead replayed"`
+	SubscriptionID uint                  `form:"subscription_id"`
+	Page           int                   `form:"page,default=1"`
+	Limit          int                   `form:"limit,default=20"`
+}
+
+type WebhookDeliveryPaginatedResponse struct {
+	Data []WebhookDelivery `json:"data"`
+	BasePagination
+}
+
+// WebhookReplayRequest selects events to send again, either by ID or by the
+// time they were recorded. SubscriptionID limits the replay to one
+// subscription; otherwise every active subscription that matches receives it.
+type WebhookReplayRequest struct {
+	EventIDs       []uint `json:"event_ids"`
+	From           string `json:"from,omitempty"`
+	To             string `json:"to,omitempty"`
+	SubscriptionID *uint  `json:"subscription_id,omitempty"`
+}
+
+type WebhookReplayResponse struct {
+	Events     int `json:"events"`
+	Deliveries int `json:"deliveries"`
+}
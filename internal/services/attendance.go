@@ -0,0 +1,752 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AttendanceShift is the scheduled shift of a working day, expressed as
+// offsets from local midnight.
+type AttendanceShift struct {
+	Start     time.Duration
+	End       time.Duration
+	LateGrace time.Duration
+}
+
+func (s AttendanceShift) Minutes() int {
+	return int((s.End - s.Start) / time.Minute)
+}
+
+// maxAttendanceSpan is the longest a clock-in stays open. A clock-out later
+// than that does not close it, so a forgotten clock-out is not paid as a
+// shift of several days.
+const maxAttendanceSpan = 24 * time.Hour
+
+type AttendanceService struct {
+	db       *gorm.DB
+	shift    AttendanceShift
+	location *time.Location
+}
+
+func NewAttendanceService(db *gorm.DB, cfg *config.Config) *AttendanceService {
+	location, err := time.LoadLocation(cfg.Attendance.Timezone)
+	if err != nil {
+		log.Warn().Err(err).Str("timezone", cfg.Attendance.Timezone).Msg("Invalid attendance timezone, falling back to UTC")
+		location = time.UTC
+	}
+
+	return &AttendanceService{
+		db: db,
+		shift: AttendanceShift{
+			Start:     parseClockOrDefault(cfg.Attendance.ShiftStart, 8*time.Hour),
+			End:       parseClockOrDefault(cfg.Attendance.ShiftEnd, 17*time.Hour),
+			LateGrace: time.Duration(cfg.Attendance.LateGraceMinutes) * time.Minute,
+		},
+		location: location,
+	}
+}
+
+func (s *AttendanceService) WithContext(ctx context.Context) *AttendanceService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+func (s *AttendanceService) ClockIn(req *models.ClockRequest, hospitalID, userID uint) (*models.AttendanceEvent, error) {
+	staff, err := s.findStaff(req.StaffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordClockEvent(staff, models.AttendanceClockIn, models.AttendanceSourceAPI, nil, &userID, req.Note)
+}
+
+func (s *AttendanceService) ClockOut(req *models.ClockRequest, hospitalID, userID uint) (*models.AttendanceEvent, error) {
+	staff, err := s.findStaff(req.StaffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordClockEvent(staff, models.AttendanceClockOut, models.AttendanceSourceAPI, nil, &userID, req.Note)
+}
+
+func (s *AttendanceService) KioskClock(token string, req *models.KioskClockRequest, eventType models.AttendanceEventType) (*models.AttendanceEvent, error) {
+	var kiosk models.AttendanceKiosk
+	if err := s.db.Where("token_hash = ?", hashKioskToken(token)).First(&kiosk).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewInvalidTokenError()
+		}
+		return nil, apperrors.NewDatabaseError("find kiosk", err)
+	}
+
+	var staff models.Staff
+	err := s.db.Where("national_id = ? AND hospital_id = ?", req.NationalID, kiosk.HospitalID).First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewStaffNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+
+	event, err := s.recordClockEvent(&staff, eventType, models.AttendanceSourceKiosk, &kiosk.ID, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.db.Model(&kiosk).Update("last_used_at", now).Error; err != nil {
+		log.Warn().Err(err).Uint("kiosk_id", kiosk.ID).Msg("Failed to update kiosk usage time")
+	}
+
+	return event, nil
+}
+
+// CorrectAttendance records a back-dated clock event on behalf of a staff
+// member. Corrections skip the in/out sequence check since they usually fill
+// a gap left by a forgotten clock-in or clock-out.
+func (s *AttendanceService) CorrectAttendance(req *models.AttendanceCorrectionRequest, hospitalID, userID uint) (*models.AttendanceEvent, error) {
+	staff, err := s.findStaff(req.StaffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OccurredAt.After(time.Now()) {
+		return nil, apperrors.NewValidationError("occurred_at", "correction cannot be in the future")
+	}
+
+	event := &models.AttendanceEvent{
+		HospitalID:   hospitalID,
+		StaffID:      staff.ID,
+		Type:         req.Type,
+		Source:       models.AttendanceSourceAdminCorrection,
+		OccurredAt:   req.OccurredAt,
+		RecordedByID: &userID,
+		Note:         req.Note,
+	}
+
+	if err := s.db.Create(event).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create attendance correction", err)
+	}
+
+	return event, nil
+}
+
+func (s *AttendanceService) GetEvents(filter *models.AttendanceEventFilterRequest, hospitalID uint) ([]models.AttendanceEvent, error) {
+	query := s.db.Where("hospital_id = ?", hospitalID)
+
+	if filter.StaffID != 0 {
+		query = query.Where("staff_id = ?", filter.StaffID)
+	}
+
+	if filter.From != "" {
+		from, err := s.parseDate(filter.From)
+		if err != nil {
+			return nil, apperrors.NewValidationError("from", "date must be in YYYY-MM-DD format")
+		}
+		query = query.Where("occurred_at >= ?", from)
+	}
+
+	if filter.To != "" {
+		to, err := s.parseDate(filter.To)
+		if err != nil {
+			return nil, apperrors.NewValidationError("to", "date must be in YYYY-MM-DD format")
+		}
+		query = query.Where("occurred_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var events []models.AttendanceEvent
+	if err := query.Order("occurred_at").Find(&events).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get attendance events", err)
+	}
+	return events, nil
+}
+
+func (s *AttendanceService) CreateKiosk(req *models.CreateKioskRequest, hospitalID uint) (*models.CreateKioskResponse, error) {
+	token, err := generateKioskToken()
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate kiosk token", err)
+	}
+
+	kiosk := models.AttendanceKiosk{
+		HospitalID: hospitalID,
+		Name:       req.Name,
+		TokenHash:  hashKioskToken(token),
+	}
+
+	if err := s.db.Create(&kiosk).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create kiosk", err)
+	}
+
+	return &models.CreateKioskResponse{
+		Kiosk: kiosk,
+		Token: token,
+	}, nil
+}
+
+func (s *AttendanceService) GetKiosks(hospitalID uint) ([]models.AttendanceKiosk, error) {
+	var kiosks []models.AttendanceKiosk
+	if err := s.db.Where("hospital_id = ?", hospitalID).Find(&kiosks).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get kiosks", err)
+	}
+	return kiosks, nil
+}
+
+func (s *AttendanceService) DeleteKiosk(kioskID, hospitalID uint) error {
+	result := s.db.Where("id = ? AND hospital_id = ?", kioskID, hospitalID).Delete(&models.AttendanceKiosk{})
+	if result.Error != nil {
+		return apperrors.NewDatabaseError("delete kiosk", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("kiosk", kioskID)
+	}
+	return nil
+}
+
+// ReconcileDay compares the clock events of every staff member in the
+// hospital against their scheduled working days for the given date and
+// stores the outcome, replacing any earlier reconciliation of that day.
+func (s *AttendanceService) ReconcileDay(hospitalID uint, date string) ([]models.AttendanceDay, error) {
+	day, err := s.parseDate(date)
+	if err != nil {
+		return nil, apperrors.NewValidationError("date", "date must be in YYYY-MM-DD format")
+	}
+
+	records, err := s.reconcileRange(hospitalID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]models.AttendanceDay, 0, len(records))
+	for _, staffDays := range records {
+		days = append(days, staffDays...)
+	}
+	if len(days) == 0 {
+		return days, nil
+	}
+
+	err = s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "staff_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"scheduled", "status", "first_clock_in", "last_clock_out", "scheduled_minutes",
+			"worked_minutes", "late_minutes", "overtime_minutes", "updated_at",
+		}),
+	}).Create(&days).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("store attendance days", err)
+	}
+
+	return days, nil
+}
+
+func (s *AttendanceService) GetDailyAttendance(hospitalID uint, date string) ([]models.AttendanceDay, error) {
+	day, err := s.parseDate(date)
+	if err != nil {
+		return nil, apperrors.NewValidationError("date", "date must be in YYYY-MM-DD format")
+	}
+
+	var days []models.AttendanceDay
+	err = s.db.Where("hospital_id = ? AND date = ?", hospitalID, day.Format(time.DateOnly)).
+		Order("staff_id").Find(&days).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get attendance days", err)
+	}
+	return days, nil
+}
+
+// GetTimesheet aggregates the reconciled days of a month for every staff
+// member of the hospital. Days after today are not counted.
+func (s *AttendanceService) GetTimesheet(hospitalID uint, month string) (*models.TimesheetResponse, error) {
+	start, err := time.ParseInLocation("2006-01", month, s.location)
+	if err != nil {
+		return nil, apperrors.NewValidationError("month", "month must be in YYYY-MM format")
+	}
+	end := start.AddDate(0, 1, 0)
+
+	records, err := s.reconcileRange(hospitalID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var staff []models.Staff
+	if err := s.staffInRange(hospitalID, start).Order("last_name, first_name").Find(&staff).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get staff", err)
+	}
+
+	entries := make([]models.TimesheetEntry, 0, len(staff))
+	for _, member := range staff {
+		entry := models.TimesheetEntry{
+			StaffID:    member.ID,
+			FirstName:  member.FirstName,
+			LastName:   member.LastName,
+			NationalID: member.NationalID,
+		}
+
+		for _, day := range records[member.ID] {
+			if day.Scheduled {
+				entry.ScheduledDays++
+			}
+			switch day.Status {
+			case models.AttendanceStatusPresent:
+				entry.PresentDays++
+			case models.AttendanceStatusAbsent:
+				entry.AbsentDays++
+			case models.AttendanceStatusIncomplete:
+				entry.IncompleteDays++
+			}
+			if day.LateMinutes > 0 {
+				entry.LateDays++
+			}
+			entry.ScheduledMinutes += day.ScheduledMinutes
+			entry.WorkedMinutes += day.WorkedMinutes
+			entry.LateMinutes += day.LateMinutes
+			entry.OvertimeMinutes += day.OvertimeMinutes
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return &models.TimesheetResponse{
+		HospitalID: hospitalID,
+		Month:      start.Format("2006-01"),
+		Entries:    entries,
+	}, nil
+}
+
+func (s *AttendanceService) WriteTimesheetCSV(w io.Writer, timesheet *models.TimesheetResponse) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"staff_id", "national_id", "first_name", "last_name",
+		"scheduled_days", "present_days", "absent_days", "late_days", "incomplete_days",
+		"scheduled_hours", "worked_hours", "late_minutes", "overtime_hours",
+	}
+	if err := writer.Write(header); err != nil {
+		return apperrors.NewInternalError("failed to write timesheet header", err)
+	}
+
+	for _, entry := range timesheet.Entries {
+		row := []string{
+			strconv.FormatUint(uint64(entry.StaffID), 10),
+			entry.NationalID,
+			escapeSpreadsheetFormula(entry.FirstName),
+			escapeSpreadsheetFormula(entry.LastName),
+			strconv.Itoa(entry.ScheduledDays),
+			strconv.Itoa(entry.PresentDays),
+			strconv.Itoa(entry.AbsentDays),
+			strconv.Itoa(entry.LateDays),
+			strconv.Itoa(entry.IncompleteDays),
+			formatHours(entry.ScheduledMinutes),
+			formatHours(entry.WorkedMinutes),
+			strconv.Itoa(entry.LateMinutes),
+			formatHours(entry.OvertimeMinutes),
+		}
+		if err := writer.Write(row); err != nil {
+			return apperrors.NewInternalError("failed to write timesheet row", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return apperrors.NewInternalError("failed to flush timesheet", err)
+	}
+	return nil
+}
+
+// reconcileRange computes attendance days in [from, to) for every staff
+// member of the hospital, keyed by staff ID. Days after today are skipped,
+// as are days the staff member was not expected at work, unless they clocked
+// in anyway: before their hire date, after they were deleted, and while on
+// leave or terminated.
+func (s *AttendanceService) reconcileRange(hospitalID uint, from, to time.Time) (map[uint][]models.AttendanceDay, error) {
+	var staff []models.Staff
+	if err := s.staffInRange(hospitalID, from).Find(&staff).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get staff", err)
+	}
+
+	availability, err := s.loadAvailability(hospitalID, staff, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// events past the end are loaded for the clock-outs of shifts that
+	// started on the last day
+	var events []models.AttendanceEvent
+	err = s.db.Where("hospital_id = ? AND occurred_at >= ? AND occurred_at < ?", hospitalID, from, to.Add(maxAttendanceSpan)).
+		Order("occurred_at").Find(&events).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get attendance events", err)
+	}
+
+	// a clock-out belongs to the day of the clock-in it closes, so a night
+	// shift counts towards the day it started on
+	eventsByStaffDay := make(map[uint]map[string][]models.AttendanceEvent)
+	openedAt := make(map[uint]time.Time)
+	for _, event := range events {
+		key := event.OccurredAt.In(s.location).Format(time.DateOnly)
+		opened, open := openedAt[event.StaffID]
+		open = open && event.OccurredAt.Sub(opened) <= maxAttendanceSpan
+		switch event.Type {
+		case models.AttendanceClockIn:
+			if !open {
+				openedAt[event.StaffID] = event.OccurredAt
+			}
+		case models.AttendanceClockOut:
+			if open {
+				key = opened.In(s.location).Format(time.DateOnly)
+			}
+			delete(openedAt, event.StaffID)
+		}
+		if eventsByStaffDay[event.StaffID] == nil {
+			eventsByStaffDay[event.StaffID] = make(map[string][]models.AttendanceEvent)
+		}
+		eventsByStaffDay[event.StaffID][key] = append(eventsByStaffDay[event.StaffID][key], event)
+	}
+
+	now := time.Now().In(s.location)
+	records := make(map[uint][]models.AttendanceDay, len(staff))
+	for _, member := range staff {
+		workingDays := parseWorkingDays(member.WorkingDays)
+
+		for day := from; day.Before(to) && !day.After(now); day = day.AddDate(0, 0, 1) {
+			dayEvents := eventsByStaffDay[member.ID][day.Format(time.DateOnly)]
+			if len(dayEvents) == 0 && !availability[member.ID].expected(day, day.AddDate(0, 0, 1)) {
+				continue
+			}
+			scheduled := workingDays[workingDayOf(day)]
+			record := ReconcileAttendanceDay(day, scheduled, s.shift, dayEvents, now)
+			record.HospitalID = hospitalID
+			record.StaffID = member.ID
+			records[member.ID] = append(records[member.ID], record)
+		}
+	}
+
+	return records, nil
+}
+
+// staffAvailability bounds the days a staff member is expected at work: from
+// the day they were hired until their record was deleted, outside the
+// periods they were on leave or terminated.
+type staffAvailability struct {
+	hiredOn   time.Time
+	deletedAt *time.Time
+	initial   models.StaffStatus
+	changes   []models.StaffStatusChange
+}
+
+// expected reports whether the staff member was employed and not on leave at
+// some point of [start, end).
+func (a staffAvailability) expected(start, end time.Time) bool {
+	if !end.After(a.hiredOn) {
+		return false
+	}
+	if a.deletedAt != nil && !a.deletedAt.After(start) {
+		return false
+	}
+
+	status := a.initial
+	for _, change := range a.changes {
+		if change.EffectiveAt.After(start) {
+			if !change.EffectiveAt.Before(end) {
+				break
+			}
+			if atWork(status) {
+				return true
+			}
+		}
+		status = change.ToStatus
+	}
+	return atWork(status)
+}
+
+func atWork(status models.StaffStatus) bool {
+	return status != models.StaffOnLeave && status != models.StaffTerminated
+}
+
+// loadAvailability loads the hire dates and status changes up to the end of
+// the period of the given staff members.
+func (s *AttendanceService) loadAvailability(hospitalID uint, staff []models.Staff, to time.Time) (map[uint]staffAvailability, error) {
+	var hires []struct {
+		StaffID  uint
+		HireDate time.Time
+	}
+	err := s.db.Model(&models.StaffEmployment{}).
+		Select("staff_id, MIN(hire_date) AS hire_date").
+		Where("hospital_id = ?", hospitalID).
+		Group("staff_id").
+		Scan(&hires).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get hire dates", err)
+	}
+	hiredOn := make(map[uint]time.Time, len(hires))
+	for _, hire := range hires {
+		// the date column carries no zone, so it is read back as UTC midnight
+		hiredOn[hire.StaffID] = time.Date(hire.HireDate.Year(), hire.HireDate.Month(), hire.HireDate.Day(), 0, 0, 0, 0, s.location)
+	}
+
+	var changes []models.StaffStatusChange
+	err = s.db.Where("hospital_id = ? AND effective_at < ?", hospitalID, to).
+		Order("effective_at, id").
+		Find(&changes).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get status changes", err)
+	}
+	changesByStaff := make(map[uint][]models.StaffStatusChange)
+	for _, change := range changes {
+		changesByStaff[change.StaffID] = append(changesByStaff[change.StaffID], change)
+	}
+
+	availability := make(map[uint]staffAvailability, len(staff))
+	for _, member := range staff {
+		entry := staffAvailability{
+			initial: member.Status,
+			changes: changesByStaff[member.ID],
+		}
+		if len(entry.changes) > 0 {
+			entry.initial = entry.changes[0].FromStatus
+		}
+		if hired, ok := hiredOn[member.ID]; ok {
+			entry.hiredOn = hired
+		} else {
+			created := member.CreatedAt.In(s.location)
+			entry.hiredOn = time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, s.location)
+		}
+		if member.DeletedAt.Valid {
+			entry.deletedAt = &member.DeletedAt.Time
+		}
+		availability[member.ID] = entry
+	}
+	return availability, nil
+}
+
+// ReconcileAttendanceDay computes the attendance of a single day from the
+// clock events of the shifts that started on it. Each clock-in is paired with
+// the next clock-out, which may fall on the following day; repeated
+// clock-ins and unmatched clock-outs are ignored. A clock-in that is still
+// open after maxAttendanceSpan makes the day incomplete.
+func ReconcileAttendanceDay(day time.Time, scheduled bool, shift AttendanceShift, events []models.AttendanceEvent, now time.Time) models.AttendanceDay {
+	sorted := make([]models.AttendanceEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OccurredAt.Before(sorted[j].OccurredAt)
+	})
+
+	// the date column carries no zone, so it is stored as UTC midnight of the
+	// local calendar day while shift boundaries are computed in local time
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	record := models.AttendanceDay{
+		Date:      time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC),
+		Scheduled: scheduled,
+	}
+	if scheduled {
+		record.ScheduledMinutes = shift.Minutes()
+	}
+
+	var openedAt *time.Time
+	var worked time.Duration
+	for i := range sorted {
+		event := sorted[i]
+		switch event.Type {
+		case models.AttendanceClockIn:
+			if openedAt != nil {
+				continue
+			}
+			openedAt = &event.OccurredAt
+			if record.FirstClockIn == nil {
+				record.FirstClockIn = &event.OccurredAt
+			}
+		case models.AttendanceClockOut:
+			if openedAt == nil {
+				continue
+			}
+			worked += event.OccurredAt.Sub(*openedAt)
+			record.LastClockOut = &event.OccurredAt
+			openedAt = nil
+		}
+	}
+	record.WorkedMinutes = int(worked / time.Minute)
+
+	shiftStart := dayStart.Add(shift.Start)
+	shiftEnd := dayStart.Add(shift.End)
+
+	switch {
+	case record.FirstClockIn == nil && scheduled && now.After(shiftEnd):
+		record.Status = models.AttendanceStatusAbsent
+	case record.FirstClockIn == nil && scheduled:
+		record.Status = models.AttendanceStatusPending
+	case record.FirstClockIn == nil:
+		record.Status = models.AttendanceStatusOff
+	case openedAt != nil && now.After(openedAt.Add(maxAttendanceSpan)):
+		record.Status = models.AttendanceStatusIncomplete
+	default:
+		record.Status = models.AttendanceStatusPresent
+	}
+
+	if scheduled && record.FirstClockIn != nil && record.FirstClockIn.After(shiftStart.Add(shift.LateGrace)) {
+		record.LateMinutes = int(record.FirstClockIn.Sub(shiftStart) / time.Minute)
+	}
+
+	if record.WorkedMinutes > record.ScheduledMinutes {
+		record.OvertimeMinutes = record.WorkedMinutes - record.ScheduledMinutes
+	}
+
+	return record
+}
+
+func (s *AttendanceService) recordClockEvent(
+	staff *models.Staff,
+	eventType models.AttendanceEventType,
+	source models.AttendanceSource,
+	kioskID, recordedByID *uint,
+	note string,
+) (*models.AttendanceEvent, error) {
+	event := &models.AttendanceEvent{
+		HospitalID:   staff.HospitalID,
+		StaffID:      staff.ID,
+		Type:         eventType,
+		Source:       source,
+		KioskID:      kioskID,
+		RecordedByID: recordedByID,
+		Note:         note,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// locking the staff row serialises clock events of one staff member,
+		// so two requests cannot both pass the checks below
+		var current models.Staff
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status").First(&current, staff.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.NewStaffNotFoundError()
+			}
+			return apperrors.NewDatabaseError("lock staff", err)
+		}
+		if eventType == models.AttendanceClockIn && current.Status == models.StaffTerminated {
+			return apperrors.NewBusinessRuleError("terminated staff cannot clock in", map[string]interface{}{
+				"staff_id": staff.ID,
+			})
+		}
+
+		var last models.AttendanceEvent
+		err := tx.Where("staff_id = ?", staff.ID).Order("occurred_at DESC").First(&last).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewDatabaseError("get last attendance event", err)
+		}
+		clockedIn := err == nil && last.Type == models.AttendanceClockIn
+
+		if eventType == models.AttendanceClockIn && clockedIn {
+			return apperrors.NewBusinessRuleError("staff is already clocked in", map[string]interface{}{
+				"staff_id":    staff.ID,
+				"clocked_in":  last.OccurredAt,
+				"event_type":  eventType,
+				"last_source": last.Source,
+			})
+		}
+		if eventType == models.AttendanceClockOut && !clockedIn {
+			return apperrors.NewBusinessRuleError("staff is not clocked in", map[string]interface{}{
+				"staff_id":   staff.ID,
+				"event_type": eventType,
+			})
+		}
+
+		event.OccurredAt = time.Now()
+		if err := tx.Create(event).Error; err != nil {
+			return apperrors.NewDatabaseError("create attendance event", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// staffInRange selects the staff of the hospital who may have worked in a
+// period starting at from, which includes those deleted since it started.
+func (s *AttendanceService) staffInRange(hospitalID uint, from time.Time) *gorm.DB {
+	return s.db.Unscoped().Where("hospital_id = ? AND (deleted_at IS NULL OR deleted_at >= ?)", hospitalID, from)
+}
+
+func (s *AttendanceService) findStaff(staffID, hospitalID uint) (*models.Staff, error) {
+	var staff models.Staff
+	if err := s.db.Where("id = ? AND hospital_id = ?", staffID, hospitalID).First(&staff).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewStaffNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+	return &staff, nil
+}
+
+func (s *AttendanceService) parseDate(value string) (time.Time, error) {
+	return time.ParseInLocation(time.DateOnly, value, s.location)
+}
+
+func parseWorkingDays(raw string) map[models.WorkingDay]bool {
+	var days []models.WorkingDay
+	_ = json.Unmarshal([]byte(raw), &days)
+
+	result := make(map[models.WorkingDay]bool, len(days))
+	for _, day := range days {
+		result[day] = true
+	}
+	return result
+}
+
+func workingDayOf(t time.Time) models.WorkingDay {
+	switch t.Weekday() {
+	case time.Monday:
+		return models.Monday
+	case time.Tuesday:
+		return models.Tuesday
+	case time.Wednesday:
+		return models.Wednesday
+	case time.Thursday:
+		return models.Thursday
+	case time.Friday:
+		return models.Friday
+	case time.Saturday:
+		return models.Saturday
+	default:
+		return models.Sunday
+	}
+}
+
+func parseClockOrDefault(value string, fallback time.Duration) time.Duration {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return fallback
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+}
+
+func formatHours(minutes int) string {
+	return fmt.Sprintf("%.2f", float64(minutes)/60)
+}
+
+func generateKioskToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashKioskToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
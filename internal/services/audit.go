@@ -0,0 +1,593 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// auditChainLockKey is the advisory lock that serialises appends to the
+	// hash chain, so every entry links to the one committed before it.
+	auditChainLockKey     = 424242001
+	auditBeforeKey        = "audit:before"
+	auditVerifyBatch      = 1000
+	defaultAuditPageLimit = 50
+	maxAuditPageLimit     = 200
+)
+
+// auditGenesisHash is the previous hash of the first entry.
+var auditGenesisHash = strings.Repeat("0", 64)
+
+// auditedTables are the tables whose writes are recorded. Attendance events
+// and days are left out as they are an append-only record of their own.
+var auditedTables = map[string]bool{
+	"hospitals":                true,
+	"users":                    true,
+	"clinics":                  true,
+	"clinic_rooms":             true,
+	"clinic_opening_hours":     true,
+	"clinic_closures":          true,
+	"hospital_holidays":        true,
+	"staffs":                   true,
+	"staff_clinic_assignments": true,
+	"staff_employments":        true,
+	"staff_status_changes":     true,
+	"staff_credentials":        true,
+	"staffing_rules":           true,
+	"attendance_kiosks":        true,
+	"clinic_types":             true,
+	"profession_groups":        true,
+	"titles":                   true,
+	"webhook_subscriptions":    true,
+}
+
+// auditRedactedColumns hold secrets whose values never enter the log; a
+// change to them is recorded without the values.
+var auditRedactedColumns = map[string]bool{
+	"password": true,
+	"secret":   true,
+}
+
+// AuditActor identifies who made the writes of a request. Services pick it
+// up from the context of their queries: their WithContext method returns a
+// copy of the service whose queries carry ctx, so the writes it makes are
+// attributed to the actor stored there.
+type AuditActor struct {
+	UserID     *uint
+	HospitalID *uint
+	RequestID  string
+	IP         string
+}
+
+type auditActorKey struct{}
+
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFrom returns the actor stored in ctx, or the zero actor for
+// writes made by the system itself, such as background jobs.
+func AuditActorFrom(ctx context.Context) AuditActor {
+	if ctx == nil {
+		return AuditActor{}
+	}
+	actor, _ := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor
+}
+
+// EnableAuditLog registers gorm callbacks that append an entry to the audit
+// log for every create, update and delete on an audited table, in the same
+// transaction as the write. Updates and deletes record the affected rows as
+// they were before, updates also the changed columns after. Raw SQL writes
+// are recorded as bulk writes with their statement. A failure to record
+// fails the write.
+func EnableAuditLog(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("audit:create", auditAfterCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("audit:before_update", auditCaptureBefore); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("audit:update", auditAfterUpdate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("audit:before_delete", auditCaptureBefore); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("audit:delete", auditAfterDelete); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("audit:raw", auditAfterRaw)
+}
+
+func auditAfterCreate(tx *gorm.DB) {
+	if tx.Error != nil || !auditedTables[tx.Statement.Table] || tx.Statement.Schema == nil {
+		return
+	}
+	ids := auditPrimaryKeys(tx.Statement)
+	if len(ids) == 0 {
+		return
+	}
+
+	rows, err := auditRowsByID(tx, ids)
+	if err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+
+	entries := make([]*models.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, newAuditEntry(tx, models.AuditCreate, row, nil, auditRedact(row)))
+	}
+	if err := appendAuditEntries(tx, entries); err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+// auditCaptureBefore loads the rows an update or delete is about to touch,
+// using the statement's conditions and the primary key of its model.
+func auditCaptureBefore(tx *gorm.DB) {
+	tx.Statement.Settings.Delete(auditBeforeKey)
+	if tx.Error != nil || !auditedTables[tx.Statement.Table] {
+		return
+	}
+
+	stmt := tx.Statement
+	query := tx.Session(&gorm.Session{NewDB: true}).Table(stmt.Table)
+	conditions := false
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			query = query.Clauses(clause.Where{Exprs: where.Exprs})
+			conditions = true
+		}
+	}
+	if stmt.Schema != nil {
+		if ids := auditPrimaryKeys(stmt); len(ids) > 0 {
+			query = query.Where(stmt.Table+".id IN ?", ids)
+			conditions = true
+		}
+		if !stmt.Unscoped && stmt.Schema.LookUpField("DeletedAt") != nil {
+			query = query.Where(stmt.Table + ".deleted_at IS NULL")
+		}
+	}
+	if !conditions {
+		return
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+	stmt.Settings.Store(auditBeforeKey, rows)
+}
+
+func auditAfterUpdate(tx *gorm.DB) {
+	value, ok := tx.Statement.Settings.LoadAndDelete(auditBeforeKey)
+	if !ok || tx.Error != nil {
+		return
+	}
+	before := value.([]map[string]interface{})
+	if len(before) == 0 {
+		return
+	}
+
+	ids := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row["id"])
+	}
+	after, err := auditRowsByID(tx, ids)
+	if err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+	afterByID := make(map[string]map[string]interface{}, len(after))
+	for _, row := range after {
+		afterByID[fmt.Sprint(row["id"])] = row
+	}
+
+	entries := make([]*models.AuditLog, 0, len(before))
+	for _, old := range before {
+		current := afterByID[fmt.Sprint(old["id"])]
+		changedBefore, changedAfter := auditDiff(old, current)
+		if len(changedBefore) == 0 {
+			continue
+		}
+		entries = append(entries, newAuditEntry(tx, models.AuditUpdate, old, changedBefore, changedAfter))
+	}
+	if err := appendAuditEntries(tx, entries); err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+func auditAfterDelete(tx *gorm.DB) {
+	value, ok := tx.Statement.Settings.LoadAndDelete(auditBeforeKey)
+	if !ok || tx.Error != nil {
+		return
+	}
+	before := value.([]map[string]interface{})
+
+	entries := make([]*models.AuditLog, 0, len(before))
+	for _, row := range before {
+		entries = append(entries, newAuditEntry(tx, models.AuditDelete, row, auditRedact(row), nil))
+	}
+	if err := appendAuditEntries(tx, entries); err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+// auditAfterRaw records raw SQL writes, which cannot be diffed, with the
+// statement and its values.
+func auditAfterRaw(tx *gorm.DB) {
+	if tx.Error != nil {
+		return
+	}
+	tables := rawWriteTables(tx.Statement.SQL.String())
+	if len(tables) == 0 || !auditedTables[tables[0]] {
+		return
+	}
+
+	changes, err := json.Marshal(map[string]interface{}{
+		"sql":  strings.Join(strings.Fields(tx.Statement.SQL.String()), " "),
+		"vars": tx.Statement.Vars,
+		"rows": tx.Statement.RowsAffected,
+	})
+	if err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+		return
+	}
+
+	actor := AuditActorFrom(tx.Statement.Context)
+	entry := &models.AuditLog{
+		HospitalID:  actor.HospitalID,
+		ActorUserID: actor.UserID,
+		Action:      models.AuditBulkWrite,
+		Resource:    tables[0],
+		Changes:     models.AuditChanges(changes),
+		RequestID:   actor.RequestID,
+		IP:          actor.IP,
+	}
+	if err := appendAuditEntries(tx, []*models.AuditLog{entry}); err != nil {
+		_ = tx.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+func newAuditEntry(tx *gorm.DB, action models.AuditAction, row, before, after map[string]interface{}) *models.AuditLog {
+	actor := AuditActorFrom(tx.Statement.Context)
+	document := map[string]interface{}{}
+	if before != nil {
+		document["before"] = before
+	}
+	if after != nil {
+		document["after"] = after
+	}
+	changes, err := json.Marshal(document)
+	if err != nil {
+		changes = []byte(strconv.Quote(err.Error()))
+	}
+
+	entry := &models.AuditLog{
+		HospitalID:  actor.HospitalID,
+		ActorUserID: actor.UserID,
+		Action:      action,
+		Resource:    tx.Statement.Table,
+		ResourceID:  auditUint(row["id"]),
+		Changes:     models.AuditChanges(changes),
+		RequestID:   actor.RequestID,
+		IP:          actor.IP,
+	}
+	if tx.Statement.Table == "hospitals" {
+		entry.HospitalID = entry.ResourceID
+	} else if hospitalID := auditUint(row["hospital_id"]); hospitalID != nil {
+		entry.HospitalID = hospitalID
+	}
+	return entry
+}
+
+// appendAuditEntries links the entries to the end of the chain. The advisory
+// lock is held until the surrounding transaction ends, so concurrent writers
+// append one after the other.
+func appendAuditEntries(tx *gorm.DB, entries []*models.AuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return tx.Session(&gorm.Session{NewDB: true}).Transaction(func(db *gorm.DB) error {
+		if err := db.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+			return err
+		}
+
+		var last []string
+		if err := db.Model(&models.AuditLog{}).Order("id DESC").Limit(1).Pluck("hash", &last).Error; err != nil {
+			return err
+		}
+		prev := auditGenesisHash
+		if len(last) > 0 {
+			prev = last[0]
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		for _, entry := range entries {
+			entry.PrevHash = prev
+			entry.CreatedAt = now
+			entry.Hash = auditHash(entry)
+			if err := db.Create(entry).Error; err != nil {
+				return err
+			}
+			prev = entry.Hash
+		}
+		return nil
+	})
+}
+
+// auditHash covers the previous hash and every recorded field of the entry.
+func auditHash(entry *models.AuditLog) string {
+	optional := func(value *uint) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*value), 10)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		entry.PrevHash,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		optional(entry.HospitalID),
+		optional(entry.ActorUserID),
+		string(entry.Action),
+		entry.Resource,
+		optional(entry.ResourceID),
+		string(entry.Changes),
+		entry.RequestID,
+		entry.IP,
+	}, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+func auditRowsByID(tx *gorm.DB, ids []interface{}) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Table(tx.Statement.Table).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&rows).Error
+	return rows, err
+}
+
+// auditPrimaryKeys returns the non-zero IDs of the statement's model, which
+// is a struct or a slice of them.
+func auditPrimaryKeys(stmt *gorm.Statement) []interface{} {
+	field := stmt.Schema.LookUpField("ID")
+	if field == nil || !stmt.ReflectValue.IsValid() {
+		return nil
+	}
+
+	rows := []reflect.Value{stmt.ReflectValue}
+	if stmt.ReflectValue.Kind() == reflect.Slice || stmt.ReflectValue.Kind() == reflect.Array {
+		rows = rows[:0]
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			rows = append(rows, reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	}
+
+	var ids []interface{}
+	for _, row := range rows {
+		if row.Kind() != reflect.Struct {
+			continue
+		}
+		if value, zero := field.ValueOf(stmt.Context, row); !zero {
+			ids = append(ids, value)
+		}
+	}
+	return ids
+}
+
+// auditDiff returns the columns whose values differ, as they were before
+// and after. The update timestamp is left out as it changes on every write.
+func auditDiff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for column, old := range before {
+		if column == "updated_at" {
+			continue
+		}
+		current := after[column]
+		oldJSON, _ := json.Marshal(old)
+		currentJSON, _ := json.Marshal(current)
+		if string(oldJSON) == string(currentJSON) {
+			continue
+		}
+		changedBefore[column] = old
+		changedAfter[column] = current
+	}
+	return auditRedact(changedBefore), auditRedact(changedAfter)
+}
+
+func auditRedact(row map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		if auditRedactedColumns[column] && value != nil {
+			value = "[redacted]"
+		}
+		redacted[column] = value
+	}
+	return redacted
+}
+
+func auditUint(value interface{}) *uint {
+	var id uint
+	switch v := value.(type) {
+	case int64:
+		if v <= 0 {
+			return nil
+		}
+		id = uint(v)
+	case int32:
+		if v <= 0 {
+			return nil
+		}
+		id = uint(v)
+	case uint:
+		id = v
+	case uint64:
+		id = uint(v)
+	default:
+		return nil
+	}
+	return &id
+}
+
+type AuditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService(db *gorm.DB) *AuditService {
+	return &AuditService{
+		db: db,
+	}
+}
+
+// GetAuditLogs returns the hospital's audit entries, newest first.
+func (s *AuditService) GetAuditLogs(filter *models.AuditFilterRequest, hospitalID uint) (*models.AuditLogPaginatedResponse, error) {
+	query := s.db.Model(&models.AuditLog{}).Where("hospital_id = ?", hospitalID)
+	if filter.ActorUserID != 0 {
+		query = query.Where("actor_user_id = ?", filter.ActorUserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Resource != "" {
+		query = query.Where("resource = ?", filter.Resource)
+	}
+	if filter.ResourceID != 0 {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != "" {
+		start, _, err := parseAsOf(filter.From)
+		if err != nil {
+			return nil, apperrors.NewValidationError("from", err.Error())
+		}
+		query = query.Where("created_at >= ?", start)
+	}
+	if filter.To != "" {
+		_, end, err := parseAsOf(filter.To)
+		if err != nil {
+			return nil, apperrors.NewValidationError("to", err.Error())
+		}
+		query = query.Where("created_at < ?", end)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("count audit logs", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageLimit
+	}
+	if filter.Limit > maxAuditPageLimit {
+		filter.Limit = maxAuditPageLimit
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	var entries []models.AuditLog
+	err := query.Order("id DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get audit logs", err)
+	}
+
+	return &models.AuditLogPaginatedResponse{
+		Data: entries,
+		BasePagination: models.BasePagination{
+			TotalCount: totalCount,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: int(math.Ceil(float64(totalCount) / float64(filter.Limit))),
+		},
+	}, nil
+}
+
+// VerifyChain walks the whole log in order and reports the first entry whose
+// previous hash is not the hash of the entry before it, or whose own hash
+// does not match its contents. Entries removed from the end of the log
+// cannot be detected this way.
+func (s *AuditService) VerifyChain() (*models.AuditVerification, error) {
+	result := &models.AuditVerification{Valid: true}
+	prev := auditGenesisHash
+	var lastID uint
+
+	for {
+		var batch []models.AuditLog
+		if err := s.db.Where("id > ?", lastID).Order("id").Limit(auditVerifyBatch).Find(&batch).Error; err != nil {
+			return nil, apperrors.NewDatabaseError("get audit logs", err)
+		}
+
+		for i := range batch {
+			entry := &batch[i]
+			result.Checked++
+			switch {
+			case entry.PrevHash != prev:
+				result.Reason = "previous hash does not match the preceding entry"
+			case auditHash(entry) != entry.Hash:
+				result.Reason = "hash does not match the entry contents"
+			default:
+				prev = entry.Hash
+				lastID = entry.ID
+				continue
+			}
+			result.Valid = false
+			result.BrokenAt = &entry.ID
+			return result, nil
+		}
+
+		if len(batch) < auditVerifyBatch {
+			return result, nil
+		}
+	}
+}
+
+// rawWriteTables returns the tables named after UPDATE, INTO and FROM in an
+// INSERT, UPDATE or DELETE statement. Tables read by a subquery are included
+// too, which only errs on the side of reporting a change.
+func rawWriteTables(sql string) []string {
+	words := strings.Fields(strings.ToLower(sql))
+	if len(words) == 0 {
+		return nil
+	}
+	switch words[0] {
+	case "insert", "update", "delete":
+	default:
+		return nil
+	}
+
+	var tables []string
+	for i := 0; i+1 < len(words); i++ {
+		switch words[i] {
+		case "update", "into", "from":
+			tables = append(tables, strings.Trim(words[i+1], `"(`))
+		}
+	}
+	return tables
+}
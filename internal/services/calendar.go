@@ -0,0 +1,497 @@
+package services
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxCalendarDays = 92
+
+//go:embed data/turkish_holidays.json
+var turkishHolidaysData []byte
+
+type nationalHoliday struct {
+	Date     string `json:"date"`
+	Name     string `json:"name"`
+	ClosesAt string `json:"closes_at"`
+}
+
+type nationalHolidayFile struct {
+	Version int                          `json:"version"`
+	Years   map[string][]nationalHoliday `json:"years"`
+}
+
+// CalendarService manages clinic opening hours, hospital holidays and ad-hoc
+// clinic closures, and resolves them into the periods a clinic is open.
+type CalendarService struct {
+	db       *gorm.DB
+	location *time.Location
+}
+
+func NewCalendarService(db *gorm.DB, cfg *config.Config) *CalendarService {
+	location, err := time.LoadLocation(cfg.Attendance.Timezone)
+	if err != nil {
+		log.Warn().Err(err).Str("timezone", cfg.Attendance.Timezone).Msg("Invalid calendar timezone, falling back to UTC")
+		location = time.UTC
+	}
+
+	return &CalendarService{
+		db:       db,
+		location: location,
+	}
+}
+
+func (s *CalendarService) WithContext(ctx context.Context) *CalendarService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+func (s *CalendarService) GetOpeningHours(clinicID, hospitalID uint) (*models.OpeningHoursResponse, error) {
+	clinic, err := s.findClinic(clinicID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := s.openingHours(clinic.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OpeningHoursResponse{OpenOnHolidays: clinic.OpenOnHolidays, Periods: hours}, nil
+}
+
+// SetOpeningHours replaces the weekly schedule of a clinic. Periods of the
+// same weekday must not overlap.
+func (s *CalendarService) SetOpeningHours(clinicID uint, req *models.OpeningHoursRequest, hospitalID uint) (*models.OpeningHoursResponse, error) {
+	clinic, err := s.findClinic(clinicID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOpeningHours(req.Periods); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("clinic_id = ?", clinic.ID).Delete(&models.ClinicOpeningHours{}).Error; err != nil {
+			return err
+		}
+		for _, period := range req.Periods {
+			hours := &models.ClinicOpeningHours{
+				ClinicID:   clinic.ID,
+				HospitalID: hospitalID,
+				Weekday:    period.Weekday,
+				OpensAt:    period.OpensAt,
+				ClosesAt:   period.ClosesAt,
+			}
+			if err := tx.Create(hours).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Clinic{}).Where("id = ?", clinic.ID).Update("open_on_holidays", req.OpenOnHolidays).Error
+	})
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("set opening hours", err)
+	}
+
+	return s.GetOpeningHours(clinic.ID, hospitalID)
+}
+
+// GetHolidays lists the hospital's holidays of a year, the current year by
+// default.
+func (s *CalendarService) GetHolidays(filter *models.HolidayFilterRequest, hospitalID uint) ([]models.HospitalHoliday, error) {
+	year := filter.Year
+	if year == 0 {
+		year = time.Now().In(s.location).Year()
+	}
+
+	var holidays []models.HospitalHoliday
+	err := s.db.Where("hospital_id = ? AND date >= ? AND date < ?", hospitalID,
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)).
+		Order("date").
+		Find(&holidays).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get holidays", err)
+	}
+	return holidays, nil
+}
+
+func (s *CalendarService) CreateHoliday(req *models.HolidayRequest, hospitalID uint) (*models.HospitalHoliday, error) {
+	if req.ClosesAt != "" {
+		if _, err := parseClockMinutes(req.ClosesAt); err != nil {
+			return nil, apperrors.NewValidationError("closes_at", err.Error())
+		}
+	}
+
+	date := startOfDay(req.Date)
+	var count int64
+	if err := s.db.Model(&models.HospitalHoliday{}).Where("hospital_id = ? AND date = ?", hospitalID, date).Count(&count).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("check holiday date", err)
+	}
+	if count > 0 {
+		return nil, apperrors.NewConflictError("date", date.Format(time.DateOnly), "holiday")
+	}
+
+	holiday := &models.HospitalHoliday{
+		HospitalID: hospitalID,
+		Date:       date,
+		Name:       req.Name,
+		ClosesAt:   req.ClosesAt,
+		Source:     models.HolidayManual,
+	}
+	if err := s.db.Create(holiday).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create holiday", err)
+	}
+	return holiday, nil
+}
+
+func (s *CalendarService) DeleteHoliday(holidayID, hospitalID uint) error {
+	result := s.db.Where("id = ? AND hospital_id = ?", holidayID, hospitalID).Delete(&models.HospitalHoliday{})
+	if result.Error != nil {
+		return apperrors.NewDatabaseError("delete holiday", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("holiday", holidayID)
+	}
+	return nil
+}
+
+// ImportNationalHolidays copies the Turkish national holidays of a year from
+// the bundled data file into the hospital's holidays. Dates the hospital
+// already has a holiday for are left untouched, so importing twice is safe.
+func (s *CalendarService) ImportNationalHolidays(year int, hospitalID uint) (*models.HolidayImportResponse, error) {
+	var file nationalHolidayFile
+	if err := json.Unmarshal(turkishHolidaysData, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse bundled holidays: %w", err)
+	}
+
+	entries, ok := file.Years[strconv.Itoa(year)]
+	if !ok {
+		return nil, apperrors.NewValidationError("year", fmt.Sprintf("no bundled national holidays for %d", year))
+	}
+
+	holidays := make([]models.HospitalHoliday, 0, len(entries))
+	for _, entry := range entries {
+		date, err := time.Parse(time.DateOnly, entry.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundled holiday date %q: %w", entry.Date, err)
+		}
+		holidays = append(holidays, models.HospitalHoliday{
+			HospitalID: hospitalID,
+			Date:       date,
+			Name:       entry.Name,
+			ClosesAt:   entry.ClosesAt,
+			Source:     models.HolidayNational,
+		})
+	}
+
+	result := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "hospital_id"}, {Name: "date"}},
+		DoNothing: true,
+	}).Create(&holidays)
+	if result.Error != nil {
+		return nil, apperrors.NewDatabaseError("import holidays", result.Error)
+	}
+
+	imported, err := s.GetHolidays(&models.HolidayFilterRequest{Year: year}, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.HolidayImportResponse{Imported: int(result.RowsAffected), Holidays: imported}, nil
+}
+
+func (s *CalendarService) GetClosures(clinicID, hospitalID uint) ([]models.ClinicClosure, error) {
+	if _, err := s.findClinic(clinicID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	var closures []models.ClinicClosure
+	if err := s.db.Where("clinic_id = ?", clinicID).Order("starts_at DESC").Find(&closures).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get clinic closures", err)
+	}
+	return closures, nil
+}
+
+func (s *CalendarService) CreateClosure(clinicID uint, req *models.ClinicClosureRequest, hospitalID, userID uint) (*models.ClinicClosure, error) {
+	if _, err := s.findClinic(clinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, apperrors.NewValidationError("ends_at", "closure must end after it starts")
+	}
+
+	closure := &models.ClinicClosure{
+		ClinicID:    clinicID,
+		HospitalID:  hospitalID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Reason:      req.Reason,
+		CreatedByID: &userID,
+	}
+	if err := s.db.Create(closure).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create clinic closure", err)
+	}
+	return closure, nil
+}
+
+func (s *CalendarService) DeleteClosure(clinicID, closureID, hospitalID uint) error {
+	result := s.db.Where("id = ? AND clinic_id = ? AND hospital_id = ?", closureID, clinicID, hospitalID).Delete(&models.ClinicClosure{})
+	if result.Error != nil {
+		return apperrors.NewDatabaseError("delete clinic closure", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("clinic closure", closureID)
+	}
+	return nil
+}
+
+// GetCalendar resolves the open periods of a clinic for every local date
+// from and to inclusive, given as YYYY-MM-DD.
+func (s *CalendarService) GetCalendar(clinicID, hospitalID uint, from, to string) ([]models.ClinicCalendarDay, error) {
+	start, err := time.ParseInLocation(time.DateOnly, from, s.location)
+	if err != nil {
+		return nil, apperrors.NewValidationError("from", "must be a date in YYYY-MM-DD format")
+	}
+	end, err := time.ParseInLocation(time.DateOnly, to, s.location)
+	if err != nil {
+		return nil, apperrors.NewValidationError("to", "must be a date in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, apperrors.NewValidationError("to", "cannot be before from")
+	}
+	if end.Sub(start) >= maxCalendarDays*24*time.Hour {
+		return nil, apperrors.NewValidationError("to", fmt.Sprintf("calendar range cannot exceed %d days", maxCalendarDays))
+	}
+
+	clinic, err := s.findClinic(clinicID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.calendar(clinic, start, end)
+}
+
+// IsOpenAt reports whether a clinic is open at the given instant and, if so,
+// the opening period it falls into. Scheduling features can use it to check
+// shifts against clinic hours.
+func (s *CalendarService) IsOpenAt(clinicID, hospitalID uint, at time.Time) (*models.ClinicOpenStatus, error) {
+	clinic, err := s.findClinic(clinicID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	local := at.In(s.location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.location)
+	days, err := s.calendar(clinic, day, day)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.ClinicOpenStatus{ClinicID: clinic.ID, At: local}
+	resolved := days[0]
+	for i := range resolved.Open {
+		period := resolved.Open[i]
+		if !local.Before(period.Start) && local.Before(period.End) {
+			status.Open = true
+			status.Period = &period
+			break
+		}
+	}
+	if resolved.Holiday != nil && !clinic.OpenOnHolidays {
+		status.Holiday = resolved.Holiday
+	}
+	for i := range resolved.Closures {
+		closure := resolved.Closures[i]
+		if !local.Before(closure.StartsAt) && local.Before(closure.EndsAt) {
+			status.Closure = &closure
+			break
+		}
+	}
+	return status, nil
+}
+
+func (s *CalendarService) calendar(clinic *models.Clinic, start, end time.Time) ([]models.ClinicCalendarDay, error) {
+	hours, err := s.openingHours(clinic.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []models.HospitalHoliday
+	err = s.db.Where("hospital_id = ? AND date >= ? AND date <= ?", clinic.HospitalID,
+		time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+		time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)).
+		Find(&holidays).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get holidays", err)
+	}
+	holidaysByDate := make(map[string]*models.HospitalHoliday, len(holidays))
+	for i := range holidays {
+		holidaysByDate[holidays[i].Date.Format(time.DateOnly)] = &holidays[i]
+	}
+
+	var closures []models.ClinicClosure
+	err = s.db.Where("clinic_id = ? AND starts_at < ? AND ends_at > ?", clinic.ID, end.AddDate(0, 0, 1), start).
+		Order("starts_at").
+		Find(&closures).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get clinic closures", err)
+	}
+
+	var days []models.ClinicCalendarDay
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		holiday := holidaysByDate[day.Format(time.DateOnly)]
+		days = append(days, ResolveClinicDay(day, hours, clinic.OpenOnHolidays, holiday, closures))
+	}
+	return days, nil
+}
+
+func (s *CalendarService) openingHours(clinicID uint) ([]models.ClinicOpeningHours, error) {
+	var hours []models.ClinicOpeningHours
+	if err := s.db.Where("clinic_id = ?", clinicID).Order("id").Find(&hours).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get opening hours", err)
+	}
+	return hours, nil
+}
+
+func (s *CalendarService) findClinic(clinicID, hospitalID uint) (*models.Clinic, error) {
+	var clinic models.Clinic
+	if err := s.db.Where("id = ? AND hospital_id = ?", clinicID, hospitalID).First(&clinic).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewClinicNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find clinic", err)
+	}
+	return &clinic, nil
+}
+
+// ResolveClinicDay computes when a clinic is open on the local date of day.
+// The weekly hours of the weekday apply unless the day is a holiday the
+// clinic does not work on; a half-day holiday cuts the hours at its closing
+// time. Closures overlapping the day are then cut out of what remains.
+func ResolveClinicDay(day time.Time, hours []models.ClinicOpeningHours, openOnHolidays bool, holiday *models.HospitalHoliday, closures []models.ClinicClosure) models.ClinicCalendarDay {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	next := day.AddDate(0, 0, 1)
+	weekday := workingDayOf(day)
+
+	result := models.ClinicCalendarDay{
+		Date:    day.Format(time.DateOnly),
+		Weekday: weekday,
+		Open:    []models.OpenPeriod{},
+		Holiday: holiday,
+	}
+
+	var periods []models.OpenPeriod
+	for _, h := range hours {
+		if h.Weekday != weekday {
+			continue
+		}
+		opens, err := parseClockMinutes(h.OpensAt)
+		if err != nil {
+			continue
+		}
+		closes, err := parseClockMinutes(h.ClosesAt)
+		if err != nil {
+			continue
+		}
+		periods = append(periods, models.OpenPeriod{
+			Start: day.Add(time.Duration(opens) * time.Minute),
+			End:   day.Add(time.Duration(closes) * time.Minute),
+		})
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+
+	if holiday != nil && !openOnHolidays {
+		cutoff := day
+		if holiday.ClosesAt != "" {
+			if closes, err := parseClockMinutes(holiday.ClosesAt); err == nil {
+				cutoff = day.Add(time.Duration(closes) * time.Minute)
+			}
+		}
+		periods = subtractPeriod(periods, cutoff, next)
+	}
+
+	for _, closure := range closures {
+		if !closure.StartsAt.Before(next) || !closure.EndsAt.After(day) {
+			continue
+		}
+		result.Closures = append(result.Closures, closure)
+		periods = subtractPeriod(periods, closure.StartsAt.In(day.Location()), closure.EndsAt.In(day.Location()))
+	}
+
+	result.Open = append(result.Open, periods...)
+	return result
+}
+
+// subtractPeriod removes [from, to) from each period, splitting periods that
+// surround it.
+func subtractPeriod(periods []models.OpenPeriod, from, to time.Time) []models.OpenPeriod {
+	var result []models.OpenPeriod
+	for _, period := range periods {
+		if !from.Before(period.End) || !to.After(period.Start) {
+			result = append(result, period)
+			continue
+		}
+		if period.Start.Before(from) {
+			result = append(result, models.OpenPeriod{Start: period.Start, End: from})
+		}
+		if to.Before(period.End) {
+			result = append(result, models.OpenPeriod{Start: to, End: period.End})
+		}
+	}
+	return result
+}
+
+func validateOpeningHours(periods []models.OpeningHoursPeriod) error {
+	type span struct{ opens, closes int }
+	byDay := make(map[models.WorkingDay][]span)
+
+	for _, period := range periods {
+		opens, err := parseClockMinutes(period.OpensAt)
+		if err != nil {
+			return apperrors.NewValidationError("opens_at", err.Error())
+		}
+		closes, err := parseClockMinutes(period.ClosesAt)
+		if err != nil {
+			return apperrors.NewValidationError("closes_at", err.Error())
+		}
+		if closes <= opens {
+			return apperrors.NewValidationError("closes_at", fmt.Sprintf("%s period must close after it opens", period.Weekday))
+		}
+		byDay[period.Weekday] = append(byDay[period.Weekday], span{opens, closes})
+	}
+
+	for day, spans := range byDay {
+		sort.Slice(spans, func(i, j int) bool { return spans[i].opens < spans[j].opens })
+		for i := 1; i < len(spans); i++ {
+			if spans[i].opens < spans[i-1].closes {
+				return apperrors.NewValidationError("periods", fmt.Sprintf("%s periods overlap", day))
+			}
+		}
+	}
+	return nil
+}
+
+// parseClockMinutes parses an HH:MM time of day into minutes after midnight.
+// "24:00" is accepted as the end of the day.
+func parseClockMinutes(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time in HH:MM format", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
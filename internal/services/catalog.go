@@ -0,0 +1,470 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// catalogReference is a column pointing at a catalogue entry. Deleting an
+// entry is refused while any row references it, soft-deleted rows included
+// since they can still be restored.
+type catalogReference struct {
+	table  string
+	column string
+}
+
+type catalogTable struct {
+	kind       models.CatalogKind
+	table      string
+	resource   string
+	references []catalogReference
+}
+
+var (
+	clinicTypeCatalog = catalogTable{
+		kind:     models.CatalogClinicType,
+		table:    "clinic_types",
+		resource: "clinic type",
+		references: []catalogReference{
+			{"clinics", "clinic_type_id"},
+		},
+	}
+	professionGroupCatalog = catalogTable{
+		kind:     models.CatalogProfessionGroup,
+		table:    "profession_groups",
+		resource: "profession group",
+		references: []catalogReference{
+			{"staffs", "profession_group_id"},
+			{"staff_versions", "profession_group_id"},
+			{"headcount_snapshots", "profession_group_id"},
+			{"titles", "profession_group_id"},
+			{"staffing_rules", "profession_group_id"},
+		},
+	}
+	titleCatalog = catalogTable{
+		kind:     models.CatalogTitle,
+		table:    "titles",
+		resource: "title",
+		references: []catalogReference{
+			{"staffs", "title_id"},
+			{"staff_versions", "title_id"},
+			{"headcount_snapshots", "title_id"},
+			{"staffing_rules", "title_id"},
+		},
+	}
+)
+
+// CatalogService manages the clinic type, profession group and title
+// catalogue: global defaults shared by every hospital plus private entries a
+// hospital adds for itself.
+type CatalogService struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+func NewCatalogService(db *gorm.DB, redisClient *redis.Client) *CatalogService {
+	return &CatalogService{
+		db:          db,
+		redisClient: redisClient,
+	}
+}
+
+func (s *CatalogService) WithContext(ctx context.Context) *CatalogService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+// visibleCatalog limits a catalogue query to the global entries and the
+// private entries of the hospital.
+func visibleCatalog(hospitalID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(hospital_id IS NULL OR hospital_id = ?)", hospitalID)
+	}
+}
+
+func (s *CatalogService) GetClinicTypes(hospitalID uint) ([]models.ClinicType, error) {
+	var clinicTypes []models.ClinicType
+	if err := s.db.Scopes(visibleCatalog(hospitalID)).Order("hospital_id NULLS FIRST, name").Find(&clinicTypes).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get clinic types", err)
+	}
+	return clinicTypes, nil
+}
+
+func (s *CatalogService) CreateClinicType(req *models.CatalogEntryRequest, hospitalID uint) (*models.ClinicType, error) {
+	if err := s.checkName(clinicTypeCatalog, req.Name, hospitalID, 0, nil); err != nil {
+		return nil, err
+	}
+
+	clinicType := &models.ClinicType{HospitalID: &hospitalID, Name: req.Name}
+	if err := s.db.Create(clinicType).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create clinic type", err)
+	}
+	return clinicType, nil
+}
+
+func (s *CatalogService) UpdateClinicType(id uint, req *models.CatalogEntryRequest, hospitalID uint) (*models.ClinicType, error) {
+	var clinicType models.ClinicType
+	if err := s.findOwn(clinicTypeCatalog, &clinicType, id, hospitalID); err != nil {
+		return nil, err
+	}
+	if err := s.checkName(clinicTypeCatalog, req.Name, hospitalID, id, nil); err != nil {
+		return nil, err
+	}
+
+	clinicType.Name = req.Name
+	if err := s.db.Save(&clinicType).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update clinic type", err)
+	}
+	return &clinicType, nil
+}
+
+func (s *CatalogService) DeleteClinicType(id uint, hospitalID uint) error {
+	return s.deleteOwn(clinicTypeCatalog, &models.ClinicType{}, id, hospitalID)
+}
+
+func (s *CatalogService) GetProfessionGroups(hospitalID uint) ([]models.ProfessionGroup, error) {
+	var professionGroups []models.ProfessionGroup
+	err := s.db.Scopes(visibleCatalog(hospitalID)).
+		Preload("Titles", func(db *gorm.DB) *gorm.DB {
+			return visibleCatalog(hospitalID)(db).Order("hospital_id NULLS FIRST, name")
+		}).
+		Order("hospital_id NULLS FIRST, name").
+		Find(&professionGroups).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get profession groups", err)
+	}
+	return professionGroups, nil
+}
+
+func (s *CatalogService) CreateProfessionGroup(req *models.CatalogEntryRequest, hospitalID uint) (*models.ProfessionGroup, error) {
+	if err := s.checkName(professionGroupCatalog, req.Name, hospitalID, 0, nil); err != nil {
+		return nil, err
+	}
+
+	professionGroup := &models.ProfessionGroup{HospitalID: &hospitalID, Name: req.Name}
+	if err := s.db.Create(professionGroup).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create profession group", err)
+	}
+	return professionGroup, nil
+}
+
+func (s *CatalogService) UpdateProfessionGroup(id uint, req *models.CatalogEntryRequest, hospitalID uint) (*models.ProfessionGroup, error) {
+	var professionGroup models.ProfessionGroup
+	if err := s.findOwn(professionGroupCatalog, &professionGroup, id, hospitalID); err != nil {
+		return nil, err
+	}
+	if err := s.checkName(professionGroupCatalog, req.Name, hospitalID, id, nil); err != nil {
+		return nil, err
+	}
+
+	professionGroup.Name = req.Name
+	if err := s.db.Save(&professionGroup).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update profession group", err)
+	}
+	return &professionGroup, nil
+}
+
+func (s *CatalogService) DeleteProfessionGroup(id uint, hospitalID uint) error {
+	return s.deleteOwn(professionGroupCatalog, &models.ProfessionGroup{}, id, hospitalID)
+}
+
+// CreateTitle adds a private title to a global profession group or to one of
+// the hospital's own groups.
+func (s *CatalogService) CreateTitle(req *models.CreateTitleRequest, hospitalID uint) (*models.Title, error) {
+	var count int64
+	err := s.db.Model(&models.ProfessionGroup{}).Scopes(visibleCatalog(hospitalID)).
+		Where("id = ?", req.ProfessionGroupID).
+		Count(&count).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("find profession group", err)
+	}
+	if count == 0 {
+		return nil, apperrors.NewValidationError("profession_group_id", "profession group not found")
+	}
+	if err := s.checkName(titleCatalog, req.Name, hospitalID, 0, &req.ProfessionGroupID); err != nil {
+		return nil, err
+	}
+
+	title := &models.Title{HospitalID: &hospitalID, Name: req.Name, ProfessionGroupID: req.ProfessionGroupID}
+	if err := s.db.Create(title).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create title", err)
+	}
+	return title, nil
+}
+
+func (s *CatalogService) UpdateTitle(id uint, req *models.CatalogEntryRequest, hospitalID uint) (*models.Title, error) {
+	var title models.Title
+	if err := s.findOwn(titleCatalog, &title, id, hospitalID); err != nil {
+		return nil, err
+	}
+	if err := s.checkName(titleCatalog, req.Name, hospitalID, id, &title.ProfessionGroupID); err != nil {
+		return nil, err
+	}
+
+	title.Name = req.Name
+	if err := s.db.Save(&title).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update title", err)
+	}
+	return &title, nil
+}
+
+func (s *CatalogService) DeleteTitle(id uint, hospitalID uint) error {
+	return s.deleteOwn(titleCatalog, &models.Title{}, id, hospitalID)
+}
+
+// GetPromotionCandidates lists private entries whose name was added by at
+// least minHospitals hospitals, most widely used first.
+func (s *CatalogService) GetPromotionCandidates(minHospitals int) ([]models.CatalogPromotionCandidate, error) {
+	var candidates []models.CatalogPromotionCandidate
+	err := s.db.Raw(`
+		SELECT 'clinic_type' AS kind, MIN(name) AS name, NULL AS profession_group_id,
+			COUNT(DISTINCT hospital_id) AS hospitals, MIN(id) AS entry_id
+		FROM clinic_types WHERE hospital_id IS NOT NULL
+		GROUP BY tr_fold(name) HAVING COUNT(DISTINCT hospital_id) >= @min
+		UNION ALL
+		SELECT 'profession_group', MIN(name), NULL, COUNT(DISTINCT hospital_id), MIN(id)
+		FROM profession_groups WHERE hospital_id IS NOT NULL
+		GROUP BY tr_fold(name) HAVING COUNT(DISTINCT hospital_id) >= @min
+		UNION ALL
+		SELECT 'title', MIN(name), profession_group_id, COUNT(DISTINCT hospital_id), MIN(id)
+		FROM titles WHERE hospital_id IS NOT NULL
+		GROUP BY tr_fold(name), profession_group_id HAVING COUNT(DISTINCT hospital_id) >= @min
+		ORDER BY hospitals DESC, name
+	`, map[string]interface{}{"min": minHospitals}).Scan(&candidates).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get promotion candidates", err)
+	}
+	return candidates, nil
+}
+
+func (s *CatalogService) PromoteClinicType(id uint) (*models.ClinicType, error) {
+	targetID, err := s.promote(clinicTypeCatalog, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var clinicType models.ClinicType
+	if err := s.db.First(&clinicType, targetID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find clinic type", err)
+	}
+	return &clinicType, nil
+}
+
+func (s *CatalogService) PromoteProfessionGroup(id uint) (*models.ProfessionGroup, error) {
+	targetID, err := s.promote(professionGroupCatalog, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var professionGroup models.ProfessionGroup
+	if err := s.db.First(&professionGroup, targetID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find profession group", err)
+	}
+	return &professionGroup, nil
+}
+
+func (s *CatalogService) PromoteTitle(id uint) (*models.Title, error) {
+	targetID, err := s.promote(titleCatalog, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var title models.Title
+	if err := s.db.First(&title, targetID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find title", err)
+	}
+	return &title, nil
+}
+
+// promote turns a private entry into a global one. When a global entry of the
+// same name already exists it becomes the target instead. Every private entry
+// of the same name, in any hospital, is then merged into the target: its
+// references are moved over and the duplicate is removed. Titles are only
+// merged within their profession group, which has to be global already.
+func (s *CatalogService) promote(catalog catalogTable, id uint) (uint, error) {
+	var entry struct {
+		ID                uint
+		HospitalID        *uint
+		Name              string
+		ProfessionGroupID uint
+	}
+	columns := "id, hospital_id, name"
+	if catalog.kind == models.CatalogTitle {
+		columns += ", profession_group_id"
+	}
+	err := s.db.Table(catalog.table).Select(columns).Where("id = ?", id).Take(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, apperrors.NewNotFoundError(catalog.resource, id)
+		}
+		return 0, apperrors.NewDatabaseError("find "+catalog.resource, err)
+	}
+	if entry.HospitalID == nil {
+		return 0, apperrors.NewBusinessRuleError(catalog.resource+" is already global", map[string]interface{}{"id": id})
+	}
+
+	sameName := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Table(catalog.table).Where("tr_fold(name) = tr_fold(?)", entry.Name)
+		if catalog.kind == models.CatalogTitle {
+			tx = tx.Where("profession_group_id = ?", entry.ProfessionGroupID)
+		}
+		return tx
+	}
+
+	if catalog.kind == models.CatalogTitle {
+		var count int64
+		err := s.db.Model(&models.ProfessionGroup{}).Where("id = ? AND hospital_id IS NULL", entry.ProfessionGroupID).Count(&count).Error
+		if err != nil {
+			return 0, apperrors.NewDatabaseError("find profession group", err)
+		}
+		if count == 0 {
+			return 0, apperrors.NewBusinessRuleError("promote the profession group of the title first", map[string]interface{}{
+				"profession_group_id": entry.ProfessionGroupID,
+			})
+		}
+	}
+
+	targetID := entry.ID
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var globalIDs []uint
+		if err := sameName(tx).Where("hospital_id IS NULL").Pluck("id", &globalIDs).Error; err != nil {
+			return err
+		}
+		if len(globalIDs) > 0 {
+			targetID = globalIDs[0]
+		} else if err := tx.Table(catalog.table).Where("id = ?", entry.ID).Update("hospital_id", nil).Error; err != nil {
+			return err
+		}
+
+		var duplicateIDs []uint
+		if err := sameName(tx).Where("hospital_id IS NOT NULL AND id <> ?", targetID).Pluck("id", &duplicateIDs).Error; err != nil {
+			return err
+		}
+		if len(duplicateIDs) == 0 {
+			return nil
+		}
+
+		if catalog.kind == models.CatalogClinicType {
+			if err := checkClinicTypeMerge(tx, targetID, duplicateIDs); err != nil {
+				return err
+			}
+		}
+		for _, ref := range catalog.references {
+			statement := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s IN ?", ref.table, ref.column, ref.column)
+			if err := tx.Exec(statement, targetID, duplicateIDs).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id IN ?", catalog.table), duplicateIDs).Error
+	})
+	if err != nil {
+		if _, ok := apperrors.IsAppError(err); ok {
+			return 0, err
+		}
+		return 0, apperrors.NewDatabaseError("promote "+catalog.resource, err)
+	}
+
+	log.Info().Str("kind", string(catalog.kind)).Uint("id", id).Uint("global_id", targetID).Msg("Catalog entry promoted")
+	if catalog.kind != models.CatalogClinicType {
+		s.invalidateProfessionGroups()
+	}
+	return targetID, nil
+}
+
+// checkClinicTypeMerge refuses to merge clinic types when a hospital already
+// runs a clinic of the target type next to one of a duplicate, since a
+// hospital can have only one clinic per type.
+func checkClinicTypeMerge(tx *gorm.DB, targetID uint, duplicateIDs []uint) error {
+	var hospitalIDs []uint
+	err := tx.Model(&models.Clinic{}).
+		Where("clinic_type_id IN ?", duplicateIDs).
+		Where("hospital_id IN (?)", tx.Model(&models.Clinic{}).Select("hospital_id").Where("clinic_type_id = ?", targetID)).
+		Distinct().
+		Pluck("hospital_id", &hospitalIDs).Error
+	if err != nil {
+		return err
+	}
+	if len(hospitalIDs) > 0 {
+		return apperrors.NewBusinessRuleError("hospitals already have clinics of both clinic types", map[string]interface{}{
+			"hospital_ids": hospitalIDs,
+		})
+	}
+	return nil
+}
+
+// findOwn loads a private entry of the hospital. Global entries are read-only
+// for hospitals and reported as not found.
+func (s *CatalogService) findOwn(catalog catalogTable, dest interface{}, id, hospitalID uint) error {
+	err := s.db.Where("id = ? AND hospital_id = ?", id, hospitalID).First(dest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewNotFoundError(catalog.resource, id)
+		}
+		return apperrors.NewDatabaseError("find "+catalog.resource, err)
+	}
+	return nil
+}
+
+func (s *CatalogService) deleteOwn(catalog catalogTable, model interface{}, id, hospitalID uint) error {
+	if err := s.findOwn(catalog, model, id, hospitalID); err != nil {
+		return err
+	}
+
+	usage := make(map[string]interface{})
+	for _, ref := range catalog.references {
+		var count int64
+		if err := s.db.Table(ref.table).Where(ref.column+" = ?", id).Count(&count).Error; err != nil {
+			return apperrors.NewDatabaseError("check "+catalog.resource+" usage", err)
+		}
+		if count > 0 {
+			usage[ref.table] = count
+		}
+	}
+	if len(usage) > 0 {
+		return apperrors.NewBusinessRuleError(catalog.resource+" is in use and cannot be deleted", usage)
+	}
+
+	if err := s.db.Delete(model).Error; err != nil {
+		return apperrors.NewDatabaseError("delete "+catalog.resource, err)
+	}
+	return nil
+}
+
+// checkName rejects a name that is already taken by a global entry or by
+// another private entry of the hospital, ignoring case and Turkish letters.
+// Titles only clash within their profession group.
+func (s *CatalogService) checkName(catalog catalogTable, name string, hospitalID, excludeID uint, professionGroupID *uint) error {
+	query := s.db.Table(catalog.table).Scopes(visibleCatalog(hospitalID)).
+		Where("tr_fold(name) = tr_fold(?) AND id <> ?", name, excludeID)
+	if professionGroupID != nil {
+		query = query.Where("profession_group_id = ?", *professionGroupID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperrors.NewDatabaseError("check "+catalog.resource+" name", err)
+	}
+	if count > 0 {
+		return apperrors.NewConflictError("name", name, catalog.resource)
+	}
+	return nil
+}
+
+// invalidateProfessionGroups drops the cached public profession group list,
+// which only holds global entries and so only changes on promotion.
+func (s *CatalogService) invalidateProfessionGroups() {
+	if s.redisClient == nil {
+		return
+	}
+	if err := s.redisClient.Del(context.Background(), "profession_groups").Err(); err != nil {
+		log.Warn().Err(err).Msg("Failed to invalidate profession group cache")
+	}
+}
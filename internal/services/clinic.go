@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"math"
 
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
 	"github.com/caner-cetin/hospital-tracker/internal/models"
 	"gorm.io/gorm"
 )
@@ -17,9 +20,15 @@ func NewClinicService(db *gorm.DB) *ClinicService {
 	}
 }
 
+func (s *ClinicService) WithContext(ctx context.Context) *ClinicService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
 func (s *ClinicService) CreateClinic(req *models.CreateClinicRequest, hospitalID uint) (*models.Clinic, error) {
 	var clinicType models.ClinicType
-	if err := s.db.First(&clinicType, req.ClinicTypeID).Error; err != nil {
+	if err := s.db.Scopes(visibleCatalog(hospitalID)).First(&clinicType, req.ClinicTypeID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("clinic type not found")
 		}
@@ -50,44 +59,124 @@ func (s *ClinicService) CreateClinic(req *models.CreateClinicRequest, hospitalID
 	return clinic, nil
 }
 
-func (s *ClinicService) GetClinics(hospitalID uint) ([]models.ClinicSummary, error) {
+const (
+	defaultClinicPageLimit = 20
+	maxClinicPageLimit     = 100
+)
+
+// GetClinics returns one page of clinic summaries. The page is built with a
+// fixed number of queries however many clinics it holds: the clinics with
+// their preloads, and a single aggregate for the staff counts.
+func (s *ClinicService) GetClinics(filter *models.ClinicFilterRequest, hospitalID uint) (*models.ClinicPaginatedResponse, error) {
+	query := s.db.Model(&models.Clinic{}).Where("hospital_id = ?", hospitalID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("count clinics", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultClinicPageLimit
+	}
+	if filter.Limit > maxClinicPageLimit {
+		filter.Limit = maxClinicPageLimit
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	summaries, err := s.clinicSummaries(hospitalID, (filter.Page-1)*filter.Limit, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ClinicPaginatedResponse{
+		Data: summaries,
+		BasePagination: models.BasePagination{
+			TotalCount: totalCount,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: int(math.Ceil(float64(totalCount) / float64(filter.Limit))),
+		},
+	}, nil
+}
+
+// GetAllClinicSummaries returns the summaries of every clinic of the
+// hospital, for exports and reports that are not paginated.
+func (s *ClinicService) GetAllClinicSummaries(hospitalID uint) ([]models.ClinicSummary, error) {
+	return s.clinicSummaries(hospitalID, 0, -1)
+}
+
+// clinicSummaries loads the clinics ordered by ID, a limit of -1 meaning no
+// limit, and fills in the staff counts from one grouped query.
+func (s *ClinicService) clinicSummaries(hospitalID uint, offset, limit int) ([]models.ClinicSummary, error) {
 	var clinics []models.Clinic
 	err := s.db.Where("hospital_id = ?", hospitalID).
 		Preload("ClinicType").
+		Preload("HeadStaff.Title").
+		Preload("Rooms", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		Order("id").
+		Offset(offset).Limit(limit).
 		Find(&clinics).Error
 	if err != nil {
-		return nil, err
+		return nil, apperrors.NewDatabaseError("get clinics", err)
 	}
 
 	summaries := make([]models.ClinicSummary, 0, len(clinics))
+	if len(clinics) == 0 {
+		return summaries, nil
+	}
+
+	clinicIDs := make([]uint, len(clinics))
+	for i, clinic := range clinics {
+		clinicIDs[i] = clinic.ID
+	}
+
+	var professionCounts []struct {
+		ClinicID            uint
+		ProfessionGroupName string
+		Count               int64
+	}
+	err = s.db.Raw(`
+		SELECT s.clinic_id, pg.name AS profession_group_name, COUNT(*) AS count
+		FROM staffs s
+		JOIN profession_groups pg ON s.profession_group_id = pg.id
+		WHERE s.hospital_id = ? AND s.clinic_id IN ? AND s.deleted_at IS NULL
+		GROUP BY s.clinic_id, pg.id, pg.name
+		ORDER BY pg.id
+	`, hospitalID, clinicIDs).Scan(&professionCounts).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count clinic staff", err)
+	}
+
+	byClinic := make(map[uint][]models.StaffProfessionSummary, len(clinics))
+	totals := make(map[uint]int64, len(clinics))
+	for _, pc := range professionCounts {
+		byClinic[pc.ClinicID] = append(byClinic[pc.ClinicID], models.StaffProfessionSummary{
+			ProfessionGroup: pc.ProfessionGroupName,
+			Count:           pc.Count,
+		})
+		totals[pc.ClinicID] += pc.Count
+	}
+
 	for _, clinic := range clinics {
 		summary := models.ClinicSummary{
-			ID:         clinic.ID,
-			ClinicType: clinic.ClinicType,
+			ID:                clinic.ID,
+			ClinicType:        clinic.ClinicType,
+			Name:              clinic.DisplayName(),
+			Floor:             clinic.Floor,
+			Building:          clinic.Building,
+			PhoneExtension:    clinic.PhoneExtension,
+			HeadOfClinic:      clinicHeadSummary(clinic.HeadStaff),
+			Rooms:             clinic.Rooms,
+			TotalStaff:        totals[clinic.ID],
+			StaffByProfession: byClinic[clinic.ID],
 		}
-
-		var totalStaff int64
-		s.db.Model(&models.Staff{}).Where("clinic_id = ? AND hospital_id = ?", clinic.ID, hospitalID).Count(&totalStaff)
-		summary.TotalStaff = totalStaff
-
-		var professionCounts []struct {
-			ProfessionGroupName string `json:"profession_group_name"`
-			Count               int64  `json:"count"`
+		if summary.Rooms == nil {
+			summary.Rooms = []models.ClinicRoom{}
 		}
-
-		s.db.Raw(`
-			SELECT pg.name as profession_group_name, COUNT(*) as count
-			FROM staffs s
-			JOIN profession_groups pg ON s.profession_group_id = pg.id
-			WHERE s.clinic_id = ? AND s.hospital_id = ?
-			GROUP BY pg.id, pg.name
-		`, clinic.ID, hospitalID).Scan(&professionCounts)
-
-		for _, pc := range professionCounts {
-			summary.StaffByProfession = append(summary.StaffByProfession, models.StaffProfessionSummary{
-				ProfessionGroup: pc.ProfessionGroupName,
-				Count:           pc.Count,
-			})
+		for _, room := range clinic.Rooms {
+			summary.TotalCapacity += room.Capacity
 		}
 
 		summaries = append(summaries, summary)
@@ -98,7 +187,7 @@ func (s *ClinicService) GetClinics(hospitalID uint) ([]models.ClinicSummary, err
 
 func (s *ClinicService) GetClinicTypes() ([]models.ClinicType, error) {
 	var clinicTypes []models.ClinicType
-	err := s.db.Find(&clinicTypes).Error
+	err := s.db.Where("hospital_id IS NULL").Find(&clinicTypes).Error
 	return clinicTypes, err
 }
 
@@ -119,3 +208,159 @@ func (s *ClinicService) DeleteClinic(clinicID uint, hospitalID uint) error {
 
 	return s.db.Delete(&clinic).Error
 }
+
+func (s *ClinicService) GetClinic(clinicID uint, hospitalID uint) (*models.Clinic, error) {
+	var clinic models.Clinic
+	err := s.db.Where("id = ? AND hospital_id = ?", clinicID, hospitalID).
+		Preload("ClinicType").
+		Preload("HeadStaff.Title").
+		Preload("Rooms", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		First(&clinic).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewClinicNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find clinic", err)
+	}
+	return &clinic, nil
+}
+
+// UpdateClinic replaces the display name, location, phone extension and head
+// of a clinic. The head has to be a current staff member of the clinic.
+func (s *ClinicService) UpdateClinic(clinicID uint, req *models.UpdateClinicRequest, hospitalID uint) (*models.Clinic, error) {
+	clinic, err := s.GetClinic(clinicID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.HeadStaffID != nil {
+		var head models.Staff
+		err := s.db.Where("id = ? AND hospital_id = ?", *req.HeadStaffID, hospitalID).First(&head).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.NewStaffNotFoundError()
+			}
+			return nil, apperrors.NewDatabaseError("find staff", err)
+		}
+		if head.ClinicID == nil || *head.ClinicID != clinic.ID || head.Status == models.StaffTerminated {
+			return nil, apperrors.NewBusinessRuleError("head of clinic must be a staff member of the clinic", map[string]interface{}{
+				"clinic_id": clinic.ID,
+				"staff_id":  head.ID,
+			})
+		}
+	}
+
+	err = s.db.Model(&models.Clinic{}).Where("id = ?", clinic.ID).Updates(map[string]interface{}{
+		"name":            req.Name,
+		"floor":           req.Floor,
+		"building":        req.Building,
+		"phone_extension": req.PhoneExtension,
+		"head_staff_id":   req.HeadStaffID,
+	}).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("update clinic", err)
+	}
+
+	return s.GetClinic(clinic.ID, hospitalID)
+}
+
+func (s *ClinicService) GetRooms(clinicID uint, hospitalID uint) ([]models.ClinicRoom, error) {
+	if _, err := s.GetClinic(clinicID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	var rooms []models.ClinicRoom
+	if err := s.db.Where("clinic_id = ?", clinicID).Order("name").Find(&rooms).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get clinic rooms", err)
+	}
+	return rooms, nil
+}
+
+func (s *ClinicService) CreateRoom(clinicID uint, req *models.ClinicRoomRequest, hospitalID uint) (*models.ClinicRoom, error) {
+	if _, err := s.GetClinic(clinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	if err := s.checkRoomName(clinicID, 0, req.Name); err != nil {
+		return nil, err
+	}
+
+	room := &models.ClinicRoom{
+		ClinicID:   clinicID,
+		HospitalID: hospitalID,
+		Name:       req.Name,
+		Kind:       req.Kind,
+		Capacity:   req.Capacity,
+	}
+	if err := s.db.Create(room).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create clinic room", err)
+	}
+	return room, nil
+}
+
+func (s *ClinicService) UpdateRoom(clinicID, roomID uint, req *models.ClinicRoomRequest, hospitalID uint) (*models.ClinicRoom, error) {
+	room, err := s.findRoom(clinicID, roomID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRoomName(clinicID, room.ID, req.Name); err != nil {
+		return nil, err
+	}
+
+	room.Name = req.Name
+	room.Kind = req.Kind
+	room.Capacity = req.Capacity
+	if err := s.db.Save(room).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update clinic room", err)
+	}
+	return room, nil
+}
+
+func (s *ClinicService) DeleteRoom(clinicID, roomID uint, hospitalID uint) error {
+	room, err := s.findRoom(clinicID, roomID, hospitalID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(room).Error; err != nil {
+		return apperrors.NewDatabaseError("delete clinic room", err)
+	}
+	return nil
+}
+
+func (s *ClinicService) findRoom(clinicID, roomID, hospitalID uint) (*models.ClinicRoom, error) {
+	var room models.ClinicRoom
+	err := s.db.Where("id = ? AND clinic_id = ? AND hospital_id = ?", roomID, clinicID, hospitalID).First(&room).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("clinic room", roomID)
+		}
+		return nil, apperrors.NewDatabaseError("find clinic room", err)
+	}
+	return &room, nil
+}
+
+func (s *ClinicService) checkRoomName(clinicID, roomID uint, name string) error {
+	var count int64
+	err := s.db.Model(&models.ClinicRoom{}).
+		Where("clinic_id = ? AND name = ? AND id <> ?", clinicID, name, roomID).
+		Count(&count).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("check clinic room name", err)
+	}
+	if count > 0 {
+		return apperrors.NewConflictError("name", name, "clinic room")
+	}
+	return nil
+}
+
+func clinicHeadSummary(head *models.Staff) *models.ClinicHeadSummary {
+	if head == nil {
+		return nil
+	}
+	return &models.ClinicHeadSummary{
+		ID:        head.ID,
+		FirstName: head.FirstName,
+		LastName:  head.LastName,
+		Title:     head.Title.Name,
+	}
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultCredentialWarningDays = 30
+	credentialCheckBatchSize     = 100
+)
+
+type CredentialService struct {
+	db          *gorm.DB
+	notifier    Notifier
+	warningDays int
+}
+
+func NewCredentialService(db *gorm.DB, notifier Notifier, cfg *config.Config) *CredentialService {
+	warningDays := cfg.Credentials.ExpiryWarningDays
+	if warningDays <= 0 {
+		warningDays = defaultCredentialWarningDays
+	}
+
+	return &CredentialService{
+		db:          db,
+		notifier:    notifier,
+		warningDays: warningDays,
+	}
+}
+
+func (s *CredentialService) WithContext(ctx context.Context) *CredentialService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+func (s *CredentialService) CreateCredential(staffID uint, req *models.CredentialRequest, hospitalID uint) (*models.StaffCredential, error) {
+	if err := s.checkStaff(staffID, hospitalID); err != nil {
+		return nil, err
+	}
+	if err := validateCredentialDates(req); err != nil {
+		return nil, err
+	}
+
+	credential := &models.StaffCredential{
+		StaffID:    staffID,
+		HospitalID: hospitalID,
+	}
+	applyCredentialRequest(credential, req)
+
+	if err := s.db.Create(credential).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create credential", err)
+	}
+
+	credential.Status = CredentialStatusAt(credential, time.Now(), s.warningDays)
+	return credential, nil
+}
+
+func (s *CredentialService) UpdateCredential(staffID, credentialID uint, req *models.CredentialRequest, hospitalID uint) (*models.StaffCredential, error) {
+	credential, err := s.findCredential(staffID, credentialID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCredentialDates(req); err != nil {
+		return nil, err
+	}
+
+	// a renewed credential has to be able to warn again before its new expiry
+	if !sameDate(credential.ExpiresAt, req.ExpiresAt) {
+		credential.NotifiedStatus = ""
+	}
+	applyCredentialRequest(credential, req)
+
+	if err := s.db.Save(credential).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update credential", err)
+	}
+
+	credential.Status = CredentialStatusAt(credential, time.Now(), s.warningDays)
+	return credential, nil
+}
+
+func (s *CredentialService) DeleteCredential(staffID, credentialID uint, hospitalID uint) error {
+	credential, err := s.findCredential(staffID, credentialID, hospitalID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(credential).Error; err != nil {
+		return apperrors.NewDatabaseError("delete credential", err)
+	}
+	return nil
+}
+
+func (s *CredentialService) GetCredentials(staffID uint, hospitalID uint) ([]models.StaffCredential, error) {
+	if err := s.checkStaff(staffID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	var credentials []models.StaffCredential
+	err := s.db.Where("staff_id = ? AND hospital_id = ?", staffID, hospitalID).
+		Order("expires_at ASC NULLS LAST, id").
+		Find(&credentials).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get credentials", err)
+	}
+
+	s.fillStatus(credentials, time.Now())
+	return credentials, nil
+}
+
+// GetExpiringCredentials lists the hospital's credentials that have expired
+// or expire within the given number of days.
+func (s *CredentialService) GetExpiringCredentials(filter *models.ExpiringCredentialFilterRequest, hospitalID uint) ([]models.StaffCredential, error) {
+	until := startOfDay(time.Now()).AddDate(0, 0, filter.Days)
+
+	var credentials []models.StaffCredential
+	err := s.db.Joins("JOIN staffs ON staffs.id = staff_credentials.staff_id AND staffs.deleted_at IS NULL AND staffs.status <> 'terminated'").
+		Where("staff_credentials.hospital_id = ? AND staff_credentials.expires_at <= ?", hospitalID, until).
+		Preload("Staff").
+		Order("staff_credentials.expires_at, staff_credentials.id").
+		Find(&credentials).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get expiring credentials", err)
+	}
+
+	s.fillStatus(credentials, time.Now())
+	return credentials, nil
+}
+
+// CheckExpiry sends an expiring-soon notification for credentials entering
+// the warning window and an expired notification for credentials past their
+// expiry date. The notified status is claimed with a conditional update
+// before sending, so concurrent replicas never notify twice.
+func (s *CredentialService) CheckExpiry(ctx context.Context, now time.Time) (int, error) {
+	today := startOfDay(now)
+	warnUntil := today.AddDate(0, 0, s.warningDays)
+
+	sent := 0
+	checks := []struct {
+		status    models.CredentialStatus
+		condition string
+		vars      []interface{}
+	}{
+		{
+			status:    models.CredentialExpired,
+			condition: "staff_credentials.expires_at < ? AND staff_credentials.notified_status <> ?",
+			vars:      []interface{}{today, models.CredentialExpired},
+		},
+		{
+			status:    models.CredentialExpiringSoon,
+			condition: "staff_credentials.expires_at >= ? AND staff_credentials.expires_at <= ? AND staff_credentials.notified_status = ''",
+			vars:      []interface{}{today, warnUntil},
+		},
+	}
+
+	for _, check := range checks {
+		var batch []models.StaffCredential
+		result := s.db.Joins("JOIN staffs ON staffs.id = staff_credentials.staff_id AND staffs.deleted_at IS NULL AND staffs.status <> 'terminated'").
+			Where(check.condition, check.vars...).
+			Preload("Staff").
+			FindInBatches(&batch, credentialCheckBatchSize, func(tx *gorm.DB, _ int) error {
+				for i := range batch {
+					notified, err := s.notifyExpiry(ctx, &batch[i], check.status)
+					if err != nil {
+						return err
+					}
+					if notified {
+						sent++
+					}
+				}
+				return nil
+			})
+		if result.Error != nil {
+			return sent, apperrors.NewDatabaseError("check credential expiry", result.Error)
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *CredentialService) notifyExpiry(ctx context.Context, credential *models.StaffCredential, status models.CredentialStatus) (bool, error) {
+	claim := s.db.Model(&models.StaffCredential{}).
+		Where("id = ? AND notified_status = ?", credential.ID, credential.NotifiedStatus).
+		Update("notified_status", status)
+	if claim.Error != nil {
+		return false, claim.Error
+	}
+	if claim.RowsAffected == 0 {
+		return false, nil
+	}
+
+	notification := Notification{
+		HospitalID: credential.HospitalID,
+		Type:       NotificationCredentialExpiringSoon,
+		Title:      "Credential expiring soon",
+		Data: map[string]interface{}{
+			"credential_id": credential.ID,
+			"staff_id":      credential.StaffID,
+			"type":          credential.Type,
+			"expires_at":    credential.ExpiresAt.Format(time.DateOnly),
+			"mandatory":     credential.Mandatory,
+		},
+	}
+	staffName := ""
+	if credential.Staff != nil {
+		staffName = credential.Staff.FirstName + " " + credential.Staff.LastName
+	}
+	notification.Message = fmt.Sprintf("%s of %s expires on %s", credential.Name, staffName, credential.ExpiresAt.Format(time.DateOnly))
+	if status == models.CredentialExpired {
+		notification.Type = NotificationCredentialExpired
+		notification.Title = "Credential expired"
+		notification.Message = fmt.Sprintf("%s of %s expired on %s", credential.Name, staffName, credential.ExpiresAt.Format(time.DateOnly))
+	}
+
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		log.Error().Err(err).Uint("credential_id", credential.ID).Str("type", notification.Type).Msg("Failed to send credential notification")
+	}
+	return true, nil
+}
+
+func (s *CredentialService) fillStatus(credentials []models.StaffCredential, now time.Time) {
+	for i := range credentials {
+		credentials[i].Status = CredentialStatusAt(&credentials[i], now, s.warningDays)
+	}
+}
+
+func (s *CredentialService) checkStaff(staffID, hospitalID uint) error {
+	var count int64
+	if err := s.db.Model(&models.Staff{}).Where("id = ? AND hospital_id = ?", staffID, hospitalID).Count(&count).Error; err != nil {
+		return apperrors.NewDatabaseError("find staff", err)
+	}
+	if count == 0 {
+		return apperrors.NewStaffNotFoundError()
+	}
+	return nil
+}
+
+func (s *CredentialService) findCredential(staffID, credentialID, hospitalID uint) (*models.StaffCredential, error) {
+	var credential models.StaffCredential
+	err := s.db.Where("id = ? AND staff_id = ? AND hospital_id = ?", credentialID, staffID, hospitalID).First(&credential).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("credential", credentialID)
+		}
+		return nil, apperrors.NewDatabaseError("find credential", err)
+	}
+	return &credential, nil
+}
+
+// CredentialStatusAt reports whether a credential is valid, inside the
+// warning window or expired on the day of now. The expiry date is the last
+// day the credential is valid.
+func CredentialStatusAt(credential *models.StaffCredential, now time.Time, warningDays int) models.CredentialStatus {
+	if credential.ExpiresAt == nil {
+		return models.CredentialValid
+	}
+
+	today := startOfDay(now)
+	expiresAt := startOfDay(*credential.ExpiresAt)
+	switch {
+	case expiresAt.Before(today):
+		return models.CredentialExpired
+	case !expiresAt.After(today.AddDate(0, 0, warningDays)):
+		return models.CredentialExpiringSoon
+	default:
+		return models.CredentialValid
+	}
+}
+
+func applyCredentialRequest(credential *models.StaffCredential, req *models.CredentialRequest) {
+	credential.Type = req.Type
+	credential.Name = req.Name
+	credential.Number = req.Number
+	credential.IssuingBody = req.IssuingBody
+	credential.IssuedAt = startOfDay(req.IssuedAt)
+	credential.ExpiresAt = nil
+	if req.ExpiresAt != nil {
+		expiresAt := startOfDay(*req.ExpiresAt)
+		credential.ExpiresAt = &expiresAt
+	}
+	credential.DocumentRef = req.DocumentRef
+	credential.Mandatory = req.Mandatory
+}
+
+func validateCredentialDates(req *models.CredentialRequest) error {
+	if req.ExpiresAt != nil && startOfDay(*req.ExpiresAt).Before(startOfDay(req.IssuedAt)) {
+		return apperrors.NewValidationError("expires_at", "expiry date cannot be before the issue date")
+	}
+	return nil
+}
+
+// startOfDay truncates t to midnight UTC of its calendar date, matching how
+// date columns are read back.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return startOfDay(*a).Equal(startOfDay(*b))
+}
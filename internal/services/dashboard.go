@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	dashboardCacheTTL              = 10 * time.Minute
+	dashboardCachePrefix           = "dashboard:"
+	dashboardGenerationKey         = "dashboard-generation"
+	dashboardGenerationPrefix      = "dashboard-generation:"
+	dashboardChangeWindow          = 30 * 24 * time.Hour
+	dashboardInvalidationCursorKey = "dashboard-invalidation:cursor"
+	dashboardInvalidationLockKey   = "dashboard-invalidation:lock"
+	dashboardInvalidationInterval  = time.Second
+	dashboardInvalidationLockTTL   = 10 * time.Second
+	dashboardInvalidationBatch     = 500
+	doctorProfessionGroup          = "Doktor"
+)
+
+// DashboardTables are the tables whose writes invalidate cached dashboards.
+var DashboardTables = []string{"staffs", "clinics", "users", "clinic_types", "profession_groups", "titles"}
+
+// setDashboardScript caches a built dashboard only if neither its hospital's
+// generation nor the global one moved while it was being built, so a build
+// that raced an invalidation cannot put the stale result back.
+var setDashboardScript = redis.NewScript(`
+if (redis.call("get", KEYS[2]) or "0") ~= ARGV[1] or (redis.call("get", KEYS[3]) or "0") ~= ARGV[2] then
+	return 0
+end
+redis.call("set", KEYS[1], ARGV[3], "PX", ARGV[4])
+return 1
+`)
+
+type DashboardService struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+func NewDashboardService(db *gorm.DB, redisClient *redis.Client) *DashboardService {
+	return &DashboardService{
+		db:          db,
+		redisClient: redisClient,
+	}
+}
+
+// GetDashboard returns the hospital overview from the cache, building and
+// caching it when missing. Cached dashboards are dropped by Invalidate and
+// otherwise expire after dashboardCacheTTL, which also moves the 30 day
+// window along. The generations are read before the build, and the result
+// is only cached if Invalidate has not bumped them in the meantime.
+func (s *DashboardService) GetDashboard(hospitalID uint) (*models.DashboardResponse, error) {
+	ctx := context.Background()
+	cacheKey := dashboardCacheKey(hospitalID)
+	generationKeys := []string{dashboardHospitalGenerationKey(hospitalID), dashboardGenerationKey}
+
+	var generations []interface{}
+	if s.redisClient != nil {
+		if cachedData, err := s.redisClient.Get(ctx, cacheKey).Result(); err == nil {
+			var dashboard models.DashboardResponse
+			if err := json.Unmarshal([]byte(cachedData), &dashboard); err == nil {
+				return &dashboard, nil
+			}
+		}
+		generations, _ = s.redisClient.MGet(ctx, generationKeys...).Result()
+	}
+
+	dashboard, err := s.buildDashboard(hospitalID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(generations) == len(generationKeys) {
+		if data, err := json.Marshal(dashboard); err == nil {
+			args := []interface{}{dashboardGeneration(generations[0]), dashboardGeneration(generations[1]), data, dashboardCacheTTL.Milliseconds()}
+			setDashboardScript.Run(ctx, s.redisClient, append([]string{cacheKey}, generationKeys...), args...)
+		}
+	}
+
+	return dashboard, nil
+}
+
+// RunInvalidation drops cached dashboards every second until ctx is
+// cancelled, while this replica holds the invalidation lock.
+func (s *DashboardService) RunInvalidation(ctx context.Context) {
+	lock := NewRedisLock(s.redisClient, dashboardInvalidationLockKey, dashboardInvalidationLockTTL)
+	defer func() { _ = lock.Release(context.Background()) }()
+
+	ticker := time.NewTicker(dashboardInvalidationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		held, err := lock.Acquire(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Dashboard invalidation lock failed")
+			continue
+		}
+		if !held {
+			continue
+		}
+		if _, err := s.Invalidate(ctx); err != nil {
+			log.Error().Err(err).Msg("Dashboard invalidation failed")
+		}
+	}
+}
+
+// Invalidate drops the cached dashboards of the hospitals written to since
+// the last call and returns how many audit entries it went through. It reads
+// the audit log, which is written in the same transaction as the change, so
+// a dashboard is only dropped once the change is committed and a rebuild
+// sees it. Entries without a hospital, such as changes to the global
+// catalogue, drop every dashboard, as does the first call, which has no
+// cursor to start from.
+func (s *DashboardService) Invalidate(ctx context.Context) (int, error) {
+	var latest uint
+	if err := s.db.Model(&models.AuditLog{}).Select("COALESCE(MAX(id), 0)").Scan(&latest).Error; err != nil {
+		return 0, err
+	}
+
+	cursor, err := s.redisClient.Get(ctx, dashboardInvalidationCursorKey).Uint64()
+	if errors.Is(err, redis.Nil) {
+		if err := s.dropAll(ctx); err != nil {
+			return 0, err
+		}
+		return 0, s.redisClient.Set(ctx, dashboardInvalidationCursorKey, latest, 0).Err()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var entries []models.AuditLog
+	err = s.db.Select("id", "hospital_id").
+		Where("resource IN ? AND id > ? AND id <= ?", DashboardTables, cursor, latest).
+		Order("id").
+		Limit(dashboardInvalidationBatch).
+		Find(&entries).Error
+	if err != nil {
+		return 0, err
+	}
+
+	hospitals := map[uint]bool{}
+	all := false
+	for _, entry := range entries {
+		if entry.HospitalID == nil {
+			all = true
+			break
+		}
+		hospitals[*entry.HospitalID] = true
+	}
+	if all {
+		err = s.dropAll(ctx)
+	} else if len(hospitals) > 0 {
+		_, err = s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for hospitalID := range hospitals {
+				pipe.Incr(ctx, dashboardHospitalGenerationKey(hospitalID))
+				pipe.Del(ctx, dashboardCacheKey(hospitalID))
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// a full batch may leave entries behind; otherwise skip ahead past the
+	// entries of other tables
+	next := latest
+	if len(entries) == dashboardInvalidationBatch {
+		next = entries[len(entries)-1].ID
+	}
+	return len(entries), s.redisClient.Set(ctx, dashboardInvalidationCursorKey, next, 0).Err()
+}
+
+func (s *DashboardService) dropAll(ctx context.Context) error {
+	if err := s.redisClient.Incr(ctx, dashboardGenerationKey).Err(); err != nil {
+		return err
+	}
+	iter := s.redisClient.Scan(ctx, 0, dashboardCachePrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := s.redisClient.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func (s *DashboardService) buildDashboard(hospitalID uint, now time.Time) (*models.DashboardResponse, error) {
+	dashboard := &models.DashboardResponse{
+		GeneratedAt:           now,
+		ByProfessionGroup:     []models.DashboardProfessionHeadcount{},
+		ByTitle:               []models.DashboardTitleHeadcount{},
+		ByClinic:              []models.DashboardClinicHeadcount{},
+		ClinicsWithoutDoctors: []models.DashboardClinicHeadcount{},
+		UnassignedStaff:       []models.DashboardStaffSummary{},
+		UsersByType:           map[models.UserType]int64{},
+	}
+
+	err := s.db.Raw(`
+		SELECT pg.id AS profession_group_id, pg.name AS profession_group, COUNT(*) AS count
+		FROM staffs s
+		JOIN profession_groups pg ON pg.id = s.profession_group_id
+		WHERE s.hospital_id = ? AND s.deleted_at IS NULL AND s.status <> ?
+		GROUP BY pg.id, pg.name
+		ORDER BY pg.name
+	`, hospitalID, models.StaffTerminated).Scan(&dashboard.ByProfessionGroup).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count staff by profession group", err)
+	}
+	for _, group := range dashboard.ByProfessionGroup {
+		dashboard.Headcount += group.Count
+	}
+
+	err = s.db.Raw(`
+		SELECT t.id AS title_id, t.name AS title, pg.name AS profession_group, COUNT(*) AS count
+		FROM staffs s
+		JOIN titles t ON t.id = s.title_id
+		JOIN profession_groups pg ON pg.id = t.profession_group_id
+		WHERE s.hospital_id = ? AND s.deleted_at IS NULL AND s.status <> ?
+		GROUP BY t.id, t.name, pg.name
+		ORDER BY pg.name, t.name
+	`, hospitalID, models.StaffTerminated).Scan(&dashboard.ByTitle).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count staff by title", err)
+	}
+
+	err = s.db.Raw(`
+		SELECT c.id AS clinic_id, COALESCE(NULLIF(c.name, ''), ct.name) AS name,
+			COUNT(s.id) AS count,
+			COUNT(s.id) FILTER (WHERE pg.name = ? AND pg.hospital_id IS NULL) AS doctors
+		FROM clinics c
+		JOIN clinic_types ct ON ct.id = c.clinic_type_id
+		LEFT JOIN staffs s ON s.clinic_id = c.id AND s.deleted_at IS NULL AND s.status <> ?
+		LEFT JOIN profession_groups pg ON pg.id = s.profession_group_id
+		WHERE c.hospital_id = ? AND c.deleted_at IS NULL
+		GROUP BY c.id, c.name, ct.name
+		ORDER BY 2
+	`, doctorProfessionGroup, models.StaffTerminated, hospitalID).Scan(&dashboard.ByClinic).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count staff by clinic", err)
+	}
+	for _, clinic := range dashboard.ByClinic {
+		if clinic.Doctors == 0 {
+			dashboard.ClinicsWithoutDoctors = append(dashboard.ClinicsWithoutDoctors, clinic)
+		}
+	}
+
+	var unassigned []models.Staff
+	err = s.db.Where("hospital_id = ? AND clinic_id IS NULL AND status <> ?", hospitalID, models.StaffTerminated).
+		Preload("ProfessionGroup").
+		Preload("Title").
+		Order("last_name, first_name, id").
+		Find(&unassigned).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get unassigned staff", err)
+	}
+	for _, staff := range unassigned {
+		dashboard.UnassignedStaff = append(dashboard.UnassignedStaff, models.DashboardStaffSummary{
+			ID:              staff.ID,
+			FirstName:       staff.FirstName,
+			LastName:        staff.LastName,
+			Status:          staff.Status,
+			ProfessionGroup: staff.ProfessionGroup.Name,
+			Title:           staff.Title.Name,
+		})
+	}
+
+	since := now.Add(-dashboardChangeWindow)
+	dashboard.StaffChanges.Since = since
+	err = s.db.Unscoped().Model(&models.Staff{}).
+		Where("hospital_id = ? AND created_at >= ?", hospitalID, since).
+		Count(&dashboard.StaffChanges.Added).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count added staff", err)
+	}
+	err = s.db.Unscoped().Model(&models.Staff{}).
+		Where("hospital_id = ?", hospitalID).
+		Where("deleted_at >= ? OR EXISTS (?)", since,
+			s.db.Model(&models.StaffStatusChange{}).Select("1").
+				Where("staff_status_changes.staff_id = staffs.id AND to_status = ? AND effective_at >= ?", models.StaffTerminated, since)).
+		Count(&dashboard.StaffChanges.Removed).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count removed staff", err)
+	}
+
+	var userCounts []struct {
+		UserType models.UserType
+		Count    int64
+	}
+	err = s.db.Model(&models.User{}).
+		Select("user_type, COUNT(*) AS count").
+		Where("hospital_id = ?", hospitalID).
+		Group("user_type").
+		Scan(&userCounts).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count users by type", err)
+	}
+	for _, count := range userCounts {
+		dashboard.UsersByType[count.UserType] = count.Count
+	}
+
+	return dashboard, nil
+}
+
+func dashboardCacheKey(hospitalID uint) string {
+	return fmt.Sprintf("%s%d", dashboardCachePrefix, hospitalID)
+}
+
+func dashboardHospitalGenerationKey(hospitalID uint) string {
+	return fmt.Sprintf("%s%d", dashboardGenerationPrefix, hospitalID)
+}
+
+// dashboardGeneration is a generation as MGET returned it, with a missing
+// key read as 0 like the script does.
+func dashboardGeneration(value interface{}) string {
+	if value == nil {
+		return "0"
+	}
+	return fmt.Sprint(value)
+}
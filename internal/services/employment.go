@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// staffStatusTransitions lists the states each lifecycle state may move to.
+// Terminated staff can be rehired into onboarding or straight into active.
+var staffStatusTransitions = map[models.StaffStatus][]models.StaffStatus{
+	models.StaffOnboarding: {models.StaffActive, models.StaffTerminated},
+	models.StaffActive:     {models.StaffOnLeave, models.StaffTerminated},
+	models.StaffOnLeave:    {models.StaffActive, models.StaffTerminated},
+	models.StaffTerminated: {models.StaffOnboarding, models.StaffActive},
+}
+
+type EmploymentService struct {
+	db *gorm.DB
+}
+
+func NewEmploymentService(db *gorm.DB) *EmploymentService {
+	return &EmploymentService{
+		db: db,
+	}
+}
+
+func (s *EmploymentService) WithContext(ctx context.Context) *EmploymentService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+// GetEmployment returns the lifecycle state, the employment records and the
+// status history of a staff member, newest first.
+func (s *EmploymentService) GetEmployment(staffID uint, hospitalID uint) (*models.StaffEmploymentResponse, error) {
+	staff, err := s.findStaff(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.StaffEmploymentResponse{Status: staff.Status}
+	err = s.db.Where("staff_id = ?", staffID).
+		Order("hire_date DESC, id DESC").
+		Find(&response.Employments).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get employments", err)
+	}
+
+	err = s.db.Where("staff_id = ?", staffID).
+		Order("effective_at DESC, id DESC").
+		Find(&response.StatusChanges).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get status changes", err)
+	}
+	return response, nil
+}
+
+// UpdateEmployment replaces the contract terms of the current employment, or
+// records one for staff hired before employment records existed.
+func (s *EmploymentService) UpdateEmployment(staffID uint, req *models.EmploymentRequest, hospitalID uint) (*models.StaffEmployment, error) {
+	staff, err := s.findStaff(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if staff.Status == models.StaffTerminated {
+		return nil, apperrors.NewBusinessRuleError("the contract of terminated staff cannot be changed", map[string]interface{}{
+			"staff_id": staffID,
+		})
+	}
+	if err := validateEmploymentDates(req); err != nil {
+		return nil, err
+	}
+
+	var employment models.StaffEmployment
+	err = s.db.Where("staff_id = ? AND terminated_at IS NULL", staffID).First(&employment).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.NewDatabaseError("find employment", err)
+	}
+
+	employment.StaffID = staff.ID
+	employment.HospitalID = staff.HospitalID
+	applyEmploymentRequest(&employment, req)
+	if err := s.db.Save(&employment).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("save employment", err)
+	}
+	return &employment, nil
+}
+
+// ChangeStatus moves a staff member to another lifecycle state. Terminating
+// closes the current clinic assignment and employment; the staff record stays
+// so it can be reported on and rehired later.
+func (s *EmploymentService) ChangeStatus(staffID uint, req *models.ChangeStaffStatusRequest, hospitalID, userID uint) (*models.Staff, error) {
+	if req.Status == models.StaffTerminated && req.Reason == "" {
+		return nil, apperrors.NewValidationError("reason", "is required when terminating")
+	}
+	if req.Employment != nil {
+		if err := validateEmploymentDates(req.Employment); err != nil {
+			return nil, err
+		}
+	}
+
+	effectiveAt := time.Now()
+	if req.EffectiveAt != nil {
+		if req.EffectiveAt.After(effectiveAt) {
+			return nil, apperrors.NewValidationError("effective_at", "status change cannot take effect in the future")
+		}
+		effectiveAt = *req.EffectiveAt
+	}
+
+	var staff models.Staff
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the staff row serialises status changes and transfers of the
+		// same person, so the transition is checked against the committed state.
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND hospital_id = ?", staffID, hospitalID).
+			First(&staff).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.NewStaffNotFoundError()
+			}
+			return apperrors.NewDatabaseError("find staff", err)
+		}
+
+		if !canTransition(staff.Status, req.Status) {
+			return apperrors.NewBusinessRuleError("invalid staff status transition", map[string]interface{}{
+				"staff_id": staffID,
+				"from":     staff.Status,
+				"to":       req.Status,
+				"allowed":  staffStatusTransitions[staff.Status],
+			})
+		}
+		if req.Employment != nil && staff.Status != models.StaffTerminated {
+			return apperrors.NewValidationError("employment", "can only be given when rehiring terminated staff")
+		}
+		if req.EffectiveAt != nil {
+			if err := checkEffectiveAfterHistory(tx, staff.ID, effectiveAt); err != nil {
+				return err
+			}
+		}
+
+		before := staff
+		after := staff
+		after.Status = req.Status
+
+		if req.Status == models.StaffTerminated {
+			after.ClinicID = nil
+			if err := moveClinicAssignment(tx, &staff, nil, effectiveAt, "terminated: "+req.Reason, &userID); err != nil {
+				return apperrors.NewDatabaseError("close clinic assignment", err)
+			}
+			err := tx.Model(&models.StaffEmployment{}).
+				Where("staff_id = ? AND terminated_at IS NULL", staff.ID).
+				Updates(map[string]interface{}{"terminated_at": effectiveAt, "termination_reason": req.Reason}).Error
+			if err != nil {
+				return apperrors.NewDatabaseError("close employment", err)
+			}
+		}
+
+		if req.Employment != nil {
+			if err := openEmployment(tx, &after, req.Employment); err != nil {
+				return err
+			}
+		}
+
+		err = tx.Model(&models.Staff{}).Where("id = ?", staff.ID).
+			Updates(map[string]interface{}{"status": after.Status, "clinic_id": after.ClinicID}).Error
+		if err != nil {
+			return apperrors.NewDatabaseError("update staff status", err)
+		}
+		if err := recordStaffVersion(tx, staff.ID, effectiveAt); err != nil {
+			return apperrors.NewDatabaseError("record staff version", err)
+		}
+		if err := publishStaffEvent(tx, models.WebhookStaffUpdated, staff.ID, nil); err != nil {
+			return apperrors.NewDatabaseError("publish staff event", err)
+		}
+
+		change := &models.StaffStatusChange{
+			StaffID:     staff.ID,
+			HospitalID:  staff.HospitalID,
+			FromStatus:  before.Status,
+			ToStatus:    after.Status,
+			Reason:      req.Reason,
+			EffectiveAt: effectiveAt,
+			CreatedByID: &userID,
+		}
+		if err := tx.Create(change).Error; err != nil {
+			return apperrors.NewDatabaseError("record status change", err)
+		}
+
+		return evaluateStaffingRules(tx, &before, &after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated models.Staff
+	err = s.db.Preload("ProfessionGroup").Preload("Title").
+		Preload("Hospital").Preload("Clinic.ClinicType").
+		First(&updated, staff.ID).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+	return &updated, nil
+}
+
+func (s *EmploymentService) findStaff(staffID, hospitalID uint) (*models.Staff, error) {
+	var staff models.Staff
+	err := s.db.Where("id = ? AND hospital_id = ?", staffID, hospitalID).First(&staff).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewStaffNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+	return &staff, nil
+}
+
+// checkEffectiveAfterHistory rejects a back-dated status change that would
+// land before the staff member's last status change or current clinic
+// assignment, which would leave the history out of order.
+func checkEffectiveAfterHistory(tx *gorm.DB, staffID uint, effectiveAt time.Time) error {
+	var latest struct {
+		StatusChange *time.Time
+		Assignment   *time.Time
+	}
+	err := tx.Raw(`SELECT
+		(SELECT MAX(effective_at) FROM staff_status_changes WHERE staff_id = ?) AS status_change,
+		(SELECT started_at FROM staff_clinic_assignments WHERE staff_id = ? AND ended_at IS NULL) AS assignment`,
+		staffID, staffID).Scan(&latest).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("find staff history", err)
+	}
+	if latest.StatusChange != nil && effectiveAt.Before(*latest.StatusChange) {
+		return apperrors.NewValidationError("effective_at", "cannot be earlier than the last status change at "+latest.StatusChange.Format(time.RFC3339))
+	}
+	if latest.Assignment != nil && effectiveAt.Before(*latest.Assignment) {
+		return apperrors.NewValidationError("effective_at", "cannot be earlier than the current clinic assignment started at "+latest.Assignment.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func canTransition(from, to models.StaffStatus) bool {
+	for _, allowed := range staffStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// openEmployment records a new employment period for the staff member.
+func openEmployment(tx *gorm.DB, staff *models.Staff, req *models.EmploymentRequest) error {
+	employment := &models.StaffEmployment{
+		StaffID:    staff.ID,
+		HospitalID: staff.HospitalID,
+	}
+	applyEmploymentRequest(employment, req)
+	if err := tx.Create(employment).Error; err != nil {
+		return apperrors.NewDatabaseError("create employment", err)
+	}
+	return nil
+}
+
+func applyEmploymentRequest(employment *models.StaffEmployment, req *models.EmploymentRequest) {
+	employment.ContractType = req.ContractType
+	employment.HireDate = startOfDay(req.HireDate)
+	employment.ProbationEndsAt = nil
+	if req.ProbationEndsAt != nil {
+		probationEndsAt := startOfDay(*req.ProbationEndsAt)
+		employment.ProbationEndsAt = &probationEndsAt
+	}
+	employment.ContractEndsAt = nil
+	if req.ContractEndsAt != nil {
+		contractEndsAt := startOfDay(*req.ContractEndsAt)
+		employment.ContractEndsAt = &contractEndsAt
+	}
+}
+
+func validateEmploymentDates(req *models.EmploymentRequest) error {
+	hireDate := startOfDay(req.HireDate)
+	if req.ProbationEndsAt != nil && startOfDay(*req.ProbationEndsAt).Before(hireDate) {
+		return apperrors.NewValidationError("probation_ends_at", "must not be before the hire date")
+	}
+	if req.ContractEndsAt != nil && startOfDay(*req.ContractEndsAt).Before(hireDate) {
+		return apperrors.NewValidationError("contract_ends_at", "must not be before the hire date")
+	}
+	// permanent contracts are open-ended; contracted and locum staff are
+	// hired for a fixed term
+	if req.ContractType == models.ContractPermanent && req.ContractEndsAt != nil {
+		return apperrors.NewValidationError("contract_ends_at", "is not allowed for permanent contracts")
+	}
+	if req.ContractType != models.ContractPermanent && req.ContractEndsAt == nil {
+		return apperrors.NewValidationError("contract_ends_at", "is required for contracted and locum staff")
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	eventChannelPrefix   = "events:hospital:"
+	eventRelayCursorKey  = "events:relay:cursor"
+	eventRelayLockKey    = "events:relay:lock"
+	eventRelayInterval   = time.Second
+	eventRelayLockTTL    = 10 * time.Second
+	eventRelayBatch      = 500
+	eventSubscriberQueue = 64
+)
+
+// streamedResources maps the audited tables that are streamed to the
+// resource name used in event types.
+var streamedResources = map[string]string{
+	"staffs":  "staff",
+	"clinics": "clinic",
+	"users":   "user",
+}
+
+var streamedActions = map[models.AuditAction]string{
+	models.AuditCreate: "created",
+	models.AuditUpdate: "updated",
+	models.AuditDelete: "deleted",
+}
+
+// EventService streams domain events read from the audit log. One replica at
+// a time relays new entries to Redis pub/sub, and every replica fans the
+// messages out to its own subscribers. Audit entries are appended under a
+// transaction-scoped lock, so they become visible in ID order and the relay
+// never passes over an entry that commits later.
+type EventService struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+
+	mu          sync.Mutex
+	listening   bool
+	subscribers map[uint]map[chan models.DomainEvent]struct{}
+}
+
+func NewEventService(db *gorm.DB, redisClient *redis.Client) *EventService {
+	return &EventService{
+		db:          db,
+		redisClient: redisClient,
+		subscribers: map[uint]map[chan models.DomainEvent]struct{}{},
+	}
+}
+
+// EventsSince returns up to limit events of the hospital after the given
+// event ID, oldest first.
+func (s *EventService) EventsSince(hospitalID, afterID uint, limit int) ([]models.DomainEvent, error) {
+	var entries []models.AuditLog
+	err := streamedEntries(s.db).
+		Where("hospital_id = ? AND id > ?", hospitalID, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get events", err)
+	}
+
+	events := make([]models.DomainEvent, len(entries))
+	for i := range entries {
+		events[i] = domainEventOf(&entries[i])
+	}
+	return events, nil
+}
+
+// RunRelay relays new audit entries every second until ctx is cancelled,
+// while this replica holds the relay lock.
+func (s *EventService) RunRelay(ctx context.Context) {
+	lock := NewRedisLock(s.redisClient, eventRelayLockKey, eventRelayLockTTL)
+	defer func() { _ = lock.Release(context.Background()) }()
+
+	ticker := time.NewTicker(eventRelayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		held, err := lock.Acquire(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Event relay lock failed")
+			continue
+		}
+		if !held {
+			continue
+		}
+		if _, err := s.Relay(ctx); err != nil {
+			log.Error().Err(err).Msg("Event relay failed")
+		}
+	}
+}
+
+// Relay publishes the streamed audit entries after the relay cursor and
+// moves the cursor past them. Without a cursor, as on the first run, it
+// starts from the newest entry instead of replaying the whole log; clients
+// catch up on older events from the database.
+func (s *EventService) Relay(ctx context.Context) (int, error) {
+	var latest uint
+	if err := s.db.Model(&models.AuditLog{}).Select("COALESCE(MAX(id), 0)").Scan(&latest).Error; err != nil {
+		return 0, err
+	}
+
+	cursor, err := s.redisClient.Get(ctx, eventRelayCursorKey).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return 0, s.redisClient.Set(ctx, eventRelayCursorKey, latest, 0).Err()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var entries []models.AuditLog
+	err = streamedEntries(s.db).
+		Where("hospital_id IS NOT NULL AND id > ? AND id <= ?", cursor, latest).
+		Order("id").
+		Limit(eventRelayBatch).
+		Find(&entries).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range entries {
+		event := domainEventOf(&entries[i])
+		message, err := json.Marshal(event)
+		if err != nil {
+			return i, err
+		}
+		if err := s.redisClient.Publish(ctx, eventChannel(event.HospitalID), message).Err(); err != nil {
+			return i, err
+		}
+		if err := s.redisClient.Set(ctx, eventRelayCursorKey, event.ID, 0).Err(); err != nil {
+			return i + 1, err
+		}
+	}
+
+	// a full batch may leave entries behind; otherwise skip ahead past the
+	// entries that are not streamed
+	if len(entries) < eventRelayBatch {
+		if err := s.redisClient.Set(ctx, eventRelayCursorKey, latest, 0).Err(); err != nil {
+			return len(entries), err
+		}
+	}
+	return len(entries), nil
+}
+
+// Subscribe registers a subscriber for the hospital's live events. The
+// channel is closed when the subscriber falls too far behind, and the caller
+// should then end the stream so the client reconnects and resumes. The
+// returned function unregisters the subscriber.
+func (s *EventService) Subscribe(ctx context.Context, hospitalID uint) (<-chan models.DomainEvent, func(), error) {
+	if err := s.listen(ctx); err != nil {
+		return nil, nil, apperrors.NewExternalServiceError("redis", err)
+	}
+
+	events := make(chan models.DomainEvent, eventSubscriberQueue)
+	s.mu.Lock()
+	if s.subscribers[hospitalID] == nil {
+		s.subscribers[hospitalID] = map[chan models.DomainEvent]struct{}{}
+	}
+	s.subscribers[hospitalID][events] = struct{}{}
+	s.mu.Unlock()
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[hospitalID][events]; ok {
+			delete(s.subscribers[hospitalID], events)
+			close(events)
+		}
+	}
+	return events, unsubscribe, nil
+}
+
+// listen subscribes this replica to every hospital's channel once, and
+// returns after Redis confirmed the subscription so no message published
+// afterwards is missed.
+func (s *EventService) listen(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listening {
+		return nil
+	}
+
+	pubsub := s.redisClient.PSubscribe(context.Background(), eventChannelPrefix+"*")
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+	s.listening = true
+
+	go func() {
+		for message := range pubsub.Channel() {
+			var event models.DomainEvent
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				log.Warn().Err(err).Str("channel", message.Channel).Msg("Invalid event message")
+				continue
+			}
+			s.dispatch(event)
+		}
+	}()
+	return nil
+}
+
+func (s *EventService) dispatch(event models.DomainEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for events := range s.subscribers[event.HospitalID] {
+		select {
+		case events <- event:
+		default:
+			delete(s.subscribers[event.HospitalID], events)
+			close(events)
+		}
+	}
+}
+
+func streamedEntries(db *gorm.DB) *gorm.DB {
+	resources := make([]string, 0, len(streamedResources))
+	for table := range streamedResources {
+		resources = append(resources, table)
+	}
+	actions := make([]models.AuditAction, 0, len(streamedActions))
+	for action := range streamedActions {
+		actions = append(actions, action)
+	}
+	return db.Model(&models.AuditLog{}).Where("resource IN ? AND action IN ?", resources, actions)
+}
+
+func domainEventOf(entry *models.AuditLog) models.DomainEvent {
+	resource := streamedResources[entry.Resource]
+	event := models.DomainEvent{
+		ID:         entry.ID,
+		Type:       resource + "." + streamedActions[entry.Action],
+		Resource:   resource,
+		OccurredAt: entry.CreatedAt,
+	}
+	if entry.HospitalID != nil {
+		event.HospitalID = *entry.HospitalID
+	}
+	if entry.ResourceID != nil {
+		event.ResourceID = *entry.ResourceID
+	}
+	return event
+}
+
+func eventChannel(hospitalID uint) string {
+	return eventChannelPrefix + strconv.FormatUint(uint64(hospitalID), 10)
+}
+
+// ParseLastEventID reads the ID a reconnecting client last saw, as sent in
+// the Last-Event-ID header. An empty value starts from the live events.
+func ParseLastEventID(value string) (uint, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, apperrors.NewValidationError("Last-Event-ID", "must be an event ID")
+	}
+	return uint(id), nil
+}
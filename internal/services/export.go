@@ -0,0 +1,572 @@
+package services
+
+import (
+	"bufio"
+	"database/sql"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+	ExportFormatPDF  = "pdf"
+
+	exportFlushInterval = 200
+	exportSheetName     = "Sheet1"
+)
+
+// The PDF core fonts only cover Latin-1, which lacks ğ, ş, ı and İ.
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	reportFontRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	reportFontBold []byte
+)
+
+type staffExportRow struct {
+	ID              uint
+	FirstName       string
+	LastName        string
+	NationalID      string
+	Phone           string
+	ProfessionGroup string
+	Title           string
+	ClinicID        sql.NullInt64
+	Clinic          sql.NullString
+	WorkingDays     string
+	Status          string
+	CreatedAt       time.Time
+}
+
+type staffExportColumn struct {
+	key    string
+	header string
+	value  func(row *staffExportRow) string
+}
+
+var staffExportColumns = []staffExportColumn{
+	{"id", "ID", func(r *staffExportRow) string { return strconv.FormatUint(uint64(r.ID), 10) }},
+	{"first_name", "First Name", func(r *staffExportRow) string { return r.FirstName }},
+	{"last_name", "Last Name", func(r *staffExportRow) string { return r.LastName }},
+	{"national_id", "National ID", func(r *staffExportRow) string { return r.NationalID }},
+	{"phone", "Phone", func(r *staffExportRow) string { return r.Phone }},
+	{"profession_group", "Profession Group", func(r *staffExportRow) string { return r.ProfessionGroup }},
+	{"title", "Title", func(r *staffExportRow) string { return r.Title }},
+	{"clinic", "Clinic", func(r *staffExportRow) string { return r.Clinic.String }},
+	{"working_days", "Working Days", func(r *staffExportRow) string { return formatWorkingDays(r.WorkingDays) }},
+	{"status", "Status", func(r *staffExportRow) string { return r.Status }},
+	{"created_at", "Created At", func(r *staffExportRow) string { return r.CreatedAt.UTC().Format(time.RFC3339) }},
+}
+
+type ExportService struct {
+	db            *gorm.DB
+	clinicService *ClinicService
+}
+
+func NewExportService(db *gorm.DB, clinicService *ClinicService) *ExportService {
+	return &ExportService{
+		db:            db,
+		clinicService: clinicService,
+	}
+}
+
+// ExportContentType returns the MIME type and file extension for a format.
+func ExportContentType(format string) (string, string) {
+	switch format {
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"
+	case ExportFormatPDF:
+		return "application/pdf", "pdf"
+	default:
+		return "text/csv; charset=utf-8", "csv"
+	}
+}
+
+// ValidateStaffExport checks the request before anything is written to the
+// response, since errors during streaming can no longer change the status.
+func (s *ExportService) ValidateStaffExport(req *models.StaffExportRequest) error {
+	if _, err := applyStaffFilters(s.db.Model(&models.Staff{}), &req.StaffFilterRequest, 0); err != nil {
+		return err
+	}
+	if req.Format == ExportFormatPDF {
+		return nil
+	}
+	if _, err := parseStaffSort(req.Sort); err != nil {
+		return err
+	}
+	_, err := selectStaffExportColumns(req.Columns)
+	return err
+}
+
+// ExportStaff streams the filtered staff list as CSV or XLSX, or writes the
+// staffing report when the PDF format is requested.
+func (s *ExportService) ExportStaff(w io.Writer, req *models.StaffExportRequest, hospitalID uint) error {
+	if req.Format == ExportFormatPDF {
+		return s.WriteStaffingReport(w, &req.StaffFilterRequest, hospitalID)
+	}
+
+	columns, err := selectStaffExportColumns(req.Columns)
+	if err != nil {
+		return err
+	}
+
+	fields, err := parseStaffSort(req.Sort)
+	if err != nil {
+		return err
+	}
+
+	table, err := newExportTableWriter(w, req.Format)
+	if err != nil {
+		return err
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	if err := table.WriteRow(headers); err != nil {
+		return err
+	}
+
+	values := make([]string, len(columns))
+	err = s.eachStaffExportRow(&req.StaffFilterRequest, hospitalID, staffSortOrder(fields, false), func(row *staffExportRow) error {
+		for i, column := range columns {
+			values[i] = column.value(row)
+		}
+		return table.WriteRow(values)
+	})
+	if err != nil {
+		return err
+	}
+
+	return table.Close()
+}
+
+// ExportClinics writes the clinic summaries with one count column per
+// profession group.
+func (s *ExportService) ExportClinics(w io.Writer, format string, hospitalID uint) error {
+	summaries, err := s.clinicService.GetAllClinicSummaries(hospitalID)
+	if err != nil {
+		return err
+	}
+
+	var professionGroups []models.ProfessionGroup
+	if err := s.db.Scopes(visibleCatalog(hospitalID)).Order("id").Find(&professionGroups).Error; err != nil {
+		return apperrors.NewDatabaseError("get profession groups", err)
+	}
+
+	table, err := newExportTableWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"ID", "Clinic", "Total Staff"}
+	for _, group := range professionGroups {
+		headers = append(headers, group.Name)
+	}
+	if err := table.WriteRow(headers); err != nil {
+		return err
+	}
+
+	for _, summary := range summaries {
+		counts := make(map[string]int64, len(summary.StaffByProfession))
+		for _, profession := range summary.StaffByProfession {
+			counts[profession.ProfessionGroup] = profession.Count
+		}
+
+		row := []string{
+			strconv.FormatUint(uint64(summary.ID), 10),
+			summary.Name,
+			strconv.FormatInt(summary.TotalStaff, 10),
+		}
+		for _, group := range professionGroups {
+			row = append(row, strconv.FormatInt(counts[group.Name], 10))
+		}
+		if err := table.WriteRow(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Close()
+}
+
+// WriteStaffingReport renders a PDF with the hospital header, a profession
+// breakdown per clinic and the staff of each clinic.
+func (s *ExportService) WriteStaffingReport(w io.Writer, filter *models.StaffFilterRequest, hospitalID uint) error {
+	var hospital models.Hospital
+	if err := s.db.Preload("Province").Preload("District").First(&hospital, hospitalID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewNotFoundError("hospital", hospitalID)
+		}
+		return apperrors.NewDatabaseError("get hospital", err)
+	}
+
+	clinicID, unassigned, err := parseClinicFilter(filter.ClinicID)
+	if err != nil {
+		return err
+	}
+
+	summaries, err := s.clinicService.GetAllClinicSummaries(hospitalID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ID < summaries[j].ID })
+	if clinicID != 0 || unassigned {
+		filtered := summaries[:0]
+		for _, summary := range summaries {
+			if summary.ID == clinicID {
+				filtered = append(filtered, summary)
+			}
+		}
+		summaries = filtered
+	}
+
+	report := newStaffingReport()
+	report.header(&hospital)
+
+	// staff arrive ordered by clinic, so each clinic section is closed as
+	// soon as a row for a later clinic shows up
+	next := 0
+	unassignedStarted := false
+	err = s.eachStaffExportRow(filter, hospitalID, "staffs.clinic_id ASC NULLS LAST, staffs.last_name, staffs.first_name", func(row *staffExportRow) error {
+		if !row.ClinicID.Valid {
+			for ; next < len(summaries); next++ {
+				report.clinic(&summaries[next])
+			}
+			if !unassignedStarted {
+				report.section("Unassigned Staff")
+				report.staffHeader()
+				unassignedStarted = true
+			}
+			report.staff(row)
+			return nil
+		}
+
+		clinicID := uint(row.ClinicID.Int64)
+		for ; next < len(summaries) && summaries[next].ID <= clinicID; next++ {
+			report.clinic(&summaries[next])
+		}
+		if report.currentClinic == clinicID {
+			report.staff(row)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for ; next < len(summaries); next++ {
+		report.clinic(&summaries[next])
+	}
+
+	if err := report.pdf.Output(w); err != nil {
+		return apperrors.NewInternalError("failed to render staffing report", err)
+	}
+	return nil
+}
+
+// eachStaffExportRow iterates the filtered staff with a database cursor so
+// large hospitals are never loaded into memory at once.
+func (s *ExportService) eachStaffExportRow(filter *models.StaffFilterRequest, hospitalID uint, order string, fn func(row *staffExportRow) error) error {
+	query, err := applyStaffFilters(s.db.Model(&models.Staff{}), filter, hospitalID)
+	if err != nil {
+		return err
+	}
+
+	query = query.
+		Select(`staffs.id, staffs.first_name, staffs.last_name, staffs.national_id, staffs.phone,
+			profession_groups.name AS profession_group, titles.name AS title,
+			staffs.clinic_id, COALESCE(NULLIF(clinics.name, ''), clinic_types.name) AS clinic, staffs.working_days, staffs.status, staffs.created_at`).
+		Joins("JOIN profession_groups ON profession_groups.id = staffs.profession_group_id").
+		Joins("JOIN titles ON titles.id = staffs.title_id").
+		Joins("LEFT JOIN clinics ON clinics.id = staffs.clinic_id").
+		Joins("LEFT JOIN clinic_types ON clinic_types.id = clinics.clinic_type_id").
+		Order(order)
+
+	rows, err := query.Rows()
+	if err != nil {
+		return apperrors.NewDatabaseError("export staff", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var row staffExportRow
+		if err := s.db.ScanRows(rows, &row); err != nil {
+			return apperrors.NewDatabaseError("export staff", err)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return apperrors.NewDatabaseError("export staff", err)
+	}
+	return nil
+}
+
+func selectStaffExportColumns(raw string) ([]staffExportColumn, error) {
+	if strings.TrimSpace(raw) == "" {
+		return staffExportColumns, nil
+	}
+
+	byKey := make(map[string]staffExportColumn, len(staffExportColumns))
+	for _, column := range staffExportColumns {
+		byKey[column.key] = column
+	}
+
+	var columns []staffExportColumn
+	for _, key := range strings.Split(raw, ",") {
+		key = strings.TrimSpace(key)
+		column, ok := byKey[key]
+		if !ok {
+			return nil, apperrors.NewValidationError("columns", fmt.Sprintf("unknown column %q", key))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func formatWorkingDays(raw string) string {
+	var days []models.WorkingDay
+	if err := json.Unmarshal([]byte(raw), &days); err != nil {
+		return raw
+	}
+
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = string(day)
+	}
+	return strings.Join(names, ", ")
+}
+
+// spreadsheetFormulaPrefixes start a formula when a spreadsheet opens a
+// cell, so a name or phone typed as "=HYPERLINK(...)" would run on the
+// reader's machine.
+const spreadsheetFormulaPrefixes = "=+-@\t\r"
+
+// escapeSpreadsheetFormula prefixes such values with an apostrophe, which
+// spreadsheets read as "treat as text" and the staff import strips again.
+func escapeSpreadsheetFormula(value string) string {
+	if value != "" && strings.IndexByte(spreadsheetFormulaPrefixes, value[0]) >= 0 {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeSpreadsheetFormula reverses escapeSpreadsheetFormula.
+func unescapeSpreadsheetFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.IndexByte(spreadsheetFormulaPrefixes, value[1]) >= 0 {
+		return value[1:]
+	}
+	return value
+}
+
+type exportTableWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+func newExportTableWriter(w io.Writer, format string) (exportTableWriter, error) {
+	switch format {
+	case "", ExportFormatCSV:
+		buffered := bufio.NewWriter(w)
+		return &csvTableWriter{out: buffered, csv: csv.NewWriter(buffered), target: w}, nil
+	case ExportFormatXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter(exportSheetName)
+		if err != nil {
+			_ = file.Close()
+			return nil, apperrors.NewInternalError("failed to create spreadsheet", err)
+		}
+		return &xlsxTableWriter{file: file, stream: stream, target: w}, nil
+	default:
+		return nil, apperrors.NewValidationError("format", fmt.Sprintf("unsupported export format %q", format))
+	}
+}
+
+type csvTableWriter struct {
+	out    *bufio.Writer
+	csv    *csv.Writer
+	target io.Writer
+	rows   int
+}
+
+func (t *csvTableWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeSpreadsheetFormula(value)
+	}
+	if err := t.csv.Write(escaped); err != nil {
+		return err
+	}
+	t.rows++
+	if t.rows%exportFlushInterval == 0 {
+		return t.flush()
+	}
+	return nil
+}
+
+func (t *csvTableWriter) Close() error {
+	return t.flush()
+}
+
+func (t *csvTableWriter) flush() error {
+	t.csv.Flush()
+	if err := t.csv.Error(); err != nil {
+		return err
+	}
+	if err := t.out.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := t.target.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// xlsxTableWriter keeps rows in excelize's temporary file rather than in
+// memory; the archive itself can only be written once every row is known.
+type xlsxTableWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	target io.Writer
+	rows   int
+}
+
+func (t *xlsxTableWriter) WriteRow(values []string) error {
+	t.rows++
+	cell, err := excelize.CoordinatesToCellName(1, t.rows)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = escapeSpreadsheetFormula(value)
+	}
+	return t.stream.SetRow(cell, row)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer func() { _ = t.file.Close() }()
+
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	return t.file.Write(t.target)
+}
+
+type staffingReport struct {
+	pdf           *fpdf.Fpdf
+	currentClinic uint
+}
+
+func newStaffingReport() *staffingReport {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("DejaVu", "", reportFontRegular)
+	pdf.AddUTF8FontFromBytes("DejaVu", "B", reportFontBold)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("DejaVu", "", 8)
+		pdf.CellFormat(0, 6, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	return &staffingReport{pdf: pdf}
+}
+
+func (r *staffingReport) header(hospital *models.Hospital) {
+	pdf := r.pdf
+	pdf.SetFont("DejaVu", "B", 16)
+	pdf.CellFormat(0, 9, hospital.Name, "", 1, "L", false, 0, "")
+
+	pdf.SetFont("DejaVu", "", 9)
+	location := hospital.Address
+	if hospital.District.Name != "" || hospital.Province.Name != "" {
+		location = fmt.Sprintf("%s, %s / %s", hospital.Address, hospital.District.Name, hospital.Province.Name)
+	}
+	pdf.CellFormat(0, 5, location, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("Tax ID: %s   Phone: %s   Email: %s", hospital.TaxID, hospital.Phone, hospital.Email), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Staffing report generated "+time.Now().UTC().Format("2006-01-02 15:04 UTC"), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+}
+
+func (r *staffingReport) section(title string) {
+	r.pdf.Ln(3)
+	r.pdf.SetFont("DejaVu", "B", 12)
+	r.pdf.CellFormat(0, 7, title, "B", 1, "L", false, 0, "")
+	r.pdf.Ln(1)
+}
+
+func (r *staffingReport) clinic(summary *models.ClinicSummary) {
+	r.currentClinic = summary.ID
+	r.section(fmt.Sprintf("%s (%d staff)", summary.Name, summary.TotalStaff))
+
+	pdf := r.pdf
+	pdf.SetFont("DejaVu", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(80, 6, "Profession Group", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(25, 6, "Count", "1", 1, "R", true, 0, "")
+	pdf.SetFont("DejaVu", "", 9)
+	for _, profession := range summary.StaffByProfession {
+		pdf.CellFormat(80, 6, profession.ProfessionGroup, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 6, strconv.FormatInt(profession.Count, 10), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	if summary.TotalStaff > 0 {
+		r.staffHeader()
+	}
+}
+
+func (r *staffingReport) staffHeader() {
+	pdf := r.pdf
+	pdf.SetFont("DejaVu", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(55, 6, "Name", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(45, 6, "Title", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(35, 6, "Phone", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(55, 6, "Working Days", "1", 1, "L", true, 0, "")
+	pdf.SetFont("DejaVu", "", 8)
+}
+
+func (r *staffingReport) staff(row *staffExportRow) {
+	pdf := r.pdf
+	pdf.CellFormat(55, 6, row.FirstName+" "+row.LastName, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(45, 6, row.Title, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(35, 6, row.Phone, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(55, 6, abbreviateWorkingDays(row.WorkingDays), "1", 1, "L", false, 0, "")
+}
+
+func abbreviateWorkingDays(raw string) string {
+	var days []models.WorkingDay
+	if err := json.Unmarshal([]byte(raw), &days); err != nil {
+		return raw
+	}
+
+	names := make([]string, len(days))
+	for i, day := range days {
+		name := string(day)
+		if len(name) > 3 {
+			name = name[:3]
+		}
+		names[i] = strings.ToUpper(name[:1]) + name[1:]
+	}
+	return strings.Join(names, " ")
+}
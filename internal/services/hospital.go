@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 
 	hospitalErrors "github.com/caner-cetin/hospital-tracker/internal/errors"
@@ -20,6 +21,12 @@ func NewHospitalService(db *gorm.DB, authService *AuthService) *HospitalService
 	}
 }
 
+func (s *HospitalService) WithContext(ctx context.Context) *HospitalService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
 func (s *HospitalService) RegisterHospital(req *models.HospitalRegistrationRequest) (*models.Hospital, *models.User, error) {
 	if err := s.validateHospitalUniqueness(req); err != nil {
 		return nil, nil, err
@@ -33,6 +40,14 @@ func (s *HospitalService) RegisterHospital(req *models.HospitalRegistrationReque
 		return nil, nil, err
 	}
 
+	if err := s.validateNeighbourhood(req.DistrictID, req.NeighbourhoodID); err != nil {
+		return nil, nil, err
+	}
+
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, nil, hospitalErrors.NewValidationError("coordinates", "latitude and longitude must be given together")
+	}
+
 	hashedPassword, err := s.authService.HashPassword(req.Password)
 	if err != nil {
 		return nil, nil, hospitalErrors.NewInternalError("password hashing failed", err)
@@ -53,6 +68,10 @@ func (s *HospitalService) RegisterHospital(req *models.HospitalRegistrationReque
 		ProvinceID: req.ProvinceID,
 		DistrictID: req.DistrictID,
 		Address:    req.Address,
+
+		NeighbourhoodID: req.NeighbourhoodID,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
 	}
 
 	if err := tx.Create(hospital).Error; err != nil {
@@ -80,7 +99,7 @@ func (s *HospitalService) RegisterHospital(req *models.HospitalRegistrationReque
 		return nil, nil, hospitalErrors.NewDatabaseError("commit transaction", err)
 	}
 
-	if err := s.db.Preload("Province").Preload("District").First(hospital, hospital.ID).Error; err != nil {
+	if err := s.db.Preload("Province").Preload("District").Preload("Neighbourhood").First(hospital, hospital.ID).Error; err != nil {
 		return nil, nil, hospitalErrors.NewDatabaseError("load hospital data", err)
 	}
 
@@ -156,3 +175,19 @@ func (s *HospitalService) validateProvinceDistrict(provinceID, districtID uint)
 	}
 	return nil
 }
+
+func (s *HospitalService) validateNeighbourhood(districtID uint, neighbourhoodID *uint) error {
+	if neighbourhoodID == nil {
+		return nil
+	}
+
+	var count int64
+	err := s.db.Model(&models.Neighbourhood{}).Where("id = ? AND district_id = ?", *neighbourhoodID, districtID).Count(&count).Error
+	if err != nil {
+		return hospitalErrors.NewDatabaseError("validate neighbourhood", err)
+	}
+	if count == 0 {
+		return hospitalErrors.NewValidationError("neighbourhood_id", "neighbourhood is not in the district")
+	}
+	return nil
+}
@@ -0,0 +1,709 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	jobQueueKey             = "jobs:queue"
+	jobDelayedKey           = "jobs:delayed"
+	jobScheduleKeyPrefix    = "jobs:schedule:"
+	jobMaintenanceLockKey   = "jobs:maintenance:lock"
+	jobScheduleInterval     = 5 * time.Second
+	jobScheduleLockTTL      = 30 * time.Second
+	jobMaintenanceInterval  = 15 * time.Second
+	jobPopTimeout           = 5 * time.Second
+	jobStaleQueuedAfter     = 5 * time.Minute
+	jobPromoteBatch         = 100
+	defaultJobConcurrency   = 4
+	defaultJobMaxAttempts   = 5
+	defaultJobBackoff       = 30 * time.Second
+	defaultJobTimeout       = 5 * time.Minute
+	maxJobBackoff           = time.Hour
+	maxJobErrorLength       = 1000
+	defaultJobRunsPageLimit = 20
+	maxJobRunsPageLimit     = 100
+
+	JobPurgePasswordResets = "purge-password-resets"
+	JobWarmCache           = "warm-cache"
+	JobCheckCredentials    = "check-credential-expiry"
+	JobPurgeTrash          = "purge-trash"
+	JobSnapshotHeadcount   = "snapshot-headcount"
+	JobDeliverWebhooks     = "deliver-webhooks"
+	JobImportStaff         = "import-staff"
+)
+
+// promoteJobsScript moves the delayed runs that are due onto the queue.
+var promoteJobsScript = redis.NewScript(`
+local due = redis.call("zrangebyscore", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+for _, id in ipairs(due) do
+	redis.call("zrem", KEYS[1], id)
+	redis.call("lpush", KEYS[2], id)
+end
+return #due
+`)
+
+// JobHandler runs one attempt of a job. A returned error, or a panic, fails
+// the attempt and the run is retried until it runs out of attempts.
+type JobHandler func(ctx context.Context, payload []byte) error
+
+type jobSchedule struct {
+	name     string
+	schedule *CronSchedule
+}
+
+// JobService queues background jobs in Redis and runs them in the worker
+// process. Every run is recorded in the job_runs table, which is the source
+// of truth: the Redis queue only carries run IDs, a worker claims a run with
+// a conditional update before running it, and runs lost from Redis or
+// abandoned by a crashed worker are queued again by the maintenance loop.
+type JobService struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+	handlers    map[string]JobHandler
+	schedules   []jobSchedule
+	location    *time.Location
+	concurrency int
+	maxAttempts int
+	backoff     time.Duration
+	timeout     time.Duration
+	worker      string
+}
+
+// NewJobService builds the job service with the built-in jobs registered and
+// scheduled as configured.
+func NewJobService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *JobService {
+	location, err := time.LoadLocation(cfg.Attendance.Timezone)
+	if err != nil {
+		log.Warn().Err(err).Str("timezone", cfg.Attendance.Timezone).Msg("Invalid job schedule timezone, falling back to UTC")
+		location = time.UTC
+	}
+	concurrency := cfg.Jobs.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultJobConcurrency
+	}
+	maxAttempts := cfg.Jobs.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultJobMaxAttempts
+	}
+	backoff := time.Duration(cfg.Jobs.BackoffSeconds) * time.Second
+	if backoff <= 0 {
+		backoff = defaultJobBackoff
+	}
+	timeout := time.Duration(cfg.Jobs.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultJobTimeout
+	}
+	hostname, _ := os.Hostname()
+
+	s := &JobService{
+		db:          db,
+		redisClient: redisClient,
+		handlers:    map[string]JobHandler{},
+		location:    location,
+		concurrency: concurrency,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		timeout:     timeout,
+		worker:      hostname + ":" + strconv.Itoa(os.Getpid()),
+	}
+	s.registerBuiltinJobs(cfg)
+	return s
+}
+
+func (s *JobService) registerBuiltinJobs(cfg *config.Config) {
+	passwordResetService := NewPasswordResetService(s.db, nil)
+	s.Register(JobPurgePasswordResets, func(ctx context.Context, _ []byte) error {
+		purged, err := passwordResetService.WithContext(ctx).PurgeExpired(time.Now())
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("password_resets", purged).Msg("Password reset purge completed")
+		return nil
+	})
+
+	locationService := NewLocationService(s.db, s.redisClient)
+	staffService := NewStaffService(s.db, s.redisClient)
+	s.Register(JobWarmCache, func(ctx context.Context, _ []byte) error {
+		if err := locationService.RefreshCache(ctx); err != nil {
+			return err
+		}
+		return staffService.RefreshProfessionGroupCache(ctx)
+	})
+
+	importStaffService := NewStaffService(s.db, s.redisClient)
+	importStaffService.SetBlockExpiredCredentials(cfg.Credentials.BlockExpiredAssignment)
+	staffImportService := NewStaffImportService(s.db, importStaffService)
+	s.Register(JobImportStaff, func(ctx context.Context, payload []byte) error {
+		var request staffImportPayload
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return err
+		}
+		return staffImportService.RunJob(ctx, request.ImportJobID)
+	})
+
+	credentialService := NewCredentialService(s.db, NewNotificationService(s.db), cfg)
+	s.Register(JobCheckCredentials, func(ctx context.Context, _ []byte) error {
+		sent, err := credentialService.CheckExpiry(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		log.Info().Int("notifications", sent).Msg("Credential expiry check completed")
+		return nil
+	})
+
+	trashService := NewTrashService(s.db, cfg)
+	s.Register(JobPurgeTrash, func(ctx context.Context, _ []byte) error {
+		result, err := trashService.Purge(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		log.Info().
+			Int64("hospitals", result.Hospitals).
+			Int64("clinics", result.Clinics).
+			Int64("users", result.Users).
+			Int64("staff", result.Staff).
+			Msg("Trash purge completed")
+		return nil
+	})
+
+	reportService := NewReportService(s.db, cfg)
+	s.Register(JobSnapshotHeadcount, func(_ context.Context, _ []byte) error {
+		rows, err := reportService.Snapshot(time.Now())
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("rows", rows).Msg("Headcount snapshot completed")
+		return nil
+	})
+
+	webhookService := NewWebhookService(s.db, cfg)
+	s.Register(JobDeliverWebhooks, func(ctx context.Context, _ []byte) error {
+		sent, err := webhookService.RunDeliveryJob(ctx, s.untilNextRun(JobDeliverWebhooks, time.Now()))
+		if sent > 0 {
+			log.Info().Int("deliveries", sent).Msg("Webhook deliveries sent")
+		}
+		return err
+	})
+
+	for name, expression := range map[string]string{
+		JobPurgePasswordResets: cfg.Jobs.PasswordResetPurgeSchedule,
+		JobWarmCache:           cfg.Jobs.CacheWarmSchedule,
+		JobCheckCredentials:    cfg.Jobs.CredentialExpirySchedule,
+		JobPurgeTrash:          cfg.Jobs.TrashPurgeSchedule,
+		JobSnapshotHeadcount:   cfg.Jobs.HeadcountSnapshotSchedule,
+		JobDeliverWebhooks:     cfg.Jobs.WebhookDeliverySchedule,
+	} {
+		if expression == "" {
+			continue
+		}
+		if err := s.Schedule(name, expression); err != nil {
+			log.Error().Err(err).Str("job", name).Msg("Invalid job schedule, the job will only run when queued")
+		}
+	}
+}
+
+// Register adds a job that can be queued by name. It must be called before
+// the service is used.
+func (s *JobService) Register(name string, handler JobHandler) {
+	s.handlers[name] = handler
+}
+
+// Schedule runs a registered job on a cron expression in the service's
+// timezone. It must be called before the service is used.
+func (s *JobService) Schedule(name, expression string) error {
+	if _, ok := s.handlers[name]; !ok {
+		return fmt.Errorf("job %q is not registered", name)
+	}
+	schedule, err := ParseCronSchedule(expression, s.location)
+	if err != nil {
+		return err
+	}
+	s.schedules = append(s.schedules, jobSchedule{name: name, schedule: schedule})
+	sort.Slice(s.schedules, func(i, j int) bool { return s.schedules[i].name < s.schedules[j].name })
+	return nil
+}
+
+// untilNextRun returns the time left before the next scheduled run of name,
+// kept well inside the run timeout. Jobs without a schedule get none.
+func (s *JobService) untilNextRun(name string, now time.Time) time.Duration {
+	for _, candidate := range s.schedules {
+		if candidate.name != name {
+			continue
+		}
+		next := candidate.schedule.Next(now)
+		if next.IsZero() {
+			return 0
+		}
+		return min(next.Sub(now), s.timeout/2)
+	}
+	return 0
+}
+
+// Enqueue queues a run of a registered job to start as soon as a worker is
+// free.
+func (s *JobService) Enqueue(ctx context.Context, name string, payload []byte) (*models.JobRun, error) {
+	if _, ok := s.handlers[name]; !ok {
+		return nil, apperrors.NewNotFoundError("job", name)
+	}
+
+	run := &models.JobRun{
+		Name:        name,
+		Payload:     string(payload),
+		Status:      models.JobQueued,
+		MaxAttempts: s.maxAttempts,
+		RunAt:       time.Now(),
+	}
+	if err := s.db.WithContext(ctx).Create(run).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("queue job", err)
+	}
+	// a run that misses the queue here is picked up by the maintenance loop
+	if err := s.push(ctx, run.ID); err != nil {
+		log.Warn().Err(err).Uint("job_run_id", run.ID).Msg("Failed to push job run to the queue")
+	}
+	return run, nil
+}
+
+// GetJobs lists the registered jobs with their schedules and latest runs.
+func (s *JobService) GetJobs() ([]models.JobSummary, error) {
+	names := make([]string, 0, len(s.handlers))
+	for name := range s.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schedules := make(map[string]*CronSchedule, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules[schedule.name] = schedule.schedule
+	}
+
+	var latest []models.JobRun
+	err := s.db.Where("id IN (?)", s.db.Model(&models.JobRun{}).Select("MAX(id)").Group("name")).Find(&latest).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get job runs", err)
+	}
+	lastRuns := make(map[string]*models.JobRun, len(latest))
+	for i := range latest {
+		lastRuns[latest[i].Name] = &latest[i]
+	}
+
+	now := time.Now()
+	jobs := make([]models.JobSummary, len(names))
+	for i, name := range names {
+		jobs[i] = models.JobSummary{Name: name, LastRun: lastRuns[name]}
+		if schedule, ok := schedules[name]; ok {
+			jobs[i].Schedule = schedule.String()
+			if next := schedule.Next(now); !next.IsZero() {
+				jobs[i].NextRunAt = &next
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// GetRuns returns job runs newest first. status=failed lists the runs that
+// gave up after every attempt.
+func (s *JobService) GetRuns(filter *models.JobRunFilterRequest) (*models.JobRunPaginatedResponse, error) {
+	query := s.db.Model(&models.JobRun{})
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("count job runs", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultJobRunsPageLimit
+	}
+	if filter.Limit > maxJobRunsPageLimit {
+		filter.Limit = maxJobRunsPageLimit
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	var runs []models.JobRun
+	err := query.Order("id DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get job runs", err)
+	}
+
+	return &models.JobRunPaginatedResponse{
+		Data: runs,
+		BasePagination: models.BasePagination{
+			TotalCount: totalCount,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: int(math.Ceil(float64(totalCount) / float64(filter.Limit))),
+		},
+	}, nil
+}
+
+// RunWorker runs the scheduler, the maintenance loop and the configured
+// number of workers until ctx is cancelled, then waits for the jobs in
+// flight to finish.
+func (s *JobService) RunWorker(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2 + s.concurrency)
+	go func() {
+		defer wg.Done()
+		s.runScheduler(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		s.runMaintenance(ctx)
+	}()
+	for i := 0; i < s.concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			s.runWorkerLoop(ctx)
+		}()
+	}
+	log.Info().Int("concurrency", s.concurrency).Int("schedules", len(s.schedules)).Str("worker", s.worker).Msg("Job worker started")
+	wg.Wait()
+}
+
+// runScheduler queues scheduled runs as they come due. Each schedule has its
+// own lock, so exactly one replica fires it while the others stand by.
+func (s *JobService) runScheduler(ctx context.Context) {
+	locks := make([]*RedisLock, len(s.schedules))
+	for i, schedule := range s.schedules {
+		locks[i] = NewRedisLock(s.redisClient, jobScheduleKeyPrefix+schedule.name+":lock", jobScheduleLockTTL)
+	}
+	defer func() {
+		for _, lock := range locks {
+			_ = lock.Release(context.Background())
+		}
+	}()
+
+	ticker := time.NewTicker(jobScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		for i, schedule := range s.schedules {
+			held, err := locks[i].Acquire(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error().Err(err).Str("job", schedule.name).Msg("Job schedule lock failed")
+				}
+				continue
+			}
+			if !held {
+				continue
+			}
+			if _, err := s.Tick(ctx, schedule.name, time.Now()); err != nil {
+				log.Error().Err(err).Str("job", schedule.name).Msg("Scheduling job failed")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick queues a run of the scheduled job when an occurrence came due since
+// the last one it queued, and reports whether it did. Missed occurrences
+// collapse into a single run for the latest of them. The first tick of a
+// schedule only records the time, so deploying a new schedule does not run
+// it at once. Callers must hold the schedule's lock.
+func (s *JobService) Tick(ctx context.Context, name string, now time.Time) (bool, error) {
+	var schedule *CronSchedule
+	for _, candidate := range s.schedules {
+		if candidate.name == name {
+			schedule = candidate.schedule
+		}
+	}
+	if schedule == nil {
+		return false, fmt.Errorf("job %q is not scheduled", name)
+	}
+
+	lastKey := jobScheduleKeyPrefix + name + ":last"
+	last, err := s.redisClient.Get(ctx, lastKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return false, s.redisClient.Set(ctx, lastKey, now.Unix(), 0).Err()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	due := schedule.Next(time.Unix(last, 0))
+	if due.IsZero() || due.After(now) {
+		return false, nil
+	}
+	for next := schedule.Next(due); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		due = next
+	}
+
+	run := &models.JobRun{
+		Name:         name,
+		ScheduledFor: &due,
+		Status:       models.JobQueued,
+		MaxAttempts:  s.maxAttempts,
+		RunAt:        now,
+	}
+	// the unique schedule index keeps a run from being queued twice when the
+	// lock changed hands in between
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(run)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if err := s.redisClient.Set(ctx, lastKey, due.Unix(), 0).Err(); err != nil {
+		return false, err
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	if err := s.push(ctx, run.ID); err != nil {
+		log.Warn().Err(err).Uint("job_run_id", run.ID).Msg("Failed to push job run to the queue")
+	}
+	return true, nil
+}
+
+// runMaintenance promotes delayed retries on every replica and, on the one
+// holding the maintenance lock, recovers runs that were lost.
+func (s *JobService) runMaintenance(ctx context.Context) {
+	lock := NewRedisLock(s.redisClient, jobMaintenanceLockKey, 2*jobMaintenanceInterval)
+	defer func() { _ = lock.Release(context.Background()) }()
+
+	promote := time.NewTicker(time.Second)
+	defer promote.Stop()
+	recoverTicker := time.NewTicker(jobMaintenanceInterval)
+	defer recoverTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-promote.C:
+			if _, err := s.PromoteDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Error().Err(err).Msg("Promoting delayed jobs failed")
+			}
+		case <-recoverTicker.C:
+			held, err := lock.Acquire(ctx)
+			if err != nil || !held {
+				continue
+			}
+			if err := s.Recover(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Error().Err(err).Msg("Recovering job runs failed")
+			}
+		}
+	}
+}
+
+// PromoteDue moves the delayed runs whose retry time has passed onto the
+// queue and returns how many it moved.
+func (s *JobService) PromoteDue(ctx context.Context, now time.Time) (int, error) {
+	return promoteJobsScript.Run(ctx, s.redisClient, []string{jobDelayedKey, jobQueueKey}, now.UnixMilli(), jobPromoteBatch).Int()
+}
+
+// Recover fails the attempts of running jobs whose worker stopped renewing
+// their lease, and pushes queued runs that have waited too long back onto
+// the queue in case their message was lost. A run pushed twice is harmless:
+// only one worker can claim it.
+func (s *JobService) Recover(ctx context.Context, now time.Time) error {
+	db := s.db.WithContext(ctx)
+
+	var abandoned []models.JobRun
+	if err := db.Where("status = ? AND lease_until < ?", models.JobRunning, now).Find(&abandoned).Error; err != nil {
+		return err
+	}
+	for i := range abandoned {
+		s.finish(ctx, &abandoned[i], errors.New("worker stopped before the job finished"), true)
+	}
+
+	var stale []models.JobRun
+	err := db.Where("status = ? AND run_at < ?", models.JobQueued, now.Add(-jobStaleQueuedAfter)).
+		Order("id").
+		Limit(jobPromoteBatch).
+		Find(&stale).Error
+	if err != nil {
+		return err
+	}
+	for i := range stale {
+		result := db.Model(&models.JobRun{}).
+			Where("id = ? AND status = ? AND run_at = ?", stale[i].ID, models.JobQueued, stale[i].RunAt).
+			Update("run_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			if err := s.push(ctx, stale[i].ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JobService) runWorkerLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		result, err := s.redisClient.BRPop(ctx, jobPopTimeout, jobQueueKey).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error().Err(err).Msg("Reading the job queue failed")
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		id, err := strconv.ParseUint(result[1], 10, 64)
+		if err != nil {
+			log.Warn().Str("message", result[1]).Msg("Invalid job queue message")
+			continue
+		}
+		// a job that started finishes even when the worker is shutting down
+		if _, err := s.Process(context.WithoutCancel(ctx), uint(id)); err != nil {
+			log.Error().Err(err).Uint64("job_run_id", id).Msg("Job run failed")
+		}
+	}
+}
+
+// Process claims a queued run and runs one attempt of it. It reports false
+// when the run was not claimable, such as when another worker already took
+// it, and returns the attempt's error.
+func (s *JobService) Process(ctx context.Context, runID uint) (bool, error) {
+	db := s.db.WithContext(ctx)
+	now := time.Now()
+	lease := now.Add(s.timeout + time.Minute)
+
+	result := db.Model(&models.JobRun{}).
+		Where("id = ? AND status = ?", runID, models.JobQueued).
+		Updates(map[string]interface{}{
+			"status":      models.JobRunning,
+			"attempts":    gorm.Expr("attempts + 1"),
+			"started_at":  now,
+			"lease_until": lease,
+			"worker":      s.worker,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	var run models.JobRun
+	if err := db.First(&run, runID).Error; err != nil {
+		return true, err
+	}
+
+	handler, ok := s.handlers[run.Name]
+	if !ok {
+		err := fmt.Errorf("job %q is not registered", run.Name)
+		s.finish(ctx, &run, err, false)
+		return true, err
+	}
+
+	started := time.Now()
+	err := s.call(ctx, handler, []byte(run.Payload))
+	s.finish(ctx, &run, err, true)
+
+	event := log.Info()
+	if err != nil {
+		event = log.Warn().Err(err)
+	}
+	event.Str("job", run.Name).Uint("job_run_id", run.ID).Int("attempt", run.Attempts).Dur("duration", time.Since(started)).Msg("Job run finished")
+	return true, err
+}
+
+func (s *JobService) call(ctx context.Context, handler JobHandler, payload []byte) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(ctx, payload)
+}
+
+// finish records the outcome of a run's attempt. A failed attempt with
+// attempts left goes back to the queue after the backoff when retry is set.
+// The update only applies while the run is still in the attempt it was
+// claimed for.
+func (s *JobService) finish(ctx context.Context, run *models.JobRun, runErr error, retry bool) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"lease_until": nil,
+	}
+	switch {
+	case runErr == nil:
+		updates["status"] = models.JobSucceeded
+		updates["finished_at"] = now
+		updates["last_error"] = ""
+	case retry && run.Attempts < run.MaxAttempts:
+		updates["status"] = models.JobQueued
+		updates["run_at"] = now.Add(s.retryDelay(run.Attempts))
+		updates["last_error"] = truncateJobError(runErr)
+	default:
+		updates["status"] = models.JobFailed
+		updates["finished_at"] = now
+		updates["last_error"] = truncateJobError(runErr)
+	}
+
+	result := s.db.WithContext(ctx).Model(&models.JobRun{}).
+		Where("id = ? AND status = ? AND attempts = ?", run.ID, models.JobRunning, run.Attempts).
+		Updates(updates)
+	if result.Error != nil {
+		log.Error().Err(result.Error).Uint("job_run_id", run.ID).Msg("Failed to record job run outcome")
+		return
+	}
+	if result.RowsAffected == 1 && updates["status"] == models.JobQueued {
+		runAt := updates["run_at"].(time.Time)
+		err := s.redisClient.ZAdd(ctx, jobDelayedKey, redis.Z{Score: float64(runAt.UnixMilli()), Member: run.ID}).Err()
+		if err != nil {
+			log.Warn().Err(err).Uint("job_run_id", run.ID).Msg("Failed to delay job retry")
+		}
+	}
+}
+
+func (s *JobService) push(ctx context.Context, runID uint) error {
+	return s.redisClient.LPush(ctx, jobQueueKey, runID).Err()
+}
+
+// retryDelay doubles the backoff with every failed attempt, up to a cap.
+func (s *JobService) retryDelay(attempts int) time.Duration {
+	delay := s.backoff
+	for i := 1; i < attempts && delay < maxJobBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxJobBackoff {
+		delay = maxJobBackoff
+	}
+	return delay
+}
+
+func truncateJobError(err error) string {
+	return truncateUTF8(err.Error(), maxJobErrorLength)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
 	"github.com/caner-cetin/hospital-tracker/internal/models"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
@@ -37,7 +39,7 @@ func (s *LocationService) GetProvinces() ([]models.Province, error) {
 	}
 
 	var provinces []models.Province
-	err = s.db.Find(&provinces).Error
+	err = s.db.Order("plate_code, id").Find(&provinces).Error
 	if err != nil {
 		return nil, err
 	}
@@ -103,3 +105,101 @@ func (s *LocationService) GetDistrictsByProvince(provinceIDStr string) ([]models
 
 	return districts, err
 }
+
+// GetProvinceDistricts lists a province's districts, failing with not found
+// for an unknown province rather than returning an empty list.
+func (s *LocationService) GetProvinceDistricts(provinceID uint) ([]models.District, error) {
+	var count int64
+	if err := s.db.Model(&models.Province{}).Where("id = ?", provinceID).Count(&count).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get province", err)
+	}
+	if count == 0 {
+		return nil, apperrors.NewNotFoundError("province", provinceID)
+	}
+
+	districts, err := s.GetDistrictsByProvince(strconv.FormatUint(uint64(provinceID), 10))
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get districts", err)
+	}
+	return districts, nil
+}
+
+// GetNeighbourhoods lists a district's neighbourhoods by name, or those
+// matching q with the closest matches first. Full lists are cached like the
+// districts; searches go to the database.
+func (s *LocationService) GetNeighbourhoods(districtID uint, q string) ([]models.Neighbourhood, error) {
+	var count int64
+	if err := s.db.Model(&models.District{}).Where("id = ?", districtID).Count(&count).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get district", err)
+	}
+	if count == 0 {
+		return nil, apperrors.NewNotFoundError("district", districtID)
+	}
+
+	q = strings.TrimSpace(q)
+	if q != "" {
+		var neighbourhoods []models.Neighbourhood
+		query := applyNameSearch(s.db.Where("district_id = ?", districtID), q, "neighbourhoods.name")
+		err := orderByNameRank(query, q, "neighbourhoods.name", "neighbourhoods.name").Find(&neighbourhoods).Error
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("search neighbourhoods", err)
+		}
+		return neighbourhoods, nil
+	}
+
+	ctx := context.Background()
+	cacheKey := "neighbourhoods:district:" + strconv.FormatUint(uint64(districtID), 10)
+
+	cachedData, err := s.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var neighbourhoods []models.Neighbourhood
+		if err := json.Unmarshal([]byte(cachedData), &neighbourhoods); err == nil {
+			return neighbourhoods, nil
+		}
+	}
+
+	var neighbourhoods []models.Neighbourhood
+	if err := s.db.Where("district_id = ?", districtID).Order("name").Find(&neighbourhoods).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get neighbourhoods", err)
+	}
+
+	if data, err := json.Marshal(neighbourhoods); err == nil {
+		s.redisClient.Set(ctx, cacheKey, data, 24*time.Hour)
+	}
+
+	return neighbourhoods, nil
+}
+
+// RefreshCache reloads the cached provinces and districts from the database,
+// so readers never wait for a cold cache and edits made outside the API show
+// up without waiting for the cache to expire. Cached neighbourhood lists are
+// dropped and refill on demand.
+func (s *LocationService) RefreshCache(ctx context.Context) error {
+	keys := []string{"provinces", "districts:all"}
+	for _, pattern := range []string{"districts:province:*", "neighbourhoods:district:*"} {
+		iter := s.redisClient.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return errors.Wrap(err, "failed to list location cache keys")
+		}
+	}
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(err, "failed to clear location cache")
+	}
+
+	provinces, err := s.GetProvinces()
+	if err != nil {
+		return err
+	}
+	if _, err := s.GetAllDistricts(); err != nil {
+		return err
+	}
+	for _, province := range provinces {
+		if _, err := s.GetDistrictsByProvince(strconv.FormatUint(uint64(province.ID), 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
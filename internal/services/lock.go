@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// renewLockScript extends the lock only while this holder still owns it.
+var renewLockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLockScript deletes the lock only while this holder still owns it.
+var releaseLockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+// RedisLock is a lease on a Redis key shared by every replica. The holder
+// must call Acquire again before the TTL runs out to keep it; a crashed
+// holder loses it once the TTL passes.
+type RedisLock struct {
+	client *redis.Client
+	key    string
+	token  string
+	ttl    time.Duration
+}
+
+func NewRedisLock(client *redis.Client, key string, ttl time.Duration) *RedisLock {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return &RedisLock{
+		client: client,
+		key:    key,
+		token:  hex.EncodeToString(b),
+		ttl:    ttl,
+	}
+}
+
+// Acquire takes the lock if it is free and renews it if this holder already
+// owns it. It reports whether the lock is held afterwards.
+func (l *RedisLock) Acquire(ctx context.Context) (bool, error) {
+	acquired, err := l.client.SetNX(ctx, l.key, l.token, l.ttl).Result()
+	if err != nil || acquired {
+		return acquired, err
+	}
+
+	renewed, err := renewLockScript.Run(ctx, l.client, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return renewed == 1, nil
+}
+
+func (l *RedisLock) Release(ctx context.Context) error {
+	return releaseLockScript.Run(ctx, l.client, []string{l.key}, l.token).Err()
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultNotificationPageLimit = 20
+	maxNotificationPageLimit     = 100
+)
+
+// Sender delivers notifications to a user over one external channel such as
+// email or SMS. Implementations must be safe for concurrent use.
+type Sender interface {
+	Channel() models.NotificationChannel
+	Send(ctx context.Context, user *models.User, notification Notification) error
+}
+
+// LogSender writes the notifications of a channel to the application log,
+// standing in for a channel without a configured provider.
+type LogSender struct {
+	channel models.NotificationChannel
+}
+
+func NewLogSender(channel models.NotificationChannel) *LogSender {
+	return &LogSender{channel: channel}
+}
+
+func (s *LogSender) Channel() models.NotificationChannel {
+	return s.channel
+}
+
+func (s *LogSender) Send(_ context.Context, user *models.User, notification Notification) error {
+	log.Info().
+		Str("channel", string(s.channel)).
+		Uint("user_id", user.ID).
+		Str("type", notification.Type).
+		Msg(notification.Title + ": " + notification.Message)
+	return nil
+}
+
+// NotificationService is the Notifier behind the notification center. It
+// keeps every user's in-app inbox and hands notifications to the senders of
+// the other channels each recipient opted into.
+type NotificationService struct {
+	db      *gorm.DB
+	senders map[models.NotificationChannel]Sender
+}
+
+func NewNotificationService(db *gorm.DB) *NotificationService {
+	s := &NotificationService{
+		db:      db,
+		senders: map[models.NotificationChannel]Sender{},
+	}
+	s.RegisterSender(NewLogSender(models.ChannelEmail))
+	s.RegisterSender(NewLogSender(models.ChannelSMS))
+	return s
+}
+
+func (s *NotificationService) WithContext(ctx context.Context) *NotificationService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+// RegisterSender sets the sender of its channel, replacing the previous one.
+// It must be called before the service is used.
+func (s *NotificationService) RegisterSender(sender Sender) {
+	s.senders[sender.Channel()] = sender
+}
+
+// Notify stores the notification in the inbox of each recipient that keeps
+// in-app notifications of its type, and sends it over the recipients' other
+// chosen channels. A failing sender does not stop the others; their errors
+// are returned together.
+func (s *NotificationService) Notify(ctx context.Context, notification Notification) error {
+	db := s.db.WithContext(ctx)
+
+	query := db.Where("hospital_id = ?", notification.HospitalID)
+	if len(notification.UserIDs) > 0 {
+		query = query.Where("id IN ?", notification.UserIDs)
+	} else {
+		query = query.Where("user_type = ?", models.UserTypeAuthorized)
+	}
+	var recipients []models.User
+	if err := query.Find(&recipients).Error; err != nil {
+		return apperrors.NewDatabaseError("get notification recipients", err)
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint, len(recipients))
+	for i := range recipients {
+		userIDs[i] = recipients[i].ID
+	}
+	var stored []models.NotificationPreference
+	err := db.Where("user_id IN ? AND type = ?", userIDs, notification.Type).Find(&stored).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("get notification preferences", err)
+	}
+	preferences := make(map[uint]models.NotificationPreference, len(stored))
+	for _, preference := range stored {
+		preferences[preference.UserID] = preference
+	}
+
+	var data models.NotificationData
+	if len(notification.Data) > 0 {
+		encoded, err := json.Marshal(notification.Data)
+		if err != nil {
+			return apperrors.NewInternalError("failed to encode notification data", err)
+		}
+		data = models.NotificationData(encoded)
+	}
+
+	for i := range recipients {
+		if _, ok := preferences[recipients[i].ID]; !ok {
+			preferences[recipients[i].ID] = defaultNotificationPreference(notification.Type)
+		}
+	}
+
+	var inbox []models.UserNotification
+	for i := range recipients {
+		if preferences[recipients[i].ID].InApp {
+			inbox = append(inbox, models.UserNotification{
+				UserID:     recipients[i].ID,
+				HospitalID: notification.HospitalID,
+				Type:       notification.Type,
+				Title:      notification.Title,
+				Message:    notification.Message,
+				Data:       data,
+			})
+		}
+	}
+	if len(inbox) > 0 {
+		if err := db.Create(&inbox).Error; err != nil {
+			return apperrors.NewDatabaseError("store notifications", err)
+		}
+	}
+
+	var errs []error
+	for i := range recipients {
+		preference := preferences[recipients[i].ID]
+		channels := []struct {
+			channel models.NotificationChannel
+			enabled bool
+		}{
+			{models.ChannelEmail, preference.Email},
+			{models.ChannelSMS, preference.SMS},
+		}
+		for _, c := range channels {
+			sender := s.senders[c.channel]
+			if !c.enabled || sender == nil {
+				continue
+			}
+			if err := sender.Send(ctx, &recipients[i], notification); err != nil {
+				errs = append(errs, fmt.Errorf("send %s to user %d: %w", c.channel, recipients[i].ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetNotifications returns a page of the user's inbox, newest first, with
+// the number of unread notifications.
+func (s *NotificationService) GetNotifications(userID uint, filter *models.NotificationFilterRequest) (*models.NotificationPaginatedResponse, error) {
+	var unreadCount int64
+	err := s.db.Model(&models.UserNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&unreadCount).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("count unread notifications", err)
+	}
+
+	query := s.db.Model(&models.UserNotification{}).Where("user_id = ?", userID)
+	if filter.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("count notifications", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultNotificationPageLimit
+	}
+	if filter.Limit > maxNotificationPageLimit {
+		filter.Limit = maxNotificationPageLimit
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	var notifications []models.UserNotification
+	err = query.Order("created_at DESC, id DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get notifications", err)
+	}
+
+	return &models.NotificationPaginatedResponse{
+		Data:        notifications,
+		UnreadCount: unreadCount,
+		BasePagination: models.BasePagination{
+			TotalCount: totalCount,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: int(math.Ceil(float64(totalCount) / float64(filter.Limit))),
+		},
+	}, nil
+}
+
+// MarkRead marks one of the user's notifications as read. Marking a read
+// notification again keeps its original read time.
+func (s *NotificationService) MarkRead(notificationID, userID uint) (*models.UserNotification, error) {
+	var notification models.UserNotification
+	err := s.db.Where("id = ? AND user_id = ?", notificationID, userID).First(&notification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("notification", notificationID)
+		}
+		return nil, apperrors.NewDatabaseError("get notification", err)
+	}
+	if notification.ReadAt != nil {
+		return &notification, nil
+	}
+
+	now := time.Now()
+	if err := s.db.Model(&notification).Update("read_at", now).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("mark notification read", err)
+	}
+	notification.ReadAt = &now
+	return &notification, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read and
+// returns how many there were.
+func (s *NotificationService) MarkAllRead(userID uint) (int64, error) {
+	result := s.db.Model(&models.UserNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, apperrors.NewDatabaseError("mark notifications read", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetPreferences returns the user's channels for every notification type,
+// filling in the defaults for types the user has not set.
+func (s *NotificationService) GetPreferences(userID uint) ([]models.NotificationPreference, error) {
+	var stored []models.NotificationPreference
+	if err := s.db.Where("user_id = ?", userID).Find(&stored).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get notification preferences", err)
+	}
+	byType := make(map[string]models.NotificationPreference, len(stored))
+	for _, preference := range stored {
+		byType[preference.Type] = preference
+	}
+
+	preferences := make([]models.NotificationPreference, len(NotificationTypes))
+	for i, notificationType := range NotificationTypes {
+		preference, ok := byType[notificationType]
+		if !ok {
+			preference = defaultNotificationPreference(notificationType)
+		}
+		preferences[i] = preference
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences sets the user's channels for the given notification
+// types and returns the preferences for all types.
+func (s *NotificationService) UpdatePreferences(userID uint, req *models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreference, error) {
+	// a type given twice keeps its last entry, since one upsert cannot
+	// write the same row twice
+	byType := make(map[string]int, len(req.Preferences))
+	preferences := make([]models.NotificationPreference, 0, len(req.Preferences))
+	for _, item := range req.Preferences {
+		if !slices.Contains(NotificationTypes, item.Type) {
+			return nil, apperrors.NewValidationError("type", fmt.Sprintf("unknown notification type %q", item.Type))
+		}
+		if i, ok := byType[item.Type]; ok {
+			preferences[i].InApp, preferences[i].Email, preferences[i].SMS = item.InApp, item.Email, item.SMS
+			continue
+		}
+		byType[item.Type] = len(preferences)
+		preferences = append(preferences, models.NotificationPreference{
+			UserID: userID,
+			Type:   item.Type,
+			InApp:  item.InApp,
+			Email:  item.Email,
+			SMS:    item.SMS,
+		})
+	}
+
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"in_app", "email", "sms", "updated_at"}),
+	}).Create(&preferences).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("update notification preferences", err)
+	}
+
+	return s.GetPreferences(userID)
+}
+
+// defaultNotificationPreference is what a user receives without a stored
+// preference: in-app only, so nobody gets email or SMS they did not ask for.
+func defaultNotificationPreference(notificationType string) models.NotificationPreference {
+	return models.NotificationPreference{
+		Type:  notificationType,
+		InApp: true,
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	NotificationCredentialExpiringSoon = "credential.expiring_soon"
+	NotificationCredentialExpired      = "credential.expired"
+	NotificationPasswordReset          = "security.password_reset"
+)
+
+// NotificationTypes are the notification types users can set channel
+// preferences for.
+var NotificationTypes = []string{
+	NotificationCredentialExpiringSoon,
+	NotificationCredentialExpired,
+	NotificationPasswordReset,
+}
+
+// Notification is a message about a hospital's data that should reach the
+// people responsible for it. UserIDs names the recipients; without them it
+// goes to the hospital's authorized users.
+type Notification struct {
+	HospitalID uint
+	UserIDs    []uint
+	Type       string
+	Title      string
+	Message    string
+	Data       map[string]interface{}
+}
+
+// Notifier delivers notifications. Implementations must be safe for
+// concurrent use.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// LogNotifier writes notifications to the application log.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, notification Notification) error {
+	log.Info().
+		Uint("hospital_id", notification.HospitalID).
+		Str("type", notification.Type).
+		Interface("data", notification.Data).
+		Msg(notification.Title + ": " + notification.Message)
+	return nil
+}
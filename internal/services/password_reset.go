@@ -1,18 +1,21 @@
 package services
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
 type PasswordResetService struct {
 	db          *gorm.DB
 	authService *AuthService
+	notifier    Notifier
 }
 
 func NewPasswordResetService(db *gorm.DB, authService *AuthService) *PasswordResetService {
@@ -22,6 +25,17 @@ func NewPasswordResetService(db *gorm.DB, authService *AuthService) *PasswordRes
 	}
 }
 
+func (s *PasswordResetService) WithContext(ctx context.Context) *PasswordResetService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+// SetNotifier makes the service tell users when their password was reset.
+func (s *PasswordResetService) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
 func (s *PasswordResetService) RequestPasswordReset(phone string) (string, error) {
 	var user models.User
 	if err := s.db.Where("phone = ?", phone).First(&user).Error; err != nil {
@@ -90,7 +104,30 @@ func (s *PasswordResetService) ResetPassword(phone, code, newPassword, confirmPa
 		return err
 	}
 
-	return tx.Commit().Error
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	if s.notifier != nil {
+		notification := Notification{
+			HospitalID: user.HospitalID,
+			UserIDs:    []uint{user.ID},
+			Type:       NotificationPasswordReset,
+			Title:      "Password reset",
+			Message:    "Your password was reset. If this was not you, contact your hospital's administrator.",
+		}
+		if err := s.notifier.Notify(s.db.Statement.Context, notification); err != nil {
+			log.Error().Err(err).Uint("user_id", user.ID).Msg("Failed to send password reset notification")
+		}
+	}
+	return nil
+}
+
+// PurgeExpired deletes the reset codes that were used or expired before the
+// given time and returns how many it deleted.
+func (s *PasswordResetService) PurgeExpired(before time.Time) (int64, error) {
+	result := s.db.Where("used = true OR expires_at < ?", before).Delete(&models.PasswordReset{})
+	return result.RowsAffected, result.Error
 }
 
 func (s *PasswordResetService) generateCode() string {
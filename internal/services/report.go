@@ -0,0 +1,308 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	maxDailyTrendDays = 366
+	reportDateLayout  = "2006-01-02"
+)
+
+type ReportService struct {
+	db       *gorm.DB
+	location *time.Location
+}
+
+func NewReportService(db *gorm.DB, cfg *config.Config) *ReportService {
+	location, err := time.LoadLocation(cfg.Attendance.Timezone)
+	if err != nil {
+		log.Warn().Err(err).Str("timezone", cfg.Attendance.Timezone).Msg("Invalid report timezone, falling back to UTC")
+		location = time.UTC
+	}
+
+	return &ReportService{
+		db:       db,
+		location: location,
+	}
+}
+
+// Snapshot replaces the snapshot of the day containing now with the current
+// headcount of every hospital.
+func (s *ReportService) Snapshot(now time.Time) (int64, error) {
+	date := now.In(s.location).Format(reportDateLayout)
+
+	var rows int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("date = ?", date).Delete(&models.HeadcountSnapshot{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(`
+			INSERT INTO headcount_snapshots (date, hospital_id, clinic_id, profession_group_id, title_id, count, created_at)
+			SELECT ?::date, s.hospital_id, s.clinic_id, s.profession_group_id, s.title_id, COUNT(*), NOW()
+			FROM staffs s
+			JOIN hospitals h ON h.id = s.hospital_id AND h.deleted_at IS NULL
+			WHERE s.deleted_at IS NULL AND s.status <> ?
+			GROUP BY s.hospital_id, s.clinic_id, s.profession_group_id, s.title_id
+		`, date, models.StaffTerminated)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, apperrors.NewDatabaseError("snapshot headcount", err)
+	}
+	return rows, nil
+}
+
+// Backfill reconstructs the daily snapshots between from and to. A staff
+// member counts on a day when they were created before it ended, not deleted
+// by then and not terminated by their latest status change. The clinic comes
+// from the assignment history, falling back to the current clinic for staff
+// without one; profession group and title are the current ones, as their
+// history is not kept. Days that already have snapshots are skipped unless
+// overwrite is set.
+func (s *ReportService) Backfill(from, to time.Time, overwrite bool) (*models.HeadcountBackfillResult, error) {
+	fromDate := from.In(s.location).Format(reportDateLayout)
+	toDate := to.In(s.location).Format(reportDateLayout)
+	if fromDate > toDate {
+		return nil, apperrors.NewValidationError("from", "must not be after to")
+	}
+	if toDate > time.Now().In(s.location).Format(reportDateLayout) {
+		return nil, apperrors.NewValidationError("to", "must not be in the future")
+	}
+
+	result := &models.HeadcountBackfillResult{From: fromDate, To: toDate}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if overwrite {
+			if err := tx.Where("date BETWEEN ? AND ?", fromDate, toDate).Delete(&models.HeadcountSnapshot{}).Error; err != nil {
+				return err
+			}
+		}
+
+		var days int64
+		err := tx.Raw(`
+			SELECT COUNT(*)
+			FROM generate_series(?::timestamp, ?::timestamp, interval '1 day') AS d(day)
+			WHERE NOT EXISTS (SELECT 1 FROM headcount_snapshots hs WHERE hs.date = d.day::date)
+		`, fromDate, toDate).Scan(&days).Error
+		if err != nil {
+			return err
+		}
+		result.Days = int(days)
+
+		insert := tx.Exec(`
+			INSERT INTO headcount_snapshots (date, hospital_id, clinic_id, profession_group_id, title_id, count, created_at)
+			SELECT day, hospital_id, clinic_id, profession_group_id, title_id, COUNT(*), NOW()
+			FROM (
+				SELECT d.day::date AS day, s.hospital_id,
+					CASE WHEN EXISTS (SELECT 1 FROM staff_clinic_assignments x WHERE x.staff_id = s.id)
+						THEN a.clinic_id ELSE s.clinic_id END AS clinic_id,
+					s.profession_group_id, s.title_id
+				FROM generate_series(?::timestamp, ?::timestamp, interval '1 day') AS d(day)
+				CROSS JOIN LATERAL (SELECT (d.day + interval '1 day') AT TIME ZONE ? AS cutoff) c
+				JOIN staffs s ON s.created_at < c.cutoff AND (s.deleted_at IS NULL OR s.deleted_at >= c.cutoff)
+				JOIN hospitals h ON h.id = s.hospital_id AND (h.deleted_at IS NULL OR h.deleted_at >= c.cutoff)
+				LEFT JOIN LATERAL (
+					SELECT x.clinic_id FROM staff_clinic_assignments x
+					WHERE x.staff_id = s.id AND x.started_at < c.cutoff AND (x.ended_at IS NULL OR x.ended_at >= c.cutoff)
+					ORDER BY x.started_at DESC LIMIT 1
+				) a ON true
+				LEFT JOIN LATERAL (
+					SELECT sc.to_status FROM staff_status_changes sc
+					WHERE sc.staff_id = s.id AND sc.effective_at < c.cutoff
+					ORDER BY sc.effective_at DESC, sc.id DESC LIMIT 1
+				) st ON true
+				WHERE st.to_status IS DISTINCT FROM ?
+					AND NOT EXISTS (SELECT 1 FROM headcount_snapshots hs WHERE hs.date = d.day::date)
+			) AS present
+			GROUP BY day, hospital_id, clinic_id, profession_group_id, title_id
+		`, fromDate, toDate, s.location.String(), models.StaffTerminated)
+		result.Rows = insert.RowsAffected
+		return insert.Error
+	})
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("backfill headcount", err)
+	}
+	return result, nil
+}
+
+// GetHeadcountTrend returns the headcount per period between from and to,
+// optionally narrowed to a clinic, profession group or title and split into
+// one series per clinic, profession group or title. Periods without any
+// snapshot are left out.
+func (s *ReportService) GetHeadcountTrend(filter *models.HeadcountTrendRequest, hospitalID uint) (*models.HeadcountTrendResponse, error) {
+	from, err := time.ParseInLocation(reportDateLayout, filter.From, s.location)
+	if err != nil {
+		return nil, apperrors.NewValidationError("from", "must be a date in YYYY-MM-DD format")
+	}
+	to, err := time.ParseInLocation(reportDateLayout, filter.To, s.location)
+	if err != nil {
+		return nil, apperrors.NewValidationError("to", "must be a date in YYYY-MM-DD format")
+	}
+	if to.Before(from) {
+		return nil, apperrors.NewValidationError("to", "must not be before from")
+	}
+	if filter.Granularity == "" {
+		filter.Granularity = models.GranularityMonth
+	}
+	if filter.Granularity == models.GranularityDay && to.Sub(from) >= maxDailyTrendDays*24*time.Hour {
+		return nil, apperrors.NewValidationError("to", "daily trends cover at most 366 days")
+	}
+
+	var periods []struct {
+		Period time.Time
+		Date   time.Time
+	}
+	err = s.db.Model(&models.HeadcountSnapshot{}).
+		Select("date_trunc(?, date)::date AS period, MAX(date) AS date", string(filter.Granularity)).
+		Where("hospital_id = ? AND date BETWEEN ? AND ?", hospitalID, filter.From, filter.To).
+		Group("1").
+		Order("1").
+		Scan(&periods).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get headcount periods", err)
+	}
+
+	keyColumn := "0"
+	switch filter.GroupBy {
+	case "clinic":
+		keyColumn = "COALESCE(clinic_id, 0)"
+	case "profession_group":
+		keyColumn = "profession_group_id"
+	case "title":
+		keyColumn = "title_id"
+	}
+
+	dates := make([]string, len(periods))
+	for i, period := range periods {
+		dates[i] = period.Date.Format(reportDateLayout)
+	}
+
+	var counts []struct {
+		Date  time.Time
+		KeyID uint
+		Count int64
+	}
+	if len(dates) > 0 {
+		query := s.db.Model(&models.HeadcountSnapshot{}).
+			Select("date, "+keyColumn+" AS key_id, SUM(count) AS count").
+			Where("hospital_id = ? AND date IN ?", hospitalID, dates)
+		switch filter.ClinicID {
+		case "":
+		case unassignedClinicFilter:
+			query = query.Where("clinic_id IS NULL")
+		default:
+			clinicID, err := strconv.ParseUint(filter.ClinicID, 10, 64)
+			if err != nil {
+				return nil, apperrors.NewValidationError("clinic_id", "must be a clinic ID or unassigned")
+			}
+			query = query.Where("clinic_id = ?", clinicID)
+		}
+		if filter.ProfessionGroupID != 0 {
+			query = query.Where("profession_group_id = ?", filter.ProfessionGroupID)
+		}
+		if filter.TitleID != 0 {
+			query = query.Where("title_id = ?", filter.TitleID)
+		}
+		if err := query.Group("date, key_id").Scan(&counts).Error; err != nil {
+			return nil, apperrors.NewDatabaseError("get headcount trend", err)
+		}
+	}
+
+	byKey := make(map[uint]map[string]int64)
+	if filter.GroupBy == "" {
+		byKey[0] = map[string]int64{}
+	}
+	for _, count := range counts {
+		if byKey[count.KeyID] == nil {
+			byKey[count.KeyID] = map[string]int64{}
+		}
+		byKey[count.KeyID][count.Date.Format(reportDateLayout)] = count.Count
+	}
+
+	names, err := s.seriesNames(filter.GroupBy, byKey)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.HeadcountTrendResponse{
+		From:        filter.From,
+		To:          filter.To,
+		Granularity: filter.Granularity,
+		GroupBy:     filter.GroupBy,
+		Series:      make([]models.HeadcountTrendSeries, 0, len(byKey)),
+	}
+	for keyID, countsByDate := range byKey {
+		series := models.HeadcountTrendSeries{
+			ID:     keyID,
+			Name:   names[keyID],
+			Points: make([]models.HeadcountTrendPoint, 0, len(periods)),
+		}
+		for i, period := range periods {
+			series.Points = append(series.Points, models.HeadcountTrendPoint{
+				Period: period.Period.Format(reportDateLayout),
+				Date:   dates[i],
+				Count:  countsByDate[dates[i]],
+			})
+		}
+		response.Series = append(response.Series, series)
+	}
+	sort.Slice(response.Series, func(i, j int) bool {
+		if response.Series[i].Name != response.Series[j].Name {
+			return response.Series[i].Name < response.Series[j].Name
+		}
+		return response.Series[i].ID < response.Series[j].ID
+	})
+
+	return response, nil
+}
+
+// seriesNames looks up the display names of the series keys. Clinics are
+// looked up including deleted ones, as their history stays in the snapshots.
+func (s *ReportService) seriesNames(groupBy string, byKey map[uint]map[string]int64) (map[uint]string, error) {
+	ids := make([]uint, 0, len(byKey))
+	for keyID := range byKey {
+		ids = append(ids, keyID)
+	}
+	names := make(map[uint]string, len(ids))
+
+	switch groupBy {
+	case "":
+		names[0] = "Total"
+	case "clinic":
+		names[0] = "Unassigned"
+		var clinics []models.Clinic
+		if err := s.db.Unscoped().Preload("ClinicType").Where("id IN ?", ids).Find(&clinics).Error; err != nil {
+			return nil, apperrors.NewDatabaseError("get clinics", err)
+		}
+		for _, clinic := range clinics {
+			names[clinic.ID] = clinic.DisplayName()
+		}
+	case "profession_group":
+		var groups []models.ProfessionGroup
+		if err := s.db.Where("id IN ?", ids).Find(&groups).Error; err != nil {
+			return nil, apperrors.NewDatabaseError("get profession groups", err)
+		}
+		for _, group := range groups {
+			names[group.ID] = group.Name
+		}
+	case "title":
+		var titles []models.Title
+		if err := s.db.Where("id IN ?", ids).Find(&titles).Error; err != nil {
+			return nil, apperrors.NewDatabaseError("get titles", err)
+		}
+		for _, title := range titles {
+			names[title.ID] = title.Name
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Name search relies on the tr_fold SQL function and the trigram indexes
+// created by the database package. Both sides of every comparison are folded
+// with tr_fold, so Turkish casing and diacritics never affect a match.
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// applyNameSearch keeps the rows whose folded name document contains every
+// word of q, either as a substring or as a close trigram match of one of its
+// words ("yilmas" finds "Yılmaz"). prefixColumns are additionally matched
+// against the whole query as a case-insensitive prefix, e.g. national IDs.
+func applyNameSearch(query *gorm.DB, q, document string, prefixColumns ...string) *gorm.DB {
+	words := strings.Fields(q)
+	if len(words) == 0 {
+		return query
+	}
+
+	folded := "tr_fold(" + document + ")"
+	conditions := make([]string, 0, len(words))
+	vars := make([]interface{}, 0, len(words)*2+len(prefixColumns))
+	for _, word := range words {
+		conditions = append(conditions, "("+folded+" LIKE '%' || tr_fold(?) || '%' OR tr_fold(?) <% "+folded+")")
+		vars = append(vars, likeEscaper.Replace(word), word)
+	}
+
+	condition := strings.Join(conditions, " AND ")
+	for _, column := range prefixColumns {
+		condition = "(" + condition + ") OR tr_fold(" + column + ") LIKE tr_fold(?) || '%'"
+		vars = append(vars, likeEscaper.Replace(strings.TrimSpace(q)))
+	}
+
+	return query.Where("("+condition+")", vars...)
+}
+
+// orderByNameRank puts names starting with q first and then sorts by word
+// similarity, falling back to tieBreaker for a stable order.
+func orderByNameRank(query *gorm.DB, q, document, tieBreaker string) *gorm.DB {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return query
+	}
+
+	folded := "tr_fold(" + document + ")"
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "(" + folded + " LIKE tr_fold(?) || '%') DESC, word_similarity(tr_fold(?), " + folded + ") DESC, " + tieBreaker,
+		Vars: []interface{}{likeEscaper.Replace(q), q},
+	}})
+}
@@ -7,15 +7,18 @@ import (
 	"math"
 	"time"
 
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
 	"github.com/caner-cetin/hospital-tracker/internal/models"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StaffService struct {
-	db          *gorm.DB
-	redisClient *redis.Client
+	db                     *gorm.DB
+	redisClient            *redis.Client
+	blockExpiredCredential bool
 }
 
 func NewStaffService(db *gorm.DB, redisClient *redis.Client) *StaffService {
@@ -25,12 +28,34 @@ func NewStaffService(db *gorm.DB, redisClient *redis.Client) *StaffService {
 	}
 }
 
+func (s *StaffService) WithContext(ctx context.Context) *StaffService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+// withDB returns a copy of the service that runs its queries on db, which is
+// usually a transaction.
+func (s *StaffService) withDB(db *gorm.DB) *StaffService {
+	return &StaffService{
+		db:                     db,
+		redisClient:            s.redisClient,
+		blockExpiredCredential: s.blockExpiredCredential,
+	}
+}
+
+// SetBlockExpiredCredentials rejects clinic assignments of staff holding an
+// expired mandatory credential when block is true.
+func (s *StaffService) SetBlockExpiredCredentials(block bool) {
+	s.blockExpiredCredential = block
+}
+
 func (s *StaffService) CreateStaff(req *models.CreateStaffRequest, hospitalID uint) (*models.Staff, error) {
 	if err := s.validateStaffUniqueness(req.NationalID, req.Phone, 0); err != nil {
 		return nil, err
 	}
 
-	if err := s.validateTitleProfessionGroup(req.TitleID, req.ProfessionGroupID); err != nil {
+	if err := s.validateTitleProfessionGroup(req.TitleID, req.ProfessionGroupID, hospitalID); err != nil {
 		return nil, err
 	}
 
@@ -40,20 +65,15 @@ func (s *StaffService) CreateStaff(req *models.CreateStaffRequest, hospitalID ui
 		}
 	}
 
-	var title models.Title
-	if err := s.db.Preload("ProfessionGroup").First(&title, req.TitleID).Error; err != nil {
-		return nil, errors.New("title not found")
+	if req.Employment != nil {
+		if err := validateEmploymentDates(req.Employment); err != nil {
+			return nil, err
+		}
 	}
 
-	if title.ProfessionGroup.Name == "İdari Personel" && title.Name == "Başhekim" {
-		var count int64
-		s.db.Model(&models.Staff{}).
-			Joins("JOIN titles ON staffs.title_id = titles.id").
-			Where("titles.name = ? AND staffs.hospital_id = ?", "Başhekim", hospitalID).
-			Count(&count)
-		if count > 0 {
-			return nil, errors.New("hospital can only have one chief physician (Başhekim)")
-		}
+	status := req.Status
+	if status == "" {
+		status = models.StaffActive
 	}
 
 	workingDaysJSON, err := json.Marshal(req.WorkingDays)
@@ -71,9 +91,34 @@ func (s *StaffService) CreateStaff(req *models.CreateStaffRequest, hospitalID ui
 		HospitalID:        hospitalID,
 		ClinicID:          req.ClinicID,
 		WorkingDays:       string(workingDaysJSON),
+		Status:            status,
 	}
 
-	if err := s.db.Create(staff).Error; err != nil {
+	// Transaction nests as a savepoint, so bulk import can create staff inside
+	// its own transaction.
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(staff).Error; err != nil {
+			return err
+		}
+		if staff.ClinicID != nil {
+			if err := moveClinicAssignment(tx, staff, staff.ClinicID, staff.CreatedAt, "initial assignment", nil); err != nil {
+				return err
+			}
+		}
+		if req.Employment != nil {
+			if err := openEmployment(tx, staff, req.Employment); err != nil {
+				return err
+			}
+		}
+		if err := recordStaffVersion(tx, staff.ID, staff.CreatedAt); err != nil {
+			return err
+		}
+		if err := publishStaffEvent(tx, models.WebhookStaffCreated, staff.ID, nil); err != nil {
+			return err
+		}
+		return evaluateStaffingRules(tx, nil, staff)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -94,6 +139,7 @@ func (s *StaffService) UpdateStaff(staffID uint, req *models.UpdateStaffRequest,
 		}
 		return nil, err
 	}
+	before := staff
 
 	if req.NationalID != "" && req.NationalID != staff.NationalID {
 		if err := s.validateStaffUniqueness(req.NationalID, "", staffID); err != nil {
@@ -110,34 +156,27 @@ func (s *StaffService) UpdateStaff(staffID uint, req *models.UpdateStaffRequest,
 	}
 
 	if req.TitleID != 0 && req.ProfessionGroupID != 0 {
-		if err := s.validateTitleProfessionGroup(req.TitleID, req.ProfessionGroupID); err != nil {
+		if err := s.validateTitleProfessionGroup(req.TitleID, req.ProfessionGroupID, hospitalID); err != nil {
 			return nil, err
 		}
-
-		var title models.Title
-		if err := s.db.Preload("ProfessionGroup").First(&title, req.TitleID).Error; err != nil {
-			return nil, errors.New("title not found")
-		}
-
-		if title.ProfessionGroup.Name == "İdari Personel" && title.Name == "Başhekim" {
-			var count int64
-			s.db.Model(&models.Staff{}).
-				Joins("JOIN titles ON staffs.title_id = titles.id").
-				Where("titles.name = ? AND staffs.hospital_id = ? AND staffs.id != ?", "Başhekim", hospitalID, staffID).
-				Count(&count)
-			if count > 0 {
-				return nil, errors.New("hospital can only have one chief physician (Başhekim)")
-			}
-		}
-
 		staff.TitleID = req.TitleID
 		staff.ProfessionGroupID = req.ProfessionGroupID
 	}
 
+	clinicChanged := false
 	if req.ClinicID != nil {
 		if err := s.validateClinicBelongsToHospital(*req.ClinicID, hospitalID); err != nil {
 			return nil, err
 		}
+		clinicChanged = staff.ClinicID == nil || *staff.ClinicID != *req.ClinicID
+		if clinicChanged {
+			if err := checkAssignable(&staff); err != nil {
+				return nil, err
+			}
+			if err := checkCredentialsForAssignment(s.db, staff.ID, s.blockExpiredCredential); err != nil {
+				return nil, err
+			}
+		}
 		staff.ClinicID = req.ClinicID
 	}
 
@@ -157,7 +196,41 @@ func (s *StaffService) UpdateStaff(staffID uint, req *models.UpdateStaffRequest,
 		staff.WorkingDays = string(workingDaysJSON)
 	}
 
-	if err := s.db.Save(&staff).Error; err != nil {
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if clinicChanged {
+		if err := moveClinicAssignment(tx, &staff, staff.ClinicID, time.Now(), "staff record updated", nil); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Save(&staff).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := recordStaffVersion(tx, staff.ID, time.Now()); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := publishStaffEvent(tx, models.WebhookStaffUpdated, staff.ID, nil); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := evaluateStaffingRules(tx, &before, &staff); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
@@ -170,74 +243,337 @@ func (s *StaffService) UpdateStaff(staffID uint, req *models.UpdateStaffRequest,
 	return &staff, nil
 }
 
+// DeleteStaff removes an onboarding staff record entered by mistake. Staff
+// with employment or status history leave through a status change to
+// terminated instead, which keeps them available for reporting.
 func (s *StaffService) DeleteStaff(staffID uint, hospitalID uint) error {
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
 	var staff models.Staff
-	if err := s.db.Where("id = ? AND hospital_id = ?", staffID, hospitalID).First(&staff).Error; err != nil {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND hospital_id = ?", staffID, hospitalID).
+		First(&staff).Error
+	if err != nil {
+		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("staff not found")
 		}
 		return err
 	}
 
-	return s.db.Delete(&staff).Error
+	if err := checkDeletable(tx, &staff); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := moveClinicAssignment(tx, &staff, nil, time.Now(), "staff deleted", nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Delete(&staff).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordStaffVersion(tx, staff.ID, time.Now()); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := publishStaffEvent(tx, models.WebhookStaffDeleted, staff.ID, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := evaluateStaffingRules(tx, &staff, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
-func (s *StaffService) GetStaff(filter *models.StaffFilterRequest, hospitalID uint) (*models.StaffPaginatedResponse, error) {
-	query := s.db.Model(&models.Staff{}).Where("hospital_id = ?", hospitalID)
+// TransferStaff moves a staff member to another clinic, or out of any clinic
+// when ClinicID is nil, closing the current assignment and opening the next
+// one in a single transaction.
+func (s *StaffService) TransferStaff(staffID uint, req *models.TransferStaffRequest, hospitalID, userID uint) (*models.Staff, error) {
+	if req.ClinicID != nil {
+		if err := s.validateClinicBelongsToHospital(*req.ClinicID, hospitalID); err != nil {
+			return nil, apperrors.NewValidationError("clinic_id", err.Error())
+		}
+	}
 
-	if filter.FirstName != "" {
-		query = query.Where("first_name ILIKE ?", "%"+filter.FirstName+"%")
+	effectiveAt := time.Now()
+	if req.EffectiveAt != nil {
+		if req.EffectiveAt.After(effectiveAt) {
+			return nil, apperrors.NewValidationError("effective_at", "transfer cannot take effect in the future")
+		}
+		effectiveAt = *req.EffectiveAt
 	}
 
-	if filter.LastName != "" {
-		query = query.Where("last_name ILIKE ?", "%"+filter.LastName+"%")
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Locking the staff row serialises concurrent transfers of the same
+	// person, so the checks below see the assignment the last one committed.
+	var staff models.Staff
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND hospital_id = ?", staffID, hospitalID).
+		First(&staff).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewStaffNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+
+	if sameClinic(staff.ClinicID, req.ClinicID) {
+		tx.Rollback()
+		return nil, apperrors.NewBusinessRuleError("staff is already assigned to this clinic", map[string]interface{}{
+			"staff_id":  staff.ID,
+			"clinic_id": req.ClinicID,
+		})
+	}
+
+	if req.ClinicID != nil {
+		if err := checkAssignable(&staff); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := checkCredentialsForAssignment(tx, staff.ID, s.blockExpiredCredential); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
 
-	if filter.NationalID != "" {
-		query = query.Where("national_id ILIKE ?", "%"+filter.NationalID+"%")
+	var current models.StaffClinicAssignment
+	err = tx.Where("staff_id = ? AND ended_at IS NULL", staff.ID).First(&current).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, apperrors.NewDatabaseError("find current assignment", err)
+	}
+	if err == nil && effectiveAt.Before(current.StartedAt) {
+		tx.Rollback()
+		return nil, apperrors.NewValidationError("effective_at", "transfer cannot take effect before the current assignment started")
 	}
 
-	if filter.ProfessionGroupID != 0 {
-		query = query.Where("profession_group_id = ?", filter.ProfessionGroupID)
+	before := staff
+	if err := moveClinicAssignment(tx, &staff, req.ClinicID, effectiveAt, req.Reason, &userID); err != nil {
+		tx.Rollback()
+		return nil, apperrors.NewDatabaseError("move clinic assignment", err)
 	}
 
-	if filter.TitleID != 0 {
-		query = query.Where("title_id = ?", filter.TitleID)
+	if err := tx.Model(&staff).Update("clinic_id", req.ClinicID).Error; err != nil {
+		tx.Rollback()
+		return nil, apperrors.NewDatabaseError("update staff clinic", err)
 	}
 
-	var totalCount int64
-	if err := query.Count(&totalCount).Error; err != nil {
+	if err := recordStaffVersion(tx, staff.ID, effectiveAt); err != nil {
+		tx.Rollback()
+		return nil, apperrors.NewDatabaseError("record staff version", err)
+	}
+
+	if err := publishStaffEvent(tx, models.WebhookStaffTransferred, staff.ID, before.ClinicID); err != nil {
+		tx.Rollback()
+		return nil, apperrors.NewDatabaseError("publish staff event", err)
+	}
+
+	after := before
+	after.ClinicID = req.ClinicID
+	if err := evaluateStaffingRules(tx, &before, &after); err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
-	if filter.Limit <= 0 {
-		filter.Limit = 10
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.NewDatabaseError("commit transaction", err)
 	}
-	if filter.Page <= 0 {
-		filter.Page = 1
+
+	return s.GetStaffByID(staff.ID, hospitalID)
+}
+
+func (s *StaffService) GetStaffAssignments(staffID uint, hospitalID uint) ([]models.StaffClinicAssignment, error) {
+	var count int64
+	if err := s.db.Model(&models.Staff{}).Where("id = ? AND hospital_id = ?", staffID, hospitalID).Count(&count).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+	if count == 0 {
+		return nil, apperrors.NewStaffNotFoundError()
 	}
 
-	offset := (filter.Page - 1) * filter.Limit
-	totalPages := int(math.Ceil(float64(totalCount) / float64(filter.Limit)))
+	var assignments []models.StaffClinicAssignment
+	err := s.db.Where("staff_id = ?", staffID).
+		Preload("Clinic", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Clinic.ClinicType").
+		Order("started_at DESC").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get staff assignments", err)
+	}
+	return assignments, nil
+}
+
+// GetClinicStaff returns the staff assigned to a clinic at the given point in
+// time. A date (YYYY-MM-DD) matches anyone assigned at any moment of that
+// day; an RFC 3339 timestamp matches that instant. An empty value means now.
+func (s *StaffService) GetClinicStaff(clinicID uint, hospitalID uint, asOf string) ([]models.Staff, error) {
+	var clinicCount int64
+	err := s.db.Unscoped().Model(&models.Clinic{}).
+		Where("id = ? AND hospital_id = ?", clinicID, hospitalID).
+		Count(&clinicCount).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("find clinic", err)
+	}
+	if clinicCount == 0 {
+		return nil, apperrors.NewClinicNotFoundError()
+	}
+
+	from, to, err := parseAsOf(asOf)
+	if err != nil {
+		return nil, apperrors.NewValidationError("as_of", err.Error())
+	}
 
 	var staff []models.Staff
-	err := query.Preload("ProfessionGroup").Preload("Title").
-		Preload("Hospital").Preload("Clinic.ClinicType").
-		Offset(offset).Limit(filter.Limit).
+	err = s.db.Unscoped().
+		Where("staffs.hospital_id = ?", hospitalID).
+		Where("staffs.deleted_at IS NULL OR staffs.deleted_at > ?", from).
+		Where(`EXISTS (
+			SELECT 1 FROM staff_clinic_assignments a
+			WHERE a.staff_id = staffs.id AND a.clinic_id = ?
+			AND a.started_at < ? AND (a.ended_at IS NULL OR a.ended_at > ?)
+		)`, clinicID, to, from).
+		Preload("ProfessionGroup").Preload("Title").
+		Order("staffs.last_name, staffs.first_name").
 		Find(&staff).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get clinic staff", err)
+	}
+	return staff, nil
+}
+
+func (s *StaffService) GetStaff(filter *models.StaffFilterRequest, hospitalID uint) (*models.StaffPaginatedResponse, error) {
+	query, err := applyStaffFilters(s.db.Model(&models.Staff{}), filter, hospitalID)
 	if err != nil {
 		return nil, err
 	}
+	query = query.Session(&gorm.Session{})
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("count staff", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultStaffPageLimit
+	}
+	if filter.Limit > maxStaffPageLimit {
+		filter.Limit = maxStaffPageLimit
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
 
-	return &models.StaffPaginatedResponse{
-		Data: staff,
+	offset := (filter.Page - 1) * filter.Limit
+	totalPages := int(math.Ceil(float64(totalCount) / float64(filter.Limit)))
+
+	response := &models.StaffPaginatedResponse{
 		BasePagination: models.BasePagination{
 			TotalCount: totalCount,
 			Page:       filter.Page,
 			Limit:      filter.Limit,
 			TotalPages: totalPages,
 		},
-	}, nil
+	}
+
+	page := query.Preload("ProfessionGroup").Preload("Title").
+		Preload("Hospital").Preload("Clinic.ClinicType")
+
+	// search results are ordered by relevance, which has no stable key to
+	// resume from, so they only support offset pagination
+	if filter.Q != "" && filter.Sort == "" {
+		if filter.Cursor != "" {
+			return nil, apperrors.NewValidationError("cursor", "cursor pagination requires an explicit sort when searching")
+		}
+
+		var staff []models.Staff
+		err := orderByNameRank(page, filter.Q, staffNameDocument, "staffs.id").
+			Offset(offset).Limit(filter.Limit).
+			Find(&staff).Error
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("get staff", err)
+		}
+		response.Data = staff
+		return response, nil
+	}
+
+	fields, err := parseStaffSort(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	backward := false
+	if filter.Cursor != "" {
+		cursor, err := decodeStaffCursor(filter.Cursor, fields)
+		if err != nil {
+			return nil, err
+		}
+		condition, vars, err := staffKeysetCondition(fields, cursor)
+		if err != nil {
+			return nil, err
+		}
+		page = page.Where(condition, vars...)
+		backward = cursor.Backward
+	} else {
+		page = page.Offset(offset)
+	}
+
+	// one extra row tells whether another page follows in this direction
+	var staff []models.Staff
+	err = page.Order(staffSortOrder(fields, backward)).Limit(filter.Limit + 1).Find(&staff).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get staff", err)
+	}
+
+	hasMore := len(staff) > filter.Limit
+	if hasMore {
+		staff = staff[:filter.Limit]
+	}
+	if backward {
+		for i, j := 0, len(staff)-1; i < j; i, j = i+1, j-1 {
+			staff[i], staff[j] = staff[j], staff[i]
+		}
+	}
+
+	if len(staff) > 0 {
+		first, last := &staff[0], &staff[len(staff)-1]
+		if backward {
+			response.NextCursor = encodeStaffCursor(fields, last, false)
+			if hasMore {
+				response.PrevCursor = encodeStaffCursor(fields, first, true)
+			}
+		} else {
+			if hasMore {
+				response.NextCursor = encodeStaffCursor(fields, last, false)
+			}
+			if filter.Cursor != "" || offset > 0 {
+				response.PrevCursor = encodeStaffCursor(fields, first, true)
+			}
+		}
+	}
+
+	response.Data = staff
+	return response, nil
 }
 
 func (s *StaffService) GetStaffByID(staffID uint, hospitalID uint) (*models.Staff, error) {
@@ -270,7 +606,9 @@ func (s *StaffService) GetProfessionGroups() ([]models.ProfessionGroupResponse,
 	}
 
 	var professionGroups []models.ProfessionGroup
-	err = s.db.Preload("Titles").Find(&professionGroups).Error
+	err = s.db.Where("hospital_id IS NULL").
+		Preload("Titles", "hospital_id IS NULL").
+		Find(&professionGroups).Error
 	if err != nil {
 		return nil, err
 	}
@@ -305,6 +643,16 @@ func (s *StaffService) GetProfessionGroups() ([]models.ProfessionGroupResponse,
 	return response, nil
 }
 
+// RefreshProfessionGroupCache reloads the cached profession groups and their
+// titles from the database.
+func (s *StaffService) RefreshProfessionGroupCache(ctx context.Context) error {
+	if err := s.redisClient.Del(ctx, "profession_groups").Err(); err != nil {
+		return err
+	}
+	_, err := s.GetProfessionGroups()
+	return err
+}
+
 func (s *StaffService) validateStaffUniqueness(nationalID, phone string, excludeID uint) error {
 	var count int64
 
@@ -337,9 +685,12 @@ func (s *StaffService) validateStaffUniqueness(nationalID, phone string, exclude
 	return nil
 }
 
-func (s *StaffService) validateTitleProfessionGroup(titleID, professionGroupID uint) error {
+func (s *StaffService) validateTitleProfessionGroup(titleID, professionGroupID, hospitalID uint) error {
 	var title models.Title
-	err := s.db.Where("id = ? AND profession_group_id = ?", titleID, professionGroupID).First(&title).Error
+	err := s.db.Scopes(visibleCatalog(hospitalID)).
+		Where("id = ? AND profession_group_id = ?", titleID, professionGroupID).
+		Where("profession_group_id IN (?)", s.db.Model(&models.ProfessionGroup{}).Select("id").Where("hospital_id IS NULL OR hospital_id = ?", hospitalID)).
+		First(&title).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("title does not belong to the specified profession group")
@@ -349,6 +700,65 @@ func (s *StaffService) validateTitleProfessionGroup(titleID, professionGroupID u
 	return nil
 }
 
+// checkCredentialsForAssignment fails when clinic assignments are blocked for
+// expired credentials and the staff member holds an expired mandatory one.
+func checkCredentialsForAssignment(db *gorm.DB, staffID uint, block bool) error {
+	if !block {
+		return nil
+	}
+
+	var expired []models.StaffCredential
+	err := db.Where("staff_id = ? AND mandatory = ? AND expires_at < ?", staffID, true, startOfDay(time.Now())).
+		Order("expires_at").
+		Find(&expired).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("check credentials", err)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	credentialIDs := make([]uint, len(expired))
+	for i, credential := range expired {
+		credentialIDs[i] = credential.ID
+	}
+	return apperrors.NewBusinessRuleError("staff with expired mandatory credentials cannot be assigned to a clinic", map[string]interface{}{
+		"staff_id":       staffID,
+		"credential_ids": credentialIDs,
+	})
+}
+
+// checkDeletable only lets onboarding staff without employment or status
+// history be deleted; everyone else is terminated instead.
+func checkDeletable(tx *gorm.DB, staff *models.Staff) error {
+	var history int64
+	err := tx.Raw(`SELECT (SELECT COUNT(*) FROM staff_employments WHERE staff_id = ?)
+		+ (SELECT COUNT(*) FROM staff_status_changes WHERE staff_id = ?)`, staff.ID, staff.ID).
+		Scan(&history).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("check staff history", err)
+	}
+	if staff.Status == models.StaffOnboarding && history == 0 {
+		return nil
+	}
+	return apperrors.NewBusinessRuleError("only onboarding staff without employment history can be deleted; terminate the staff member through POST /api/staff/:id/status instead", map[string]interface{}{
+		"staff_id": staff.ID,
+		"status":   staff.Status,
+	})
+}
+
+// checkAssignable fails for terminated staff, who have to be rehired before
+// they can work in a clinic again.
+func checkAssignable(staff *models.Staff) error {
+	if staff.Status != models.StaffTerminated {
+		return nil
+	}
+	return apperrors.NewBusinessRuleError("terminated staff cannot be assigned to a clinic", map[string]interface{}{
+		"staff_id": staff.ID,
+		"status":   staff.Status,
+	})
+}
+
 func (s *StaffService) validateClinicBelongsToHospital(clinicID, hospitalID uint) error {
 	var clinic models.Clinic
 	err := s.db.Where("id = ? AND hospital_id = ?", clinicID, hospitalID).First(&clinic).Error
@@ -360,3 +770,64 @@ func (s *StaffService) validateClinicBelongsToHospital(clinicID, hospitalID uint
 	}
 	return nil
 }
+
+// moveClinicAssignment closes the open clinic assignment of the staff member
+// at the given time and, when clinicID is set, opens a new one from then on.
+// A staff member leaving a clinic also stops being its head.
+func moveClinicAssignment(tx *gorm.DB, staff *models.Staff, clinicID *uint, at time.Time, reason string, createdByID *uint) error {
+	err := tx.Model(&models.StaffClinicAssignment{}).
+		Where("staff_id = ? AND ended_at IS NULL", staff.ID).
+		Update("ended_at", at).Error
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to close clinic assignment")
+	}
+
+	heads := tx.Unscoped().Model(&models.Clinic{}).Where("head_staff_id = ?", staff.ID)
+	if clinicID != nil {
+		heads = heads.Where("id <> ?", *clinicID)
+	}
+	if err := heads.Update("head_staff_id", nil).Error; err != nil {
+		return pkgerrors.Wrap(err, "failed to clear head of clinic")
+	}
+
+	if clinicID == nil {
+		return nil
+	}
+
+	assignment := &models.StaffClinicAssignment{
+		StaffID:     staff.ID,
+		HospitalID:  staff.HospitalID,
+		ClinicID:    *clinicID,
+		StartedAt:   at,
+		Reason:      reason,
+		CreatedByID: createdByID,
+	}
+	if err := tx.Create(assignment).Error; err != nil {
+		return pkgerrors.Wrap(err, "failed to open clinic assignment")
+	}
+	return nil
+}
+
+func sameClinic(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func parseAsOf(value string) (time.Time, time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return now, now.Add(time.Microsecond), nil
+	}
+
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+
+	instant, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, time.Time{}, pkgerrors.New("must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+	}
+	return instant, instant.Add(time.Microsecond), nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recordStaffVersion snapshots the staff row as written by tx. The previous
+// version is closed at changedAt and a new one opened, unless nothing that is
+// versioned changed. changedAt is moved forward to the start of the previous
+// version when a backdated write would otherwise make the versions overlap.
+func recordStaffVersion(tx *gorm.DB, staffID uint, changedAt time.Time) error {
+	var staff models.Staff
+	if err := tx.Unscoped().First(&staff, staffID).Error; err != nil {
+		return err
+	}
+	next := staffVersionOf(&staff)
+
+	var latest models.StaffVersion
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("staff_id = ?", staffID).
+		Order("version DESC").
+		First(&latest).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		next.Version = 1
+	case err != nil:
+		return err
+	default:
+		if len(diffStaffVersions(&latest, next)) == 0 {
+			return nil
+		}
+		if changedAt.Before(latest.ValidFrom) {
+			changedAt = latest.ValidFrom
+		}
+		if err := tx.Model(&latest).Update("valid_to", changedAt).Error; err != nil {
+			return err
+		}
+		next.Version = latest.Version + 1
+	}
+
+	next.ValidFrom = changedAt
+	next.ChangedByID = AuditActorFrom(tx.Statement.Context).UserID
+	return tx.Create(next).Error
+}
+
+func staffVersionOf(staff *models.Staff) *models.StaffVersion {
+	return &models.StaffVersion{
+		StaffID:           staff.ID,
+		HospitalID:        staff.HospitalID,
+		FirstName:         staff.FirstName,
+		LastName:          staff.LastName,
+		NationalID:        staff.NationalID,
+		Phone:             staff.Phone,
+		ProfessionGroupID: staff.ProfessionGroupID,
+		TitleID:           staff.TitleID,
+		ClinicID:          staff.ClinicID,
+		WorkingDays:       staff.WorkingDays,
+		Status:            staff.Status,
+		Deleted:           staff.DeletedAt.Valid,
+	}
+}
+
+type staffVersionField struct {
+	name  string
+	value interface{}
+}
+
+func staffVersionFields(v *models.StaffVersion) []staffVersionField {
+	var clinicID interface{}
+	if v.ClinicID != nil {
+		clinicID = *v.ClinicID
+	}
+
+	var workingDays interface{} = v.WorkingDays
+	var days []string
+	if err := json.Unmarshal([]byte(v.WorkingDays), &days); err == nil {
+		workingDays = days
+	}
+
+	return []staffVersionField{
+		{"first_name", v.FirstName},
+		{"last_name", v.LastName},
+		{"national_id", v.NationalID},
+		{"phone", v.Phone},
+		{"profession_group_id", v.ProfessionGroupID},
+		{"title_id", v.TitleID},
+		{"clinic_id", clinicID},
+		{"working_days", workingDays},
+		{"status", v.Status},
+		{"deleted", v.Deleted},
+	}
+}
+
+// diffStaffVersions lists the fields that differ from prev to next. With no
+// previous version every field is reported, coming from nil.
+func diffStaffVersions(prev, next *models.StaffVersion) []models.StaffFieldChange {
+	nextFields := staffVersionFields(next)
+	changes := []models.StaffFieldChange{}
+	if prev == nil {
+		for _, field := range nextFields {
+			changes = append(changes, models.StaffFieldChange{Field: field.name, To: field.value})
+		}
+		return changes
+	}
+
+	for i, field := range staffVersionFields(prev) {
+		if !reflect.DeepEqual(field.value, nextFields[i].value) {
+			changes = append(changes, models.StaffFieldChange{Field: field.name, From: field.value, To: nextFields[i].value})
+		}
+	}
+	return changes
+}
+
+// GetStaffHistory returns every version of a staff member, newest first, each
+// with the fields that changed from the version before it. Staff in the trash
+// keep their history.
+func (s *StaffService) GetStaffHistory(staffID, hospitalID uint) (*models.StaffHistoryResponse, error) {
+	var count int64
+	err := s.db.Unscoped().Model(&models.Staff{}).Where("id = ? AND hospital_id = ?", staffID, hospitalID).Count(&count).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+	if count == 0 {
+		return nil, apperrors.NewStaffNotFoundError()
+	}
+
+	var versions []models.StaffVersion
+	if err := s.db.Where("staff_id = ?", staffID).Order("version").Find(&versions).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get staff versions", err)
+	}
+
+	entries := make([]models.StaffHistoryEntry, len(versions))
+	var prev *models.StaffVersion
+	for i := range versions {
+		entries[len(versions)-1-i] = models.StaffHistoryEntry{
+			StaffVersion: versions[i],
+			Changes:      diffStaffVersions(prev, &versions[i]),
+		}
+		prev = &versions[i]
+	}
+
+	return &models.StaffHistoryResponse{StaffID: staffID, Versions: entries}, nil
+}
+
+// GetStaffAsOf rebuilds a staff record as it was at the given point in time.
+// A date (YYYY-MM-DD) returns the record as it stood at the end of that day.
+// A staff member that did not exist yet or was in the trash is not found.
+func (s *StaffService) GetStaffAsOf(staffID, hospitalID uint, asOf string) (*models.Staff, error) {
+	_, to, err := parseAsOf(asOf)
+	if err != nil {
+		return nil, apperrors.NewValidationError("as_of", err.Error())
+	}
+
+	var current models.Staff
+	err = s.db.Unscoped().Where("id = ? AND hospital_id = ?", staffID, hospitalID).First(&current).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewStaffNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+
+	var version models.StaffVersion
+	err = s.db.Where("staff_id = ?", staffID).
+		Where("valid_from < ? AND (valid_to IS NULL OR valid_to >= ?)", to, to).
+		First(&version).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewStaffNotFoundError()
+		}
+		return nil, apperrors.NewDatabaseError("find staff version", err)
+	}
+	if version.Deleted {
+		return nil, apperrors.NewStaffNotFoundError()
+	}
+
+	staff := &models.Staff{
+		ID:                current.ID,
+		FirstName:         version.FirstName,
+		LastName:          version.LastName,
+		NationalID:        version.NationalID,
+		Phone:             version.Phone,
+		ProfessionGroupID: version.ProfessionGroupID,
+		TitleID:           version.TitleID,
+		HospitalID:        current.HospitalID,
+		ClinicID:          version.ClinicID,
+		WorkingDays:       version.WorkingDays,
+		Status:            version.Status,
+		CreatedAt:         current.CreatedAt,
+		UpdatedAt:         version.ValidFrom,
+	}
+
+	// catalogue entries still named by a version cannot be deleted, but
+	// versions older than that rule may name one that is gone; it is left
+	// empty rather than failing the whole lookup
+	err = s.db.First(&staff.ProfessionGroup, staff.ProfessionGroupID).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.NewDatabaseError("find profession group", err)
+	}
+	err = s.db.First(&staff.Title, staff.TitleID).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.NewDatabaseError("find title", err)
+	}
+
+	// the hospital and clinic may have been soft-deleted since
+	db := s.db.Unscoped()
+	if err := db.First(&staff.Hospital, staff.HospitalID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find hospital", err)
+	}
+	if staff.ClinicID != nil {
+		var clinic models.Clinic
+		err := db.Preload("ClinicType").First(&clinic, *staff.ClinicID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewDatabaseError("find clinic", err)
+		}
+		if err == nil {
+			staff.Clinic = &clinic
+		}
+	}
+
+	return staff, nil
+}
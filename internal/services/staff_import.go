@@ -0,0 +1,557 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// imports with more rows than this run as a background job
+	staffImportAsyncThreshold = 500
+	// how often a background job reports its progress, in rows
+	staffImportProgressInterval = 25
+)
+
+var staffImportColumns = []string{
+	"first_name",
+	"last_name",
+	"national_id",
+	"phone",
+	"profession_group",
+	"title",
+	"clinic",
+	"working_days",
+}
+
+var workingDayNames = foldKeys(map[string]models.WorkingDay{
+	"monday":    models.Monday,
+	"tuesday":   models.Tuesday,
+	"wednesday": models.Wednesday,
+	"thursday":  models.Thursday,
+	"friday":    models.Friday,
+	"saturday":  models.Saturday,
+	"sunday":    models.Sunday,
+	"pazartesi": models.Monday,
+	"salı":      models.Tuesday,
+	"çarşamba":  models.Wednesday,
+	"perşembe":  models.Thursday,
+	"cuma":      models.Friday,
+	"cumartesi": models.Saturday,
+	"pazar":     models.Sunday,
+})
+
+type staffImportRow struct {
+	line   int
+	values map[string]string
+}
+
+// JobEnqueuer queues runs of background jobs; JobService implements it.
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, name string, payload []byte) (*models.JobRun, error)
+}
+
+type staffImportPayload struct {
+	ImportJobID uint `json:"import_job_id"`
+}
+
+type StaffImportService struct {
+	db             *gorm.DB
+	staffService   *StaffService
+	jobs           JobEnqueuer
+	asyncThreshold int
+}
+
+func NewStaffImportService(db *gorm.DB, staffService *StaffService) *StaffImportService {
+	return &StaffImportService{
+		db:             db,
+		staffService:   staffService,
+		asyncThreshold: staffImportAsyncThreshold,
+	}
+}
+
+// SetJobQueue sets the queue that runs imports above the async threshold.
+// Without one such imports are refused.
+func (s *StaffImportService) SetJobQueue(jobs JobEnqueuer) {
+	s.jobs = jobs
+}
+
+func (s *StaffImportService) WithContext(ctx context.Context) *StaffImportService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	clone.staffService = s.staffService.WithContext(ctx)
+	return &clone
+}
+
+// Import validates every row of a CSV or XLSX staff file with the same rules
+// as StaffService.CreateStaff. All rows are created in one transaction which
+// is only committed when no row failed and dryRun is false. Files above the
+// async threshold are stored with an import job that is queued for the
+// worker, and the job is returned instead of a report.
+func (s *StaffImportService) Import(fileName string, data []byte, dryRun bool, hospitalID, userID uint) (*models.StaffImportResponse, error) {
+	rows, err := parseStaffImportFile(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, apperrors.NewValidationError("file", "file contains no staff rows")
+	}
+
+	if len(rows) <= s.asyncThreshold {
+		report, err := s.importRows(hospitalID, rows, dryRun, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &models.StaffImportResponse{Report: report}, nil
+	}
+
+	if s.jobs == nil {
+		return nil, apperrors.NewBusinessRuleError("large imports need the background job queue, which is not configured", map[string]interface{}{
+			"rows":            len(rows),
+			"async_threshold": s.asyncThreshold,
+		})
+	}
+
+	job := &models.StaffImportJob{
+		HospitalID:  hospitalID,
+		CreatedByID: userID,
+		FileName:    fileName,
+		File:        data,
+		DryRun:      dryRun,
+		Status:      models.StaffImportPending,
+		TotalRows:   len(rows),
+	}
+	if err := s.db.Create(job).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create import job", err)
+	}
+
+	payload, err := json.Marshal(staffImportPayload{ImportJobID: job.ID})
+	if err != nil {
+		return nil, apperrors.NewInternalError("encode import job", err)
+	}
+	if _, err := s.jobs.Enqueue(s.db.Statement.Context, JobImportStaff, payload); err != nil {
+		s.db.Model(job).Updates(map[string]interface{}{
+			"status":       models.StaffImportFailed,
+			"error":        "import could not be queued",
+			"file":         nil,
+			"completed_at": time.Now(),
+		})
+		return nil, err
+	}
+
+	return &models.StaffImportResponse{Job: job}, nil
+}
+
+func (s *StaffImportService) GetJob(jobID, hospitalID uint) (*models.StaffImportJob, error) {
+	var job models.StaffImportJob
+	if err := s.db.Where("id = ? AND hospital_id = ?", jobID, hospitalID).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("import job", jobID)
+		}
+		return nil, apperrors.NewDatabaseError("get import job", err)
+	}
+	return &job, nil
+}
+
+// RunJob runs a queued import job as the user who uploaded the file. A job
+// that already finished is left alone, so a retried run never imports twice;
+// one left running by a stopped worker starts over, as its rows were rolled
+// back with the unfinished transaction.
+func (s *StaffImportService) RunJob(ctx context.Context, jobID uint) (err error) {
+	var job models.StaffImportJob
+	if err := s.db.WithContext(ctx).First(&job, jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.Status == models.StaffImportCompleted || job.Status == models.StaffImportFailed {
+		return nil
+	}
+
+	ctx = WithAuditActor(ctx, AuditActor{UserID: &job.CreatedByID, HospitalID: &job.HospitalID})
+	s = s.WithContext(ctx)
+	jobs := s.db.Model(&models.StaffImportJob{}).Where("id = ?", jobID).Session(&gorm.Session{})
+	fail := func(message string) {
+		jobs.Updates(map[string]interface{}{
+			"status":       models.StaffImportFailed,
+			"error":        message,
+			"file":         nil,
+			"completed_at": time.Now(),
+		})
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Interface("panic", r).Uint("job_id", jobID).Msg("Staff import job panicked")
+			fail("import aborted unexpectedly")
+			err = fmt.Errorf("staff import job %d panicked: %v", jobID, r)
+		}
+	}()
+
+	rows, err := parseStaffImportFile(job.FileName, job.File)
+	if err != nil {
+		fail(err.Error())
+		return nil
+	}
+	if err := jobs.Updates(map[string]interface{}{"status": models.StaffImportRunning, "processed_rows": 0}).Error; err != nil {
+		return err
+	}
+
+	report, err := s.importRows(job.HospitalID, rows, job.DryRun, func(processed int) {
+		if processed%staffImportProgressInterval != 0 {
+			return
+		}
+		if err := jobs.Update("processed_rows", processed).Error; err != nil {
+			log.Warn().Err(err).Uint("job_id", jobID).Msg("Failed to update staff import progress")
+		}
+	})
+	if err != nil {
+		log.Error().Err(err).Uint("job_id", jobID).Msg("Staff import job failed")
+		fail(err.Error())
+		return err
+	}
+
+	now := time.Now()
+	return jobs.Select("status", "processed_rows", "report", "file", "completed_at").Updates(&models.StaffImportJob{
+		Status:        models.StaffImportCompleted,
+		ProcessedRows: len(rows),
+		Report:        report,
+		File:          nil,
+		CompletedAt:   &now,
+	}).Error
+}
+
+func (s *StaffImportService) importRows(hospitalID uint, rows []staffImportRow, dryRun bool, progress func(processed int)) (*models.StaffImportReport, error) {
+	lookup, err := s.loadLookup(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.StaffImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    []models.StaffImportRowError{},
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	for i, row := range rows {
+		req, rowErr := lookup.toCreateRequest(row)
+		if rowErr == nil {
+			// each row runs in a savepoint so a failed row does not abort the
+			// rows that follow it
+			err := tx.Transaction(func(rowTx *gorm.DB) error {
+				_, err := s.staffService.withDB(rowTx).CreateStaff(req, hospitalID)
+				return err
+			})
+			if err != nil {
+				rowErr = &models.StaffImportRowError{Message: err.Error()}
+			}
+		}
+
+		if rowErr != nil {
+			rowErr.Row = row.line
+			rowErr.NationalID = row.values["national_id"]
+			report.Errors = append(report.Errors, *rowErr)
+		} else {
+			report.ValidRows++
+		}
+
+		if progress != nil {
+			progress(i + 1)
+		}
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		if err := tx.Rollback().Error; err != nil {
+			return nil, apperrors.NewDatabaseError("rollback import", err)
+		}
+		return report, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.NewDatabaseError("commit import", err)
+	}
+
+	report.Committed = true
+	report.ImportedRows = report.ValidRows
+	return report, nil
+}
+
+// staffImportLookup resolves the names or IDs used in an import file to the
+// reference records of the hospital.
+type staffImportLookup struct {
+	professionGroups map[string]models.ProfessionGroup
+	professionByID   map[uint]models.ProfessionGroup
+	clinics          map[string]uint
+	clinicIDs        map[uint]bool
+}
+
+func (s *StaffImportService) loadLookup(hospitalID uint) (*staffImportLookup, error) {
+	var professionGroups []models.ProfessionGroup
+	err := s.db.Scopes(visibleCatalog(hospitalID)).
+		Preload("Titles", visibleCatalog(hospitalID)).
+		Find(&professionGroups).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("load profession groups", err)
+	}
+
+	var clinics []models.Clinic
+	if err := s.db.Where("hospital_id = ?", hospitalID).Preload("ClinicType").Find(&clinics).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("load clinics", err)
+	}
+
+	lookup := &staffImportLookup{
+		professionGroups: make(map[string]models.ProfessionGroup, len(professionGroups)),
+		professionByID:   make(map[uint]models.ProfessionGroup, len(professionGroups)),
+		clinics:          make(map[string]uint, len(clinics)),
+		clinicIDs:        make(map[uint]bool, len(clinics)),
+	}
+	for _, pg := range professionGroups {
+		lookup.professionGroups[foldName(pg.Name)] = pg
+		lookup.professionByID[pg.ID] = pg
+	}
+	// A clinic is found by its type name or by its own name, which is what
+	// the export writes; the own name wins where the two collide.
+	for _, clinic := range clinics {
+		lookup.clinics[foldName(clinic.ClinicType.Name)] = clinic.ID
+		lookup.clinicIDs[clinic.ID] = true
+	}
+	for _, clinic := range clinics {
+		lookup.clinics[foldName(clinic.DisplayName())] = clinic.ID
+	}
+	return lookup, nil
+}
+
+func (l *staffImportLookup) toCreateRequest(row staffImportRow) (*models.CreateStaffRequest, *models.StaffImportRowError) {
+	for _, column := range []string{"first_name", "last_name", "national_id", "phone", "profession_group", "title"} {
+		if row.values[column] == "" {
+			return nil, &models.StaffImportRowError{Field: column, Message: "value is required"}
+		}
+	}
+
+	professionGroup, ok := l.findProfessionGroup(row.values["profession_group"])
+	if !ok {
+		return nil, &models.StaffImportRowError{Field: "profession_group", Message: "unknown profession group"}
+	}
+
+	title, ok := findTitle(professionGroup, row.values["title"])
+	if !ok {
+		return nil, &models.StaffImportRowError{Field: "title", Message: "title does not belong to the specified profession group"}
+	}
+
+	var clinicID *uint
+	if value := row.values["clinic"]; value != "" {
+		id, ok := l.findClinic(value)
+		if !ok {
+			return nil, &models.StaffImportRowError{Field: "clinic", Message: "clinic not found in hospital"}
+		}
+		clinicID = &id
+	}
+
+	workingDays, err := parseWorkingDayList(row.values["working_days"])
+	if err != nil {
+		return nil, &models.StaffImportRowError{Field: "working_days", Message: err.Error()}
+	}
+
+	return &models.CreateStaffRequest{
+		FirstName:         row.values["first_name"],
+		LastName:          row.values["last_name"],
+		NationalID:        row.values["national_id"],
+		Phone:             row.values["phone"],
+		ProfessionGroupID: professionGroup.ID,
+		TitleID:           title.ID,
+		ClinicID:          clinicID,
+		WorkingDays:       workingDays,
+	}, nil
+}
+
+func (l *staffImportLookup) findProfessionGroup(value string) (models.ProfessionGroup, bool) {
+	if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+		pg, ok := l.professionByID[uint(id)]
+		return pg, ok
+	}
+	pg, ok := l.professionGroups[foldName(value)]
+	return pg, ok
+}
+
+func (l *staffImportLookup) findClinic(value string) (uint, bool) {
+	if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return uint(id), l.clinicIDs[uint(id)]
+	}
+	id, ok := l.clinics[foldName(value)]
+	return id, ok
+}
+
+func findTitle(professionGroup models.ProfessionGroup, value string) (models.Title, bool) {
+	id, idErr := strconv.ParseUint(value, 10, 32)
+	for _, title := range professionGroup.Titles {
+		if idErr == nil && title.ID == uint(id) {
+			return title, true
+		}
+		if idErr != nil && foldName(title.Name) == foldName(value) {
+			return title, true
+		}
+	}
+	return models.Title{}, false
+}
+
+func parseWorkingDayList(value string) ([]models.WorkingDay, error) {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|' || unicode.IsSpace(r)
+	})
+
+	days := make([]models.WorkingDay, 0, len(fields))
+	seen := make(map[models.WorkingDay]bool, len(fields))
+	for _, field := range fields {
+		day, ok := workingDayNames[foldName(field)]
+		if !ok {
+			return nil, errors.New("unknown working day: " + field)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func parseStaffImportFile(fileName string, data []byte) ([]staffImportRow, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		records, err = readCSVRecords(data)
+	case ".xlsx":
+		records, err = readXLSXRecords(data)
+	default:
+		return nil, apperrors.NewValidationError("file", "only .csv and .xlsx files are supported")
+	}
+	if err != nil {
+		return nil, apperrors.NewValidationError("file", err.Error())
+	}
+	if len(records) == 0 {
+		return nil, apperrors.NewValidationError("file", "file is empty")
+	}
+
+	header := make([]string, len(records[0]))
+	present := make(map[string]bool, len(header))
+	for i, name := range records[0] {
+		header[i] = strings.ReplaceAll(foldName(strings.TrimPrefix(name, "\ufeff")), " ", "_")
+		present[header[i]] = true
+	}
+	for _, column := range staffImportColumns {
+		if column != "clinic" && column != "working_days" && !present[column] {
+			return nil, apperrors.NewValidationError("file", "missing column: "+column)
+		}
+	}
+
+	rows := make([]staffImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		values := make(map[string]string, len(header))
+		empty := true
+		for j, value := range record {
+			if j >= len(header) {
+				break
+			}
+			values[header[j]] = strings.TrimSpace(unescapeSpreadsheetFormula(value))
+			if values[header[j]] != "" {
+				empty = false
+			}
+		}
+		if empty {
+			continue
+		}
+		// line numbers are 1-based and the header is line 1
+		rows = append(rows, staffImportRow{line: i + 2, values: values})
+	}
+	return rows, nil
+}
+
+func readCSVRecords(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	// spreadsheets set to a Turkish locale export with semicolons
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid CSV: " + err.Error())
+	}
+	return records, nil
+}
+
+func readXLSXRecords(data []byte) ([][]string, error) {
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid XLSX: " + err.Error())
+	}
+	defer func() { _ = file.Close() }()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+
+	rows, err := file.Rows(sheets[0])
+	if err != nil {
+		return nil, errors.New("invalid XLSX: " + err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records [][]string
+	for rows.Next() {
+		columns, err := rows.Columns()
+		if err != nil {
+			return nil, errors.New("invalid XLSX: " + err.Error())
+		}
+		records = append(records, columns)
+	}
+	if err := rows.Error(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid XLSX: " + err.Error())
+	}
+	return records, nil
+}
+
+// foldName lowercases with Turkish casing rules and treats dotless and dotted
+// i alike, so "İdari Personel", "IDARI PERSONEL" and "idari personel" all
+// compare equal.
+func foldName(value string) string {
+	return strings.ReplaceAll(strings.ToLowerSpecial(unicode.TurkishCase, strings.TrimSpace(value)), "ı", "i")
+}
+
+func foldKeys[V any](m map[string]V) map[string]V {
+	folded := make(map[string]V, len(m))
+	for key, value := range m {
+		folded[foldName(key)] = value
+	}
+	return folded
+}
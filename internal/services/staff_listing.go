@@ -0,0 +1,294 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStaffPageLimit  = 10
+	maxStaffPageLimit      = 100
+	unassignedClinicFilter = "unassigned"
+	allStatusesFilter      = "all"
+	staffNameDocument      = "staffs.first_name || ' ' || staffs.last_name"
+)
+
+// staffSortColumn is a column the staff listing may be sorted by. Only
+// non-nullable columns are allowed so keyset comparisons never meet NULLs.
+type staffSortColumn struct {
+	column string
+	value  func(staff *models.Staff) string
+	parse  func(value string) (interface{}, error)
+}
+
+var staffSortColumns = map[string]staffSortColumn{
+	"id":          {"staffs.id", func(s *models.Staff) string { return strconv.FormatUint(uint64(s.ID), 10) }, parseCursorUint},
+	"first_name":  {"staffs.first_name", func(s *models.Staff) string { return s.FirstName }, parseCursorString},
+	"last_name":   {"staffs.last_name", func(s *models.Staff) string { return s.LastName }, parseCursorString},
+	"national_id": {"staffs.national_id", func(s *models.Staff) string { return s.NationalID }, parseCursorString},
+	"created_at":  {"staffs.created_at", func(s *models.Staff) string { return s.CreatedAt.Format(time.RFC3339Nano) }, parseCursorTime},
+	"updated_at":  {"staffs.updated_at", func(s *models.Staff) string { return s.UpdatedAt.Format(time.RFC3339Nano) }, parseCursorTime},
+}
+
+type staffSortField struct {
+	staffSortColumn
+	desc bool
+}
+
+// staffCursor marks the row a page starts after (or before, when Backward is
+// set). Values hold that row's sort keys in the order of the sort fields.
+type staffCursor struct {
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// applyStaffFilters narrows a staffs query to the hospital and the filter
+// fields. Columns are qualified so the query can be joined with reference
+// tables.
+func applyStaffFilters(query *gorm.DB, filter *models.StaffFilterRequest, hospitalID uint) (*gorm.DB, error) {
+	if filter.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
+	query = query.Where("staffs.hospital_id = ?", hospitalID)
+	query = applyNameSearch(query, filter.Q, staffNameDocument, "staffs.national_id")
+
+	if filter.FirstName != "" {
+		query = query.Where("staffs.first_name ILIKE ?", "%"+filter.FirstName+"%")
+	}
+
+	if filter.LastName != "" {
+		query = query.Where("staffs.last_name ILIKE ?", "%"+filter.LastName+"%")
+	}
+
+	if filter.NationalID != "" {
+		query = query.Where("staffs.national_id ILIKE ?", "%"+filter.NationalID+"%")
+	}
+
+	if filter.ProfessionGroupID != 0 {
+		query = query.Where("staffs.profession_group_id = ?", filter.ProfessionGroupID)
+	}
+
+	if filter.TitleID != 0 {
+		query = query.Where("staffs.title_id = ?", filter.TitleID)
+	}
+
+	clinicID, unassigned, err := parseClinicFilter(filter.ClinicID)
+	if err != nil {
+		return nil, err
+	}
+	if unassigned {
+		query = query.Where("staffs.clinic_id IS NULL")
+	} else if clinicID != 0 {
+		query = query.Where("staffs.clinic_id = ?", clinicID)
+	}
+
+	if filter.WorkingDay != "" {
+		days, _ := json.Marshal([]models.WorkingDay{filter.WorkingDay})
+		query = query.Where("staffs.working_days::jsonb @> ?::jsonb", string(days))
+	}
+
+	statuses, err := parseStatusFilter(filter.Status)
+	if err != nil {
+		return nil, err
+	}
+	if statuses != nil {
+		if len(statuses) > 0 {
+			query = query.Where("staffs.status IN ?", statuses)
+		}
+	} else {
+		// terminated staff stay on record for reporting but are only listed
+		// when asked for
+		query = query.Where("staffs.status <> ?", models.StaffTerminated)
+	}
+
+	ranges := []struct {
+		field, column, value string
+		upper                bool
+	}{
+		{"created_from", "staffs.created_at", filter.CreatedFrom, false},
+		{"created_to", "staffs.created_at", filter.CreatedTo, true},
+		{"updated_from", "staffs.updated_at", filter.UpdatedFrom, false},
+		{"updated_to", "staffs.updated_at", filter.UpdatedTo, true},
+	}
+	for _, r := range ranges {
+		if r.value == "" {
+			continue
+		}
+		start, end, err := parseAsOf(r.value)
+		if err != nil {
+			return nil, apperrors.NewValidationError(r.field, err.Error())
+		}
+		// a date bound covers the whole day on both ends of the range
+		if r.upper {
+			query = query.Where(r.column+" < ?", end)
+		} else {
+			query = query.Where(r.column+" >= ?", start)
+		}
+	}
+
+	return query, nil
+}
+
+// parseClinicFilter reads the clinic_id filter, which is either a clinic ID
+// or "unassigned" for staff without a clinic.
+func parseClinicFilter(value string) (uint, bool, error) {
+	if value == "" {
+		return 0, false, nil
+	}
+	if value == unassignedClinicFilter {
+		return 0, true, nil
+	}
+
+	clinicID, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false, apperrors.NewValidationError("clinic_id", `must be a clinic ID or "unassigned"`)
+	}
+	return uint(clinicID), false, nil
+}
+
+// parseStatusFilter parses a comma separated list of staff statuses. It
+// returns nil for an empty filter and an empty slice for "all".
+func parseStatusFilter(value string) ([]models.StaffStatus, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if value == allStatusesFilter {
+		return []models.StaffStatus{}, nil
+	}
+
+	var statuses []models.StaffStatus
+	for _, part := range strings.Split(value, ",") {
+		status := models.StaffStatus(strings.TrimSpace(part))
+		if _, ok := staffStatusTransitions[status]; !ok {
+			return nil, apperrors.NewValidationError("status", "must be onboarding, active, on_leave, terminated or all")
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// parseStaffSort reads a comma separated sort such as "last_name,-created_at".
+// The ID is always appended as the last key so every row has a unique
+// position, which keeps pages deterministic and cursors unambiguous.
+func parseStaffSort(raw string) ([]staffSortField, error) {
+	var fields []staffSortField
+	seen := make(map[string]bool)
+
+	for _, key := range strings.Split(raw, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+
+		column, ok := staffSortColumns[key]
+		if !ok {
+			return nil, apperrors.NewValidationError("sort", "cannot sort by "+strconv.Quote(key))
+		}
+		if seen[key] {
+			return nil, apperrors.NewValidationError("sort", strconv.Quote(key)+" is listed more than once")
+		}
+		seen[key] = true
+		fields = append(fields, staffSortField{staffSortColumn: column, desc: desc})
+	}
+
+	if !seen["id"] {
+		fields = append(fields, staffSortField{staffSortColumn: staffSortColumns["id"]})
+	}
+	return fields, nil
+}
+
+func staffSortOrder(fields []staffSortField, backward bool) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		direction := "ASC"
+		if field.desc != backward {
+			direction = "DESC"
+		}
+		parts[i] = field.column + " " + direction
+	}
+	return strings.Join(parts, ", ")
+}
+
+// staffKeysetCondition selects the rows after the cursor in sort order, or
+// before it for a backward cursor. Sort directions may differ per field, so
+// the comparison is expanded instead of using a row constructor.
+func staffKeysetCondition(fields []staffSortField, cursor *staffCursor) (string, []interface{}, error) {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		value, err := field.parse(cursor.Values[i])
+		if err != nil {
+			return "", nil, apperrors.NewValidationError("cursor", "invalid cursor")
+		}
+		values[i] = value
+	}
+
+	var branches []string
+	var vars []interface{}
+	for i, field := range fields {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fields[j].column+" = ?")
+			vars = append(vars, values[j])
+		}
+
+		operator := ">"
+		if field.desc != cursor.Backward {
+			operator = "<"
+		}
+		terms = append(terms, field.column+" "+operator+" ?")
+		vars = append(vars, values[i])
+
+		branches = append(branches, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return "(" + strings.Join(branches, " OR ") + ")", vars, nil
+}
+
+func encodeStaffCursor(fields []staffSortField, staff *models.Staff, backward bool) string {
+	cursor := staffCursor{Values: make([]string, len(fields)), Backward: backward}
+	for i, field := range fields {
+		cursor.Values[i] = field.value(staff)
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeStaffCursor(raw string, fields []staffSortField) (*staffCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, apperrors.NewValidationError("cursor", "invalid cursor")
+	}
+
+	var cursor staffCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, apperrors.NewValidationError("cursor", "invalid cursor")
+	}
+	if len(cursor.Values) != len(fields) {
+		return nil, apperrors.NewValidationError("cursor", "cursor does not match the requested sort")
+	}
+	return &cursor, nil
+}
+
+func parseCursorString(value string) (interface{}, error) {
+	return value, nil
+}
+
+func parseCursorUint(value string) (interface{}, error) {
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func parseCursorTime(value string) (interface{}, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
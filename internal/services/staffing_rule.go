@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+type StaffingRuleService struct {
+	db *gorm.DB
+}
+
+func NewStaffingRuleService(db *gorm.DB) *StaffingRuleService {
+	return &StaffingRuleService{
+		db: db,
+	}
+}
+
+func (s *StaffingRuleService) WithContext(ctx context.Context) *StaffingRuleService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+// GetRules returns the platform defaults followed by the hospital's own rules.
+func (s *StaffingRuleService) GetRules(hospitalID uint) ([]models.StaffingRule, error) {
+	var rules []models.StaffingRule
+	err := s.db.Where("hospital_id = ? OR hospital_id IS NULL", hospitalID).
+		Preload("Title").Preload("ProfessionGroup").Preload("Clinic").
+		Order("hospital_id NULLS FIRST, id").
+		Find(&rules).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get staffing rules", err)
+	}
+	return rules, nil
+}
+
+func (s *StaffingRuleService) CreateRule(req *models.StaffingRuleRequest, hospitalID uint) (*models.StaffingRule, error) {
+	rule := &models.StaffingRule{
+		HospitalID: &hospitalID,
+		Enabled:    true,
+	}
+	if err := s.applyRuleRequest(rule, req, hospitalID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(rule).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create staffing rule", err)
+	}
+	return s.getRule(rule.ID, hospitalID)
+}
+
+func (s *StaffingRuleService) UpdateRule(ruleID uint, req *models.StaffingRuleRequest, hospitalID uint) (*models.StaffingRule, error) {
+	rule, err := s.getRule(ruleID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRuleRequest(rule, req, hospitalID); err != nil {
+		return nil, err
+	}
+
+	// clear the preloaded associations so Save does not upsert them
+	rule.Title, rule.ProfessionGroup, rule.Clinic = nil, nil, nil
+	if err := s.db.Save(rule).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update staffing rule", err)
+	}
+	return s.getRule(rule.ID, hospitalID)
+}
+
+func (s *StaffingRuleService) DeleteRule(ruleID uint, hospitalID uint) error {
+	rule, err := s.getRule(ruleID, hospitalID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(rule).Error; err != nil {
+		return apperrors.NewDatabaseError("delete staffing rule", err)
+	}
+	return nil
+}
+
+// getRule finds a rule owned by the hospital. Platform defaults are not
+// returned, so hospital users cannot change them.
+func (s *StaffingRuleService) getRule(ruleID, hospitalID uint) (*models.StaffingRule, error) {
+	var rule models.StaffingRule
+	err := s.db.Where("id = ? AND hospital_id = ?", ruleID, hospitalID).
+		Preload("Title").Preload("ProfessionGroup").Preload("Clinic").
+		First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("staffing rule", ruleID)
+		}
+		return nil, apperrors.NewDatabaseError("find staffing rule", err)
+	}
+	return &rule, nil
+}
+
+func (s *StaffingRuleService) applyRuleRequest(rule *models.StaffingRule, req *models.StaffingRuleRequest, hospitalID uint) error {
+	scope := req.Scope
+	if scope == "" {
+		scope = models.StaffingRuleScopeHospital
+	}
+
+	switch req.Type {
+	case models.StaffingRuleMaxTitleCount:
+		if req.TitleID == nil {
+			return apperrors.NewValidationError("title_id", "is required for max_title_count rules")
+		}
+		if req.ClinicID != nil && scope != models.StaffingRuleScopeClinic {
+			return apperrors.NewValidationError("clinic_id", "requires the clinic scope")
+		}
+	case models.StaffingRuleMinProfessionCount:
+		if req.ProfessionGroupID == nil {
+			return apperrors.NewValidationError("profession_group_id", "is required for min_profession_count rules")
+		}
+		if req.Threshold < 1 {
+			return apperrors.NewValidationError("threshold", "must be at least 1 for min_profession_count rules")
+		}
+		// a minimum only makes sense per clinic; hospital-wide minimums would
+		// block every deletion of a hospital that has not reached them yet
+		scope = models.StaffingRuleScopeClinic
+	case models.StaffingRuleTitleRequiresClinic:
+		if req.TitleID == nil {
+			return apperrors.NewValidationError("title_id", "is required for title_requires_clinic rules")
+		}
+		if req.ClinicID != nil {
+			return apperrors.NewValidationError("clinic_id", "is not supported for title_requires_clinic rules")
+		}
+		scope = models.StaffingRuleScopeHospital
+	}
+
+	if req.TitleID != nil {
+		var count int64
+		if err := s.db.Model(&models.Title{}).Scopes(visibleCatalog(hospitalID)).Where("id = ?", *req.TitleID).Count(&count).Error; err != nil {
+			return apperrors.NewDatabaseError("find title", err)
+		}
+		if count == 0 {
+			return apperrors.NewValidationError("title_id", "title not found")
+		}
+	}
+	if req.ProfessionGroupID != nil {
+		var count int64
+		if err := s.db.Model(&models.ProfessionGroup{}).Scopes(visibleCatalog(hospitalID)).Where("id = ?", *req.ProfessionGroupID).Count(&count).Error; err != nil {
+			return apperrors.NewDatabaseError("find profession group", err)
+		}
+		if count == 0 {
+			return apperrors.NewValidationError("profession_group_id", "profession group not found")
+		}
+	}
+	if req.ClinicID != nil {
+		var count int64
+		err := s.db.Model(&models.Clinic{}).Where("id = ? AND hospital_id = ?", *req.ClinicID, hospitalID).Count(&count).Error
+		if err != nil {
+			return apperrors.NewDatabaseError("find clinic", err)
+		}
+		if count == 0 {
+			return apperrors.NewValidationError("clinic_id", "clinic not found or does not belong to hospital")
+		}
+	}
+
+	rule.Type = req.Type
+	rule.Scope = scope
+	rule.TitleID = req.TitleID
+	rule.ProfessionGroupID = req.ProfessionGroupID
+	rule.ClinicID = req.ClinicID
+	rule.Threshold = req.Threshold
+	rule.Description = req.Description
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	return nil
+}
+
+// evaluateStaffingRules checks the enabled rules of the staff member's
+// hospital after a mutation. before is nil for a new staff member and after is
+// nil for a deleted one. tx must already contain the change, so the counts
+// include it; a rule is only checked when the change can affect it, which
+// keeps existing violations from blocking unrelated edits.
+func evaluateStaffingRules(tx *gorm.DB, before, after *models.Staff) error {
+	staff := after
+	if staff == nil {
+		staff = before
+	}
+	hospitalID := staff.HospitalID
+
+	// terminated staff no longer count towards any rule, so termination is
+	// checked like a deletion and rehiring like a new hire
+	if before != nil && before.Status == models.StaffTerminated {
+		before = nil
+	}
+	if after != nil && after.Status == models.StaffTerminated {
+		after = nil
+	}
+	if before == nil && after == nil {
+		return nil
+	}
+
+	var rules []models.StaffingRule
+	err := tx.Where("(hospital_id = ? OR hospital_id IS NULL) AND enabled = ?", hospitalID, true).
+		Preload("Title").Preload("ProfessionGroup").
+		Order("id").
+		Find(&rules).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("load staffing rules", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// serialize the checks per hospital so two concurrent mutations cannot
+	// both pass a count that only one of them would have passed
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('staffing_rules'), ?::int)", hospitalID).Error; err != nil {
+		return apperrors.NewDatabaseError("lock staffing rules", err)
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		var err error
+		switch rule.Type {
+		case models.StaffingRuleMaxTitleCount:
+			err = checkMaxTitleCount(tx, rule, hospitalID, before, after)
+		case models.StaffingRuleMinProfessionCount:
+			err = checkMinProfessionCount(tx, rule, hospitalID, before, after)
+		case models.StaffingRuleTitleRequiresClinic:
+			err = checkTitleRequiresClinic(rule, before, after)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkMaxTitleCount(tx *gorm.DB, rule *models.StaffingRule, hospitalID uint, before, after *models.Staff) error {
+	if after == nil || rule.TitleID == nil || after.TitleID != *rule.TitleID {
+		return nil
+	}
+	titleGained := before == nil || before.TitleID != *rule.TitleID
+
+	query := tx.Model(&models.Staff{}).
+		Where("hospital_id = ? AND title_id = ? AND status <> ?", hospitalID, *rule.TitleID, models.StaffTerminated)
+	if rule.Scope == models.StaffingRuleScopeClinic {
+		if after.ClinicID == nil || (rule.ClinicID != nil && *rule.ClinicID != *after.ClinicID) {
+			return nil
+		}
+		if !titleGained && sameClinic(before.ClinicID, after.ClinicID) {
+			return nil
+		}
+		query = query.Where("clinic_id = ?", *after.ClinicID)
+	} else if !titleGained {
+		return nil
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperrors.NewDatabaseError("count staff by title", err)
+	}
+	if count <= int64(rule.Threshold) {
+		return nil
+	}
+
+	message := fmt.Sprintf("a hospital can have at most %d staff with the title %s", rule.Threshold, ruleTitleName(rule))
+	if rule.Scope == models.StaffingRuleScopeClinic {
+		message = fmt.Sprintf("a clinic can have at most %d staff with the title %s", rule.Threshold, ruleTitleName(rule))
+	}
+	return staffingRuleViolation(rule, message, map[string]interface{}{
+		"title_id":  *rule.TitleID,
+		"clinic_id": after.ClinicID,
+		"count":     count,
+	})
+}
+
+func checkMinProfessionCount(tx *gorm.DB, rule *models.StaffingRule, hospitalID uint, before, after *models.Staff) error {
+	if before == nil || before.ClinicID == nil || rule.ProfessionGroupID == nil {
+		return nil
+	}
+	if before.ProfessionGroupID != *rule.ProfessionGroupID {
+		return nil
+	}
+	if rule.ClinicID != nil && *rule.ClinicID != *before.ClinicID {
+		return nil
+	}
+	if after != nil && after.ProfessionGroupID == before.ProfessionGroupID && sameClinic(before.ClinicID, after.ClinicID) {
+		return nil
+	}
+
+	var count int64
+	err := tx.Model(&models.Staff{}).
+		Where("hospital_id = ? AND clinic_id = ? AND profession_group_id = ? AND status <> ?",
+			hospitalID, *before.ClinicID, *rule.ProfessionGroupID, models.StaffTerminated).
+		Count(&count).Error
+	if err != nil {
+		return apperrors.NewDatabaseError("count staff by profession group", err)
+	}
+	// a clinic that never reached the minimum is still being staffed, only
+	// dropping below it is a violation
+	if count >= int64(rule.Threshold) || count+1 < int64(rule.Threshold) {
+		return nil
+	}
+
+	professionGroup := fmt.Sprint(*rule.ProfessionGroupID)
+	if rule.ProfessionGroup != nil {
+		professionGroup = rule.ProfessionGroup.Name
+	}
+	return staffingRuleViolation(rule,
+		fmt.Sprintf("a clinic must keep at least %d staff in the profession group %s", rule.Threshold, professionGroup),
+		map[string]interface{}{
+			"profession_group_id": *rule.ProfessionGroupID,
+			"clinic_id":           *before.ClinicID,
+			"count":               count,
+		})
+}
+
+func checkTitleRequiresClinic(rule *models.StaffingRule, before, after *models.Staff) error {
+	if after == nil || rule.TitleID == nil || after.TitleID != *rule.TitleID || after.ClinicID != nil {
+		return nil
+	}
+	if before != nil && before.TitleID == after.TitleID && before.ClinicID == nil {
+		return nil
+	}
+	return staffingRuleViolation(rule,
+		fmt.Sprintf("staff with the title %s must be assigned to a clinic", ruleTitleName(rule)),
+		map[string]interface{}{
+			"title_id": *rule.TitleID,
+		})
+}
+
+func staffingRuleViolation(rule *models.StaffingRule, message string, context map[string]interface{}) error {
+	if rule.Description != "" {
+		message = rule.Description
+	}
+	context["rule_id"] = rule.ID
+	context["rule_type"] = rule.Type
+	context["threshold"] = rule.Threshold
+	return apperrors.NewBusinessRuleError(message, context)
+}
+
+func ruleTitleName(rule *models.StaffingRule) string {
+	if rule.Title != nil {
+		return rule.Title.Name
+	}
+	return fmt.Sprint(*rule.TitleID)
+}
@@ -0,0 +1,457 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	trashPurgeBatchSize = 100
+	pgUniqueViolation   = "23505"
+)
+
+// TrashService lists, restores and eventually erases soft-deleted hospitals,
+// users, clinics and staff.
+type TrashService struct {
+	db                     *gorm.DB
+	retention              models.TrashRetention
+	blockExpiredCredential bool
+}
+
+func NewTrashService(db *gorm.DB, cfg *config.Config) *TrashService {
+	return &TrashService{
+		db: db,
+		retention: models.TrashRetention{
+			StaffDays:    max(cfg.Trash.StaffRetentionDays, 0),
+			UserDays:     max(cfg.Trash.UserRetentionDays, 0),
+			ClinicDays:   max(cfg.Trash.ClinicRetentionDays, 0),
+			HospitalDays: max(cfg.Trash.HospitalRetentionDays, 0),
+		},
+		blockExpiredCredential: cfg.Credentials.BlockExpiredAssignment,
+	}
+}
+
+func (s *TrashService) WithContext(ctx context.Context) *TrashService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+func (s *TrashService) Retention() models.TrashRetention {
+	return s.retention
+}
+
+func (s *TrashService) GetDeletedStaff(hospitalID uint) ([]models.Staff, error) {
+	var staff []models.Staff
+	err := s.db.Unscoped().
+		Where("hospital_id = ? AND deleted_at IS NOT NULL", hospitalID).
+		Preload("ProfessionGroup").Preload("Title").
+		Order("deleted_at DESC").
+		Find(&staff).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get deleted staff", err)
+	}
+	return staff, nil
+}
+
+func (s *TrashService) GetDeletedUsers(hospitalID uint) ([]models.User, error) {
+	var users []models.User
+	err := s.db.Unscoped().
+		Where("hospital_id = ? AND deleted_at IS NOT NULL", hospitalID).
+		Order("deleted_at DESC").
+		Find(&users).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get deleted users", err)
+	}
+	return users, nil
+}
+
+func (s *TrashService) GetDeletedClinics(hospitalID uint) ([]models.Clinic, error) {
+	var clinics []models.Clinic
+	err := s.db.Unscoped().
+		Where("hospital_id = ? AND deleted_at IS NOT NULL", hospitalID).
+		Preload("ClinicType").
+		Order("deleted_at DESC").
+		Find(&clinics).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get deleted clinics", err)
+	}
+	return clinics, nil
+}
+
+func (s *TrashService) GetDeletedHospitals() ([]models.Hospital, error) {
+	var hospitals []models.Hospital
+	err := s.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Preload("Province").Preload("District").
+		Order("deleted_at DESC").
+		Find(&hospitals).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get deleted hospitals", err)
+	}
+	return hospitals, nil
+}
+
+// RestoreStaff brings a deleted staff member back. Their national ID and
+// phone must still be free, and they return to their last clinic only if that
+// clinic still exists and their credentials allow the assignment.
+func (s *TrashService) RestoreStaff(staffID, hospitalID, userID uint) (*models.Staff, error) {
+	var staff models.Staff
+	if err := s.findDeleted(&staff, "staff", staffID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	if staff.ClinicID != nil {
+		var count int64
+		if err := s.db.Model(&models.Clinic{}).Where("id = ?", *staff.ClinicID).Count(&count).Error; err != nil {
+			return nil, apperrors.NewDatabaseError("find clinic", err)
+		}
+		if count == 0 {
+			staff.ClinicID = nil
+		}
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkFree(tx, &models.Staff{}, "national_id", staff.NationalID, apperrors.NewDuplicateNationalIDError(staff.NationalID)); err != nil {
+			return err
+		}
+		if err := checkFree(tx, &models.Staff{}, "phone", staff.Phone, apperrors.NewDuplicatePhoneError(staff.Phone)); err != nil {
+			return err
+		}
+
+		err := tx.Unscoped().Model(&models.Staff{}).Where("id = ?", staff.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "clinic_id": staff.ClinicID}).Error
+		if err != nil {
+			// a staff member created with the same value after the check above
+			// trips the unique index instead
+			switch uniqueViolation(err) {
+			case "idx_staffs_national_id":
+				return apperrors.NewDuplicateNationalIDError(staff.NationalID)
+			case "idx_staffs_phone":
+				return apperrors.NewDuplicatePhoneError(staff.Phone)
+			}
+			return apperrors.NewDatabaseError("restore staff", err)
+		}
+		if err := recordStaffVersion(tx, staff.ID, time.Now()); err != nil {
+			return apperrors.NewDatabaseError("record staff version", err)
+		}
+		if err := publishStaffEvent(tx, models.WebhookStaffRestored, staff.ID, nil); err != nil {
+			return apperrors.NewDatabaseError("publish staff event", err)
+		}
+		if staff.ClinicID != nil {
+			if err := checkCredentialsForAssignment(tx, staff.ID, s.blockExpiredCredential); err != nil {
+				return err
+			}
+			if err := moveClinicAssignment(tx, &staff, staff.ClinicID, time.Now(), "restored from trash", &userID); err != nil {
+				return apperrors.NewDatabaseError("reopen clinic assignment", err)
+			}
+		}
+		return evaluateStaffingRules(tx, nil, &staff)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var restored models.Staff
+	err = s.db.Preload("ProfessionGroup").Preload("Title").
+		Preload("Hospital").Preload("Clinic.ClinicType").
+		First(&restored, staff.ID).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("find staff", err)
+	}
+	return &restored, nil
+}
+
+func (s *TrashService) RestoreUser(userID, hospitalID uint) (*models.User, error) {
+	var user models.User
+	if err := s.findDeleted(&user, "user", userID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	if err := checkFree(s.db, &models.User{}, "national_id", user.NationalID, apperrors.NewDuplicateNationalIDError(user.NationalID)); err != nil {
+		return nil, err
+	}
+	if err := checkFree(s.db, &models.User{}, "email", user.Email, apperrors.NewDuplicateEmailError(user.Email)); err != nil {
+		return nil, err
+	}
+	if err := checkFree(s.db, &models.User{}, "phone", user.Phone, apperrors.NewDuplicatePhoneError(user.Phone)); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Unscoped().Model(&user).Update("deleted_at", nil).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("restore user", err)
+	}
+
+	var restored models.User
+	if err := s.db.Preload("Hospital").Preload("CreatedBy").First(&restored, user.ID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find user", err)
+	}
+	return &restored, nil
+}
+
+func (s *TrashService) RestoreClinic(clinicID, hospitalID uint) (*models.Clinic, error) {
+	var clinic models.Clinic
+	if err := s.findDeleted(&clinic, "clinic", clinicID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	err := s.db.Model(&models.Clinic{}).
+		Where("hospital_id = ? AND clinic_type_id = ?", hospitalID, clinic.ClinicTypeID).
+		Count(&count).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("check clinic uniqueness", err)
+	}
+	if count > 0 {
+		return nil, apperrors.NewBusinessRuleError("clinic type already exists for this hospital", map[string]interface{}{
+			"clinic_id":      clinic.ID,
+			"clinic_type_id": clinic.ClinicTypeID,
+		})
+	}
+
+	if err := s.db.Unscoped().Model(&clinic).Update("deleted_at", nil).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("restore clinic", err)
+	}
+
+	var restored models.Clinic
+	if err := s.db.Preload("Hospital").Preload("ClinicType").First(&restored, clinic.ID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find clinic", err)
+	}
+	return &restored, nil
+}
+
+func (s *TrashService) RestoreHospital(hospitalID uint) (*models.Hospital, error) {
+	var hospital models.Hospital
+	err := s.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", hospitalID).First(&hospital).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("deleted hospital", hospitalID)
+		}
+		return nil, apperrors.NewDatabaseError("find deleted hospital", err)
+	}
+
+	if err := checkFree(s.db, &models.Hospital{}, "tax_id", hospital.TaxID, apperrors.NewDuplicateTaxIDError(hospital.TaxID)); err != nil {
+		return nil, err
+	}
+	if err := checkFree(s.db, &models.Hospital{}, "email", hospital.Email, apperrors.NewConflictError("email", hospital.Email, "hospital")); err != nil {
+		return nil, err
+	}
+	if err := checkFree(s.db, &models.Hospital{}, "phone", hospital.Phone, apperrors.NewConflictError("phone", hospital.Phone, "hospital")); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Unscoped().Model(&hospital).Update("deleted_at", nil).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("restore hospital", err)
+	}
+
+	var restored models.Hospital
+	if err := s.db.Preload("Province").Preload("District").First(&restored, hospital.ID).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find hospital", err)
+	}
+	return &restored, nil
+}
+
+// Purge permanently erases records that were deleted longer ago than their
+// retention, together with the rows that only exist for them. Hospitals go
+// first since erasing one takes all of its data with it. A clinic stays in
+// the trash while staff that still exist have assignment history in it, so
+// their history is never cut short; it goes once those staff are erased,
+// which is why staff go before clinics.
+func (s *TrashService) Purge(ctx context.Context, now time.Time) (*models.TrashPurgeResult, error) {
+	result := &models.TrashPurgeResult{}
+	steps := []struct {
+		table   string
+		days    int
+		keep    string
+		purge   func(tx *gorm.DB, ids []uint) error
+		counter *int64
+	}{
+		{"hospitals", s.retention.HospitalDays, "", purgeHospitals, &result.Hospitals},
+		{"users", s.retention.UserDays, "", purgeUsers, &result.Users},
+		{"staffs", s.retention.StaffDays, "", purgeStaff, &result.Staff},
+		{"clinics", s.retention.ClinicDays, "EXISTS (SELECT 1 FROM staff_clinic_assignments a WHERE a.clinic_id = clinics.id)", purgeClinics, &result.Clinics},
+	}
+
+	for _, step := range steps {
+		if step.days == 0 {
+			continue
+		}
+		cutoff := now.AddDate(0, 0, -step.days)
+
+		for {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			var ids []uint
+			query := s.db.Table(step.table).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+			if step.keep != "" {
+				query = query.Where("NOT (" + step.keep + ")")
+			}
+			err := query.Order("id").Limit(trashPurgeBatchSize).Pluck("id", &ids).Error
+			if err != nil {
+				return result, apperrors.NewDatabaseError("find expired "+step.table, err)
+			}
+			if len(ids) == 0 {
+				break
+			}
+
+			if err := s.db.Transaction(func(tx *gorm.DB) error { return step.purge(tx, ids) }); err != nil {
+				return result, apperrors.NewDatabaseError("purge "+step.table, err)
+			}
+			*step.counter += int64(len(ids))
+		}
+	}
+
+	return result, nil
+}
+
+// findDeleted loads a soft-deleted row of the hospital into dest.
+func (s *TrashService) findDeleted(dest interface{}, resource string, id, hospitalID uint) error {
+	err := s.db.Unscoped().Where("id = ? AND hospital_id = ? AND deleted_at IS NOT NULL", id, hospitalID).First(dest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewNotFoundError("deleted "+resource, id)
+		}
+		return apperrors.NewDatabaseError("find deleted "+resource, err)
+	}
+	return nil
+}
+
+// uniqueViolation returns the name of the unique index err violated, or ""
+// for any other error.
+func uniqueViolation(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
+
+// checkFree returns conflict when a live row of the model already uses value.
+func checkFree(db *gorm.DB, model interface{}, column, value string, conflict error) error {
+	var count int64
+	if err := db.Model(model).Where(column+" = ?", value).Count(&count).Error; err != nil {
+		return apperrors.NewDatabaseError("check "+column+" uniqueness", err)
+	}
+	if count > 0 {
+		return conflict
+	}
+	return nil
+}
+
+func purgeStaff(tx *gorm.DB, ids []uint) error {
+	for _, model := range []interface{}{
+		&models.StaffCredential{},
+		&models.StaffClinicAssignment{},
+		&models.AttendanceEvent{},
+		&models.AttendanceDay{},
+		&models.StaffEmployment{},
+		&models.StaffStatusChange{},
+		&models.StaffVersion{},
+	} {
+		if err := tx.Unscoped().Where("staff_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Staff{}).Error
+}
+
+func purgeUsers(tx *gorm.DB, ids []uint) error {
+	references := []struct {
+		model  interface{}
+		column string
+	}{
+		{&models.User{}, "created_by_id"},
+		{&models.StaffClinicAssignment{}, "created_by_id"},
+		{&models.StaffStatusChange{}, "created_by_id"},
+		{&models.AttendanceEvent{}, "recorded_by_id"},
+	}
+	for _, ref := range references {
+		err := tx.Unscoped().Model(ref.model).Where(ref.column+" IN ?", ids).Update(ref.column, nil).Error
+		if err != nil {
+			return err
+		}
+	}
+	if err := tx.Unscoped().Where("created_by_id IN ?", ids).Delete(&models.StaffImportJob{}).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&models.UserNotification{}, &models.NotificationPreference{}} {
+		if err := tx.Where("user_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.User{}).Error
+}
+
+// purgeClinics erases clinics without assignment history; Purge leaves the
+// others in the trash.
+func purgeClinics(tx *gorm.DB, ids []uint) error {
+	// only deleted or terminated staff can still point at a deleted clinic
+	if err := tx.Unscoped().Model(&models.Staff{}).Where("clinic_id IN ?", ids).Update("clinic_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("clinic_id IN ?", ids).Delete(&models.StaffingRule{}).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&models.ClinicRoom{}, &models.ClinicOpeningHours{}, &models.ClinicClosure{}} {
+		if err := tx.Unscoped().Where("clinic_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Clinic{}).Error
+}
+
+func purgeHospitals(tx *gorm.DB, ids []uint) error {
+	for _, model := range []interface{}{
+		&models.StaffCredential{},
+		&models.StaffClinicAssignment{},
+		&models.AttendanceEvent{},
+		&models.AttendanceDay{},
+		&models.AttendanceKiosk{},
+		&models.StaffEmployment{},
+		&models.StaffStatusChange{},
+		&models.StaffVersion{},
+		&models.StaffImportJob{},
+		&models.WebhookDelivery{},
+		&models.WebhookEvent{},
+		&models.WebhookSubscription{},
+		&models.UserNotification{},
+		&models.StaffingRule{},
+		&models.ClinicRoom{},
+		&models.ClinicOpeningHours{},
+		&models.ClinicClosure{},
+		&models.HospitalHoliday{},
+		&models.HeadcountSnapshot{},
+		&models.Staff{},
+		&models.Clinic{},
+		&models.Title{},
+		&models.ProfessionGroup{},
+		&models.ClinicType{},
+	} {
+		if err := tx.Unscoped().Where("hospital_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	err := tx.Where("user_id IN (?)", tx.Unscoped().Model(&models.User{}).Select("id").Where("hospital_id IN ?", ids)).
+		Delete(&models.NotificationPreference{}).Error
+	if err != nil {
+		return err
+	}
+	// users of the hospital reference each other through created_by_id
+	err = tx.Unscoped().Model(&models.User{}).Where("hospital_id IN ?", ids).Update("created_by_id", nil).Error
+	if err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("hospital_id IN ?", ids).Delete(&models.User{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Hospital{}).Error
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 
 	userErrors "github.com/caner-cetin/hospital-tracker/internal/errors"
@@ -20,6 +21,12 @@ func NewUserService(db *gorm.DB, authService *AuthService) *UserService {
 	}
 }
 
+func (s *UserService) WithContext(ctx context.Context) *UserService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
 func (s *UserService) CreateUser(req *models.CreateUserRequest, createdByID uint, hospitalID uint) (*models.User, error) {
 	if err := s.validateUserUniqueness(req.NationalID, req.Email, req.Phone); err != nil {
 		return nil, err
@@ -121,9 +128,17 @@ func (s *UserService) DeleteUser(userID uint, hospitalID uint) error {
 	return nil
 }
 
-func (s *UserService) GetUsers(hospitalID uint) ([]models.User, error) {
+const userNameDocument = "users.first_name || ' ' || users.last_name"
+
+func (s *UserService) GetUsers(hospitalID uint, filter *models.UserFilterRequest) ([]models.User, error) {
+	query := s.db.Where("users.hospital_id = ?", hospitalID)
+	if filter != nil {
+		query = applyNameSearch(query, filter.Q, userNameDocument, "users.email", "users.national_id")
+		query = orderByNameRank(query, filter.Q, userNameDocument, "users.id")
+	}
+
 	var users []models.User
-	err := s.db.Where("hospital_id = ?", hospitalID).
+	err := query.
 		Preload("Hospital").
 		Preload("CreatedBy").
 		Find(&users).Error
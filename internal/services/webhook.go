@@ -0,0 +1,734 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultWebhookDeliveryInterval = 5 * time.Second
+	defaultWebhookMaxAttempts      = 8
+	defaultWebhookBackoff          = 30 * time.Second
+	defaultWebhookTimeout          = 10 * time.Second
+	maxWebhookBackoff              = 6 * time.Hour
+	webhookDeliveryBatch           = 50
+	defaultWebhookPageLimit        = 20
+	maxWebhookPageLimit            = 100
+	maxWebhookReplayEvents         = 1000
+	maxWebhookErrorLength          = 500
+)
+
+// blockedWebhookPrefixes are the ranges outside the private, loopback and
+// link-local ones that net.IP already knows about, which still never lead
+// to a public receiver.
+var blockedWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+var errWebhookPrivateTarget = errors.New("webhook target is a private, loopback or link-local address")
+
+type WebhookService struct {
+	db           *gorm.DB
+	client       *http.Client
+	interval     time.Duration
+	maxAttempts  int
+	backoff      time.Duration
+	allowPrivate bool
+}
+
+func NewWebhookService(db *gorm.DB, cfg *config.Config) *WebhookService {
+	interval := time.Duration(cfg.Webhooks.DeliveryIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultWebhookDeliveryInterval
+	}
+	maxAttempts := cfg.Webhooks.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	backoff := time.Duration(cfg.Webhooks.BackoffSeconds) * time.Second
+	if backoff <= 0 {
+		backoff = defaultWebhookBackoff
+	}
+	timeout := time.Duration(cfg.Webhooks.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &WebhookService{
+		db:           db,
+		client:       newWebhookClient(timeout, cfg.Webhooks.AllowPrivateTargets),
+		interval:     interval,
+		maxAttempts:  maxAttempts,
+		backoff:      backoff,
+		allowPrivate: cfg.Webhooks.AllowPrivateTargets,
+	}
+}
+
+// newWebhookClient returns a client that never follows redirects and, unless
+// allowPrivate is set, refuses to connect to internal addresses. The check
+// runs on the address actually dialled, after DNS resolution, so a receiver
+// hostname that later resolves to an internal address is refused as well.
+func newWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateWebhookIP(ip) {
+				return errWebhookPrivateTarget
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return errors.New("webhook receivers must not redirect")
+		},
+	}
+}
+
+func isPrivateWebhookIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return true
+	}
+	addr = addr.Unmap()
+	for _, prefix := range blockedWebhookPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *WebhookService) WithContext(ctx context.Context) *WebhookService {
+	clone := *s
+	clone.db = s.db.WithContext(ctx)
+	return &clone
+}
+
+func (s *WebhookService) GetSubscriptions(hospitalID uint) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := s.db.Where("hospital_id = ?", hospitalID).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("get webhook subscriptions", err)
+	}
+	return subscriptions, nil
+}
+
+func (s *WebhookService) CreateSubscription(req *models.CreateWebhookRequest, hospitalID uint) (*models.WebhookSecretResponse, error) {
+	if err := s.validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, apperrors.NewInternalError("generate webhook secret", err)
+	}
+
+	subscription := &models.WebhookSubscription{
+		HospitalID:  hospitalID,
+		URL:         req.URL,
+		Secret:      secret,
+		EventTypes:  joinWebhookEventTypes(req.EventTypes),
+		Description: req.Description,
+		Active:      true,
+	}
+	if err := s.db.Create(subscription).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("create webhook subscription", err)
+	}
+
+	return &models.WebhookSecretResponse{Subscription: *subscription, Secret: secret}, nil
+}
+
+func (s *WebhookService) UpdateSubscription(subscriptionID uint, req *models.UpdateWebhookRequest, hospitalID uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.getSubscription(subscriptionID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != "" {
+		if err := s.validateWebhookURL(req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = req.URL
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = joinWebhookEventTypes(req.EventTypes)
+	}
+	if req.Description != nil {
+		subscription.Description = *req.Description
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := s.db.Save(subscription).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("update webhook subscription", err)
+	}
+	return subscription, nil
+}
+
+// RotateSecret replaces the signing secret of a subscription. Deliveries sent
+// from then on, including retries, are signed with the new secret.
+func (s *WebhookService) RotateSecret(subscriptionID, hospitalID uint) (*models.WebhookSecretResponse, error) {
+	subscription, err := s.getSubscription(subscriptionID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, apperrors.NewInternalError("generate webhook secret", err)
+	}
+	if err := s.db.Model(subscription).Update("secret", secret).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("rotate webhook secret", err)
+	}
+
+	return &models.WebhookSecretResponse{Subscription: *subscription, Secret: secret}, nil
+}
+
+// DeleteSubscription removes a subscription together with its deliveries
+// that are still pending.
+func (s *WebhookService) DeleteSubscription(subscriptionID, hospitalID uint) error {
+	subscription, err := s.getSubscription(subscriptionID, hospitalID)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("subscription_id = ? AND status = ?", subscription.ID, models.WebhookDeliveryPending).
+			Delete(&models.WebhookDelivery{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(subscription).Error
+	})
+	if err != nil {
+		return apperrors.NewDatabaseError("delete webhook subscription", err)
+	}
+	return nil
+}
+
+// GetDeliveries lists deliveries newest first. Filtering by the dead status
+// gives the dead-letter list.
+func (s *WebhookService) GetDeliveries(filter *models.WebhookDeliveryFilterRequest, hospitalID uint) (*models.WebhookDeliveryPaginatedResponse, error) {
+	query := s.db.Model(&models.WebhookDelivery{}).Where("hospital_id = ?", hospitalID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.SubscriptionID != 0 {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("count webhook deliveries", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultWebhookPageLimit
+	}
+	if filter.Limit > maxWebhookPageLimit {
+		filter.Limit = maxWebhookPageLimit
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.Preload("Event").
+		Order("id DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get webhook deliveries", err)
+	}
+
+	return &models.WebhookDeliveryPaginatedResponse{
+		Data: deliveries,
+		BasePagination: models.BasePagination{
+			TotalCount: totalCount,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: int(math.Ceil(float64(totalCount) / float64(filter.Limit))),
+		},
+	}, nil
+}
+
+// Replay queues the selected events again as new deliveries. Dead letters
+// of a replayed event and subscription are marked replayed.
+func (s *WebhookService) Replay(req *models.WebhookReplayRequest, hospitalID uint) (*models.WebhookReplayResponse, error) {
+	if len(req.EventIDs) == 0 && req.From == "" {
+		return nil, apperrors.NewValidationError("event_ids", "give event IDs or a from time")
+	}
+
+	query := s.db.Where("hospital_id = ?", hospitalID)
+	if len(req.EventIDs) > 0 {
+		query = query.Where("id IN ?", req.EventIDs)
+	}
+	if req.From != "" {
+		start, _, err := parseAsOf(req.From)
+		if err != nil {
+			return nil, apperrors.NewValidationError("from", err.Error())
+		}
+		query = query.Where("created_at >= ?", start)
+	}
+	if req.To != "" {
+		_, end, err := parseAsOf(req.To)
+		if err != nil {
+			return nil, apperrors.NewValidationError("to", err.Error())
+		}
+		query = query.Where("created_at < ?", end)
+	}
+
+	var events []models.WebhookEvent
+	if err := query.Order("id").Limit(maxWebhookReplayEvents + 1).Find(&events).Error; err != nil {
+		return nil, apperrors.NewDatabaseError("find webhook events", err)
+	}
+	if len(events) > maxWebhookReplayEvents {
+		return nil, apperrors.NewValidationError("from", fmt.Sprintf("more than %d events match, narrow the range", maxWebhookReplayEvents))
+	}
+
+	var subscriptions []models.WebhookSubscription
+	if req.SubscriptionID != nil {
+		subscription, err := s.getSubscription(*req.SubscriptionID, hospitalID)
+		if err != nil {
+			return nil, err
+		}
+		if !subscription.Active {
+			return nil, apperrors.NewBusinessRuleError("cannot replay to an inactive webhook subscription", map[string]interface{}{
+				"subscription_id": subscription.ID,
+			})
+		}
+		subscriptions = []models.WebhookSubscription{*subscription}
+	} else {
+		err := s.db.Where("hospital_id = ? AND active", hospitalID).Find(&subscriptions).Error
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("find webhook subscriptions", err)
+		}
+	}
+
+	now := time.Now()
+	var deliveries []models.WebhookDelivery
+	for _, event := range events {
+		for _, subscription := range subscriptions {
+			if !subscriptionAccepts(&subscription, event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, models.WebhookDelivery{
+				EventID:        event.ID,
+				SubscriptionID: subscription.ID,
+				HospitalID:     hospitalID,
+				Status:         models.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+			})
+		}
+	}
+
+	if len(deliveries) > 0 {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			for _, delivery := range deliveries {
+				err := tx.Model(&models.WebhookDelivery{}).
+					Where("event_id = ? AND subscription_id = ? AND status = ?", delivery.EventID, delivery.SubscriptionID, models.WebhookDeliveryDead).
+					Update("status", models.WebhookDeliveryReplayed).Error
+				if err != nil {
+					return err
+				}
+			}
+			return tx.CreateInBatches(deliveries, 100).Error
+		})
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("queue webhook replay", err)
+		}
+	}
+
+	return &models.WebhookReplayResponse{Events: len(events), Deliveries: len(deliveries)}, nil
+}
+
+// RunDeliveryJob sends due deliveries on every interval until window has
+// passed or ctx is cancelled, and returns how many it attempted. The
+// scheduled job runs it for the gap between two of its runs, so deliveries
+// keep going out every few seconds while the job itself fires once a minute.
+func (s *WebhookService) RunDeliveryJob(ctx context.Context, window time.Duration) (int, error) {
+	deadline := time.Now().Add(window)
+	attempted := 0
+	for {
+		sent, err := s.DeliverDue(ctx)
+		attempted += sent
+		if err != nil {
+			return attempted, err
+		}
+		if time.Until(deadline) < s.interval {
+			return attempted, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempted, nil
+		case <-time.After(s.interval):
+		}
+	}
+}
+
+// DeliverDue sends the pending deliveries whose next attempt is due, in
+// batches until none are left, and returns how many it attempted. Claimed
+// deliveries are leased for long enough to send the whole batch one after
+// another, so another replica running the job skips them while they wait.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		deliveries, err := s.claimDue(time.Now())
+		if err != nil {
+			return attempted, err
+		}
+		if len(deliveries) == 0 {
+			return attempted, nil
+		}
+
+		for i := range deliveries {
+			if err := s.deliver(ctx, &deliveries[i]); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+	}
+}
+
+func (s *WebhookService) claimDue(now time.Time) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		active := tx.Model(&models.WebhookSubscription{}).Select("id").Where("active")
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Where("subscription_id IN (?)", active).
+			Order("next_attempt_at, id").
+			Limit(webhookDeliveryBatch).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		lease := now.Add(time.Duration(len(deliveries)+1) * s.client.Timeout)
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", lease).Error
+	})
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("claim webhook deliveries", err)
+	}
+	return deliveries, nil
+}
+
+type webhookEnvelope struct {
+	ID         uint                    `json:"id"`
+	Type       models.WebhookEventType `json:"type"`
+	HospitalID uint                    `json:"hospital_id"`
+	OccurredAt time.Time               `json:"occurred_at"`
+	Data       json.RawMessage         `json:"data"`
+}
+
+// deliver sends one delivery and records the outcome. Only failures to
+// record it are returned; a failed request schedules a retry.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	var event models.WebhookEvent
+	if err := s.db.First(&event, delivery.EventID).Error; err != nil {
+		return apperrors.NewDatabaseError("find webhook event", err)
+	}
+	var subscription models.WebhookSubscription
+	if err := s.db.Unscoped().First(&subscription, delivery.SubscriptionID).Error; err != nil {
+		return apperrors.NewDatabaseError("find webhook subscription", err)
+	}
+
+	statusCode, sendErr := s.send(ctx, &subscription, &event, delivery.ID)
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"attempts":        delivery.Attempts + 1,
+		"response_status": statusCode,
+	}
+	if sendErr == nil {
+		updates["status"] = models.WebhookDeliveryDelivered
+		updates["delivered_at"] = now
+		updates["last_error"] = ""
+	} else {
+		updates["last_error"] = truncateUTF8(sendErr.Error(), maxWebhookErrorLength)
+		if delivery.Attempts+1 >= s.maxAttempts {
+			updates["status"] = models.WebhookDeliveryDead
+		} else {
+			updates["next_attempt_at"] = now.Add(s.retryDelay(delivery.Attempts + 1))
+		}
+		log.Warn().Err(sendErr).
+			Uint("delivery_id", delivery.ID).
+			Uint("subscription_id", subscription.ID).
+			Int("attempt", delivery.Attempts+1).
+			Msg("Webhook delivery attempt failed")
+	}
+
+	if err := s.db.Model(delivery).Updates(updates).Error; err != nil {
+		return apperrors.NewDatabaseError("record webhook delivery", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) send(ctx context.Context, subscription *models.WebhookSubscription, event *models.WebhookEvent, deliveryID uint) (int, error) {
+	body, err := json.Marshal(webhookEnvelope{
+		ID:         event.ID,
+		Type:       event.Type,
+		HospitalID: event.HospitalID,
+		OccurredAt: event.CreatedAt,
+		Data:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "hospital-tracker-webhooks")
+	request.Header.Set("X-Webhook-ID", strconv.FormatUint(uint64(event.ID), 10))
+	request.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(deliveryID), 10))
+	request.Header.Set("X-Webhook-Event", string(event.Type))
+	request.Header.Set("X-Webhook-Timestamp", timestamp)
+	request.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(subscription.Secret, timestamp, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("receiver responded with %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// retryDelay doubles the backoff with every failed attempt, up to a cap.
+func (s *WebhookService) retryDelay(attempts int) time.Duration {
+	delay := s.backoff
+	for i := 1; i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookBackoff {
+		delay = maxWebhookBackoff
+	}
+	return delay
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+// under the subscription secret, as sent in X-Webhook-Signature after the
+// "sha256=" prefix. Receivers recompute it from X-Webhook-Timestamp and the
+// raw body, and should reject stale timestamps.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// publishWebhookEvent writes an event to the outbox with a pending delivery
+// for every active subscription of the hospital that accepts its type. It
+// runs in the caller's transaction, so the event exists exactly when the
+// change it describes was committed.
+func publishWebhookEvent(tx *gorm.DB, hospitalID uint, eventType models.WebhookEventType, data interface{}) error {
+	var subscriptions []models.WebhookSubscription
+	if err := tx.Where("hospital_id = ? AND active", hospitalID).Find(&subscriptions).Error; err != nil {
+		return err
+	}
+
+	var accepting []models.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscriptionAccepts(&subscription, eventType) {
+			accepting = append(accepting, subscription)
+		}
+	}
+	if len(accepting) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	event := &models.WebhookEvent{
+		HospitalID: hospitalID,
+		Type:       eventType,
+		Payload:    string(payload),
+	}
+	if err := tx.Create(event).Error; err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(accepting))
+	for i, subscription := range accepting {
+		deliveries[i] = models.WebhookDelivery{
+			EventID:        event.ID,
+			SubscriptionID: subscription.ID,
+			HospitalID:     hospitalID,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  event.CreatedAt,
+		}
+	}
+	return tx.Create(&deliveries).Error
+}
+
+// publishStaffEvent publishes the staff row as written by tx.
+func publishStaffEvent(tx *gorm.DB, eventType models.WebhookEventType, staffID uint, previousClinicID *uint) error {
+	var staff models.Staff
+	err := tx.Unscoped().Preload("ProfessionGroup").Preload("Title").First(&staff, staffID).Error
+	if err != nil {
+		return err
+	}
+
+	days := []string{}
+	if staff.WorkingDays != "" {
+		if err := json.Unmarshal([]byte(staff.WorkingDays), &days); err != nil {
+			return err
+		}
+	}
+
+	return publishWebhookEvent(tx, staff.HospitalID, eventType, models.WebhookStaffData{
+		ID:                staff.ID,
+		FirstName:         staff.FirstName,
+		LastName:          staff.LastName,
+		NationalID:        staff.NationalID,
+		Phone:             staff.Phone,
+		ProfessionGroupID: staff.ProfessionGroupID,
+		ProfessionGroup:   staff.ProfessionGroup.Name,
+		TitleID:           staff.TitleID,
+		Title:             staff.Title.Name,
+		ClinicID:          staff.ClinicID,
+		PreviousClinicID:  previousClinicID,
+		WorkingDays:       days,
+		Status:            staff.Status,
+		UpdatedAt:         staff.UpdatedAt,
+	})
+}
+
+func subscriptionAccepts(subscription *models.WebhookSubscription, eventType models.WebhookEventType) bool {
+	if subscription.EventTypes == "" {
+		return true
+	}
+	for _, accepted := range strings.Split(subscription.EventTypes, ",") {
+		if accepted == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinWebhookEventTypes(eventTypes []models.WebhookEventType) string {
+	names := make([]string, 0, len(eventTypes))
+	seen := map[models.WebhookEventType]bool{}
+	for _, eventType := range eventTypes {
+		if !seen[eventType] {
+			seen[eventType] = true
+			names = append(names, string(eventType))
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// validateWebhookURL catches receivers that are internal on their face.
+// Hostnames are checked again on every delivery, once they are resolved.
+func (s *WebhookService) validateWebhookURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return apperrors.NewValidationError("url", "must be an absolute http or https URL")
+	}
+	if s.allowPrivate {
+		return nil
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return apperrors.NewValidationError("url", "must not point to a private or loopback address")
+	}
+	if ip := net.ParseIP(host); ip != nil && isPrivateWebhookIP(ip) {
+		return apperrors.NewValidationError("url", "must not point to a private or loopback address")
+	}
+	return nil
+}
+
+// truncateUTF8 cuts s to at most max bytes without splitting a multi-byte
+// character, which Postgres would reject as invalid UTF-8.
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *WebhookService) getSubscription(subscriptionID, hospitalID uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := s.db.Where("id = ? AND hospital_id = ?", subscriptionID, hospitalID).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("webhook subscription", subscriptionID)
+		}
+		return nil, apperrors.NewDatabaseError("find webhook subscription", err)
+	}
+	return &subscription, nil
+}
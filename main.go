@@ -2,12 +2,15 @@ package main
 
 import (
 	"net/http"
+	"os"
+	_ "time/tzdata"
 
 	"github.com/caner-cetin/hospital-tracker/internal/config"
 	"github.com/caner-cetin/hospital-tracker/internal/database"
 	"github.com/caner-cetin/hospital-tracker/internal/handlers"
 	"github.com/caner-cetin/hospital-tracker/internal/middleware"
 	"github.com/caner-cetin/hospital-tracker/internal/redis"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	swaggerFiles "github.com/swaggo/files"
@@ -36,6 +39,13 @@ func main() {
 	cfg := config.Load()
 	config.InitLogger(&cfg.Logging)
 
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Str("command", os.Args[1]).Msg("Command failed")
+		}
+		return
+	}
+
 	log.Info().Msg("Starting Hospital Tracker API")
 
 	db, err := database.Initialize(cfg.Database)
@@ -46,8 +56,15 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize Redis")
 	}
-	r := gin.Default()
+
+	if err := services.EnableAuditLog(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to enable audit log")
+	}
+
+	r := gin.New()
+	r.Use(middleware.Logger(), gin.Recovery())
 	r.Use(middleware.CORS())
+	r.Use(middleware.RequestContext())
 	api := r.Group("/api")
 	handlers.SetupRoutes(api, db, redisClient, cfg)
 	r.GET("/health", func(c *gin.Context) {
@@ -101,7 +101,7 @@ func CreateTestUser(db *gorm.DB, authService *services.AuthService, hospitalID u
 
 func CreateTestClinic(db *gorm.DB, hospitalID uint) (*models.Clinic, error) {
 	var clinicType models.ClinicType
-	if err := db.First(&clinicType).Error; err != nil {
+	if err := db.Where("hospital_id IS NULL").First(&clinicType).Error; err != nil {
 		clinicType = models.ClinicType{Name: faker.Word()}
 		if err := db.Create(&clinicType).Error; err != nil {
 			return nil, err
@@ -121,6 +121,16 @@ func CreateTestClinic(db *gorm.DB, hospitalID uint) (*models.Clinic, error) {
 }
 
 func CreateTestStaff(db *gorm.DB, hospitalID uint, clinicID *uint) (*models.Staff, error) {
+	return createTestStaff(db, hospitalID, clinicID, models.StaffActive)
+}
+
+// CreateTestOnboardingStaff creates a staff member still in onboarding, the
+// only kind of record DeleteStaff accepts.
+func CreateTestOnboardingStaff(db *gorm.DB, hospitalID uint, clinicID *uint) (*models.Staff, error) {
+	return createTestStaff(db, hospitalID, clinicID, models.StaffOnboarding)
+}
+
+func createTestStaff(db *gorm.DB, hospitalID uint, clinicID *uint, status models.StaffStatus) (*models.Staff, error) {
 	var professionGroup models.ProfessionGroup
 	if err := db.Where("name = ?", "Doktor").First(&professionGroup).Error; err != nil {
 		return nil, err
@@ -139,6 +149,7 @@ func CreateTestStaff(db *gorm.DB, hospitalID uint, clinicID *uint) (*models.Staf
 		ProfessionGroupID: professionGroup.ID,
 		TitleID:           title.ID,
 		ClinicID:          clinicID,
+		Status:            status,
 		WorkingDays:       []models.WorkingDay{models.Monday, models.Tuesday, models.Wednesday},
 	}
 
@@ -81,6 +81,8 @@ func SetupTestContainers(ctx context.Context) (*TestContainers, error) {
 			Password: "test",
 			Name:     "test_hospital_tracker",
 			SSLMode:  "disable",
+
+			AutoMigrate: true,
 		},
 		Redis: config.RedisConfig{
 			Host:     redisHost,
@@ -137,6 +139,27 @@ func (tc *TestContainers) CleanDatabase() error {
 	tc.DB.Exec("SET session_replication_role = replica")
 
 	tables := []string{
+		"job_runs",
+		"user_notifications",
+		"notification_preferences",
+		"webhook_deliveries",
+		"webhook_events",
+		"webhook_subscriptions",
+		"staff_versions",
+		"audit_logs",
+		"headcount_snapshots",
+		"clinic_closures",
+		"hospital_holidays",
+		"clinic_opening_hours",
+		"clinic_rooms",
+		"staff_status_changes",
+		"staff_employments",
+		"staff_credentials",
+		"attendance_days",
+		"attendance_events",
+		"attendance_kiosks",
+		"staff_clinic_assignments",
+		"staff_import_jobs",
 		"staffs",
 		"password_resets",
 		"clinics",
@@ -150,6 +173,11 @@ func (tc *TestContainers) CleanDatabase() error {
 		}
 	}
 
+	// platform default staffing rules are seeded once per database and kept
+	if err := tc.DB.Exec("DELETE FROM staffing_rules WHERE hospital_id IS NOT NULL").Error; err != nil {
+		fmt.Printf("Warning: failed to clean table staffing_rules: %v\n", err)
+	}
+
 	tc.DB.Exec("SET session_replication_role = DEFAULT")
 
 	err := tc.Redis.FlushAll(context.Background()).Err()
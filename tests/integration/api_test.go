@@ -295,9 +295,10 @@ func (suite *APITestSuite) TestGetClinics() {
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
-	suite.Contains(response, "clinics")
+	suite.Contains(response, "data")
+	suite.Equal(float64(1), response["total_count"])
 
-	clinics := response["clinics"].([]interface{})
+	clinics := response["data"].([]interface{})
 	suite.Len(clinics, 1)
 }
 
@@ -403,6 +404,40 @@ func (suite *APITestSuite) TestGetDistrictsByProvince() {
 	suite.Contains(response, "districts")
 }
 
+func (suite *APITestSuite) TestGetProvinceDistrictsAndNeighbourhoods() {
+	w := suite.makeRequest("GET", "/api/provinces/999/districts", nil, nil)
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	var province models.Province
+	suite.Require().NoError(suite.containers.DB.Where("plate_code = ?", 34).First(&province).Error)
+	w = suite.makeRequest("GET", fmt.Sprintf("/api/provinces/%d/districts", province.ID), nil, nil)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var districts struct {
+		Districts []models.District `json:"districts"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &districts))
+	suite.Len(districts.Districts, 39)
+
+	var kadikoy models.District
+	for _, district := range districts.Districts {
+		if district.Name == "Kadıköy" {
+			kadikoy = district
+		}
+	}
+	suite.Require().NotZero(kadikoy.ID)
+
+	w = suite.makeRequest("GET", fmt.Sprintf("/api/districts/%d/neighbourhoods?q=CAFERAGA", kadikoy.ID), nil, nil)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var neighbourhoods struct {
+		Neighbourhoods []models.Neighbourhood `json:"neighbourhoods"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &neighbourhoods))
+	suite.Require().NotEmpty(neighbourhoods.Neighbourhoods)
+	suite.Equal("Caferağa", neighbourhoods.Neighbourhoods[0].Name)
+}
+
 func (suite *APITestSuite) TestGetClinicTypes() {
 	w := suite.makeRequest("GET", "/api/clinic-types", nil, nil)
 
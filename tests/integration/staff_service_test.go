@@ -2,8 +2,14 @@ package integration
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
 	"github.com/caner-cetin/hospital-tracker/internal/models"
 	"github.com/caner-cetin/hospital-tracker/internal/services"
 	"github.com/caner-cetin/hospital-tracker/tests/helpers"
@@ -145,6 +151,12 @@ func (suite *StaffServiceIntegrationTestSuite) TestCreateStaffChiefPhysicianRest
 	staff2, err := suite.staffService.CreateStaff(req2, suite.hospitalID)
 	suite.Error(err)
 	suite.Nil(staff2)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+		suite.Contains(appErr.Context, "rule_id")
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
 }
 
 func (suite *StaffServiceIntegrationTestSuite) TestGetStaffWithPagination() {
@@ -189,6 +201,112 @@ func (suite *StaffServiceIntegrationTestSuite) TestGetStaffWithPagination() {
 	suite.Len(result.Data, 5) // remaining 5 records
 }
 
+func (suite *StaffServiceIntegrationTestSuite) TestGetStaffWithCursor() {
+	professionGroupID, titleID := suite.getProfessionGroupAndTitle("Doktor", "Asistan")
+
+	lastNames := []string{"Aksoy", "Aksoy", "Bulut", "Demir", "Demir", "Er", "Koç"}
+	for i, lastName := range lastNames {
+		_, err := suite.staffService.CreateStaff(&models.CreateStaffRequest{
+			FirstName:         fmt.Sprintf("Personel %d", i),
+			LastName:          lastName,
+			NationalID:        fmt.Sprintf("3000000000%d", i),
+			Phone:             fmt.Sprintf("+90555300000%d", i),
+			ProfessionGroupID: professionGroupID,
+			TitleID:           titleID,
+			WorkingDays:       []models.WorkingDay{models.Monday},
+		}, suite.hospitalID)
+		suite.Require().NoError(err)
+	}
+
+	filter := &models.StaffFilterRequest{Sort: "-last_name,first_name", Limit: 3}
+
+	var forward []string
+	var pages []*models.StaffPaginatedResponse
+	for {
+		result, err := suite.staffService.GetStaff(filter, suite.hospitalID)
+		suite.Require().NoError(err)
+		pages = append(pages, result)
+		for _, staff := range result.Data {
+			forward = append(forward, staff.LastName+" "+staff.FirstName)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		filter.Cursor = result.NextCursor
+	}
+
+	suite.Len(pages, 3)
+	suite.Empty(pages[0].PrevCursor)
+	suite.Equal([]string{
+		"Koç Personel 6", "Er Personel 5", "Demir Personel 3",
+		"Demir Personel 4", "Bulut Personel 2", "Aksoy Personel 0",
+		"Aksoy Personel 1",
+	}, forward)
+
+	filter.Cursor = pages[2].PrevCursor
+	result, err := suite.staffService.GetStaff(filter, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Data, 3)
+	suite.Equal("Personel 4", result.Data[0].FirstName)
+	suite.Equal("Bulut", result.Data[1].LastName)
+	suite.NotEmpty(result.PrevCursor)
+
+	filter = &models.StaffFilterRequest{Sort: "password"}
+	_, err = suite.staffService.GetStaff(filter, suite.hospitalID)
+	suite.Error(err)
+
+	filter = &models.StaffFilterRequest{Limit: 1000}
+	result, err = suite.staffService.GetStaff(filter, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(100, result.Limit)
+}
+
+func (suite *StaffServiceIntegrationTestSuite) TestGetStaffWithRicherFilters() {
+	assigned, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	unassigned, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	deleted, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(deleted.ID, suite.hospitalID))
+
+	result, err := suite.staffService.GetStaff(&models.StaffFilterRequest{ClinicID: "unassigned"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Data, 1)
+	suite.Equal(unassigned.ID, result.Data[0].ID)
+
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{ClinicID: "unassigned", IncludeDeleted: true}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(result.Data, 2)
+
+	// the helper schedules monday to wednesday
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{WorkingDay: models.Tuesday}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(result.Data, 2)
+
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{WorkingDay: models.Sunday}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Empty(result.Data)
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{CreatedFrom: today, CreatedTo: today}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(result.Data, 2)
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{UpdatedFrom: tomorrow}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Empty(result.Data)
+
+	_, err = suite.staffService.GetStaff(&models.StaffFilterRequest{CreatedFrom: "yesterday"}, suite.hospitalID)
+	suite.Error(err)
+
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{ClinicID: strconv.FormatUint(uint64(suite.clinicID), 10)}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Data, 1)
+	suite.Equal(assigned.ID, result.Data[0].ID)
+}
+
 func (suite *StaffServiceIntegrationTestSuite) TestGetStaffWithFilters() {
 	doctorProfessionGroupID, doctorTitleID := suite.getProfessionGroupAndTitle("Doktor", "Asistan")
 	serviceProfessionGroupID, serviceTitleID := suite.getProfessionGroupAndTitle("Hizmet Personeli", "Danışman")
@@ -245,6 +363,43 @@ func (suite *StaffServiceIntegrationTestSuite) TestGetStaffWithFilters() {
 	suite.Len(result.Data, 1)
 }
 
+func (suite *StaffServiceIntegrationTestSuite) TestSearchStaffFoldsTurkish() {
+	professionGroupID, titleID := suite.getProfessionGroupAndTitle("Doktor", "Uzman")
+
+	names := [][2]string{{"İbrahim", "Şahin"}, {"Işıl", "Yılmaz"}, {"Ayşe", "Kılıç"}}
+	for i, name := range names {
+		_, err := suite.staffService.CreateStaff(&models.CreateStaffRequest{
+			FirstName:         name[0],
+			LastName:          name[1],
+			NationalID:        fmt.Sprintf("2000000000%d", i),
+			Phone:             fmt.Sprintf("+90555200000%d", i),
+			ProfessionGroupID: professionGroupID,
+			TitleID:           titleID,
+			WorkingDays:       []models.WorkingDay{models.Monday},
+		}, suite.hospitalID)
+		suite.Require().NoError(err)
+	}
+
+	cases := map[string]string{
+		"ibrahim sahin": "İbrahim",
+		"IBRAHIM":       "İbrahim",
+		"isil":          "Işıl",
+		"yilmas":        "Işıl",
+		"kili":          "Ayşe",
+		"200000000":     "İbrahim",
+	}
+	for q, expected := range cases {
+		result, err := suite.staffService.GetStaff(&models.StaffFilterRequest{Q: q, Page: 1, Limit: 10}, suite.hospitalID)
+		suite.Require().NoError(err, q)
+		suite.Require().NotEmpty(result.Data, q)
+		suite.Equal(expected, result.Data[0].FirstName, q)
+	}
+
+	result, err := suite.staffService.GetStaff(&models.StaffFilterRequest{Q: "zeynep", Page: 1, Limit: 10}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Empty(result.Data)
+}
+
 func (suite *StaffServiceIntegrationTestSuite) TestUpdateStaff() {
 	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
 	suite.Require().NoError(err)
@@ -266,7 +421,7 @@ func (suite *StaffServiceIntegrationTestSuite) TestUpdateStaff() {
 }
 
 func (suite *StaffServiceIntegrationTestSuite) TestDeleteStaff() {
-	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
 	suite.Require().NoError(err)
 
 	err = suite.staffService.DeleteStaff(staff.ID, suite.hospitalID)
@@ -277,6 +432,130 @@ func (suite *StaffServiceIntegrationTestSuite) TestDeleteStaff() {
 	suite.Nil(deletedStaff)
 	suite.Contains(err.Error(), "staff not found")
 }
+
+func (suite *StaffServiceIntegrationTestSuite) TestDeleteStaffRefusesStaffWithHistory() {
+	active, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	onboarding, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	employmentService := services.NewEmploymentService(suite.containers.DB)
+	_, err = employmentService.ChangeStatus(onboarding.ID, &models.ChangeStaffStatusRequest{Status: models.StaffTerminated, Reason: "offer withdrawn"}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+	_, err = employmentService.ChangeStatus(onboarding.ID, &models.ChangeStaffStatusRequest{Status: models.StaffOnboarding}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+
+	for _, staff := range []*models.Staff{active, onboarding} {
+		err := suite.staffService.DeleteStaff(staff.ID, suite.hospitalID)
+		suite.Require().Error(err)
+		appErr, ok := errors.IsAppError(err)
+		suite.Require().True(ok)
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+		suite.Contains(appErr.Message, "/status")
+
+		kept, err := suite.staffService.GetStaffByID(staff.ID, suite.hospitalID)
+		suite.Require().NoError(err)
+		suite.NotNil(kept)
+	}
+}
+
+func (suite *StaffServiceIntegrationTestSuite) createSecondClinic() uint {
+	var clinicType models.ClinicType
+	err := suite.containers.DB.Where("name = ?", "Ortopedi").First(&clinicType).Error
+	suite.Require().NoError(err)
+
+	clinic, err := suite.clinicService.CreateClinic(&models.CreateClinicRequest{ClinicTypeID: clinicType.ID}, suite.hospitalID)
+	suite.Require().NoError(err)
+	return clinic.ID
+}
+
+func (suite *StaffServiceIntegrationTestSuite) TestTransferStaff() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	targetClinicID := suite.createSecondClinic()
+
+	transferred, err := suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{
+		ClinicID: &targetClinicID,
+		Reason:   "rotation",
+	}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+	suite.Equal(targetClinicID, *transferred.ClinicID)
+
+	assignments, err := suite.staffService.GetStaffAssignments(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(assignments, 2)
+	suite.Equal(targetClinicID, assignments[0].ClinicID)
+	suite.Nil(assignments[0].EndedAt)
+	suite.Equal(suite.clinicID, assignments[1].ClinicID)
+	suite.NotNil(assignments[1].EndedAt)
+
+	_, err = suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{
+		ClinicID: &targetClinicID,
+		Reason:   "again",
+	}, suite.hospitalID, 0)
+	suite.Error(err)
+}
+
+func (suite *StaffServiceIntegrationTestSuite) TestConcurrentTransfersLeaveOneOpenAssignment() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	targetClinicID := suite.createSecondClinic()
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{
+				ClinicID: &targetClinicID,
+				Reason:   "rotation",
+			}, suite.hospitalID, 0)
+			if err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.Equal(int32(1), succeeded.Load())
+	var open int64
+	err = suite.containers.DB.Model(&models.StaffClinicAssignment{}).
+		Where("staff_id = ? AND ended_at IS NULL", staff.ID).
+		Count(&open).Error
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), open)
+}
+
+func (suite *StaffServiceIntegrationTestSuite) TestGetClinicStaffAsOf() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	targetClinicID := suite.createSecondClinic()
+
+	beforeTransfer := time.Now().UTC().Format(time.RFC3339Nano)
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{
+		ClinicID: &targetClinicID,
+		Reason:   "rotation",
+	}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+
+	current, err := suite.staffService.GetClinicStaff(suite.clinicID, suite.hospitalID, "")
+	suite.NoError(err)
+	suite.Empty(current)
+
+	past, err := suite.staffService.GetClinicStaff(suite.clinicID, suite.hospitalID, beforeTransfer)
+	suite.NoError(err)
+	suite.Require().Len(past, 1)
+	suite.Equal(staff.ID, past[0].ID)
+
+	target, err := suite.staffService.GetClinicStaff(targetClinicID, suite.hospitalID, "")
+	suite.NoError(err)
+	suite.Len(target, 1)
+}
+
 func TestStaffServiceIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(StaffServiceIntegrationTestSuite))
 }
@@ -0,0 +1,333 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttendanceServiceTestSuite struct {
+	suite.Suite
+	containers        *helpers.TestContainers
+	attendanceService *services.AttendanceService
+	authService       *services.AuthService
+	hospitalID        uint
+	userID            uint
+}
+
+func (suite *AttendanceServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.attendanceService = services.NewAttendanceService(containers.DB, containers.Config)
+}
+
+func (suite *AttendanceServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *AttendanceServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, user, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+	suite.userID = user.ID
+}
+
+func (suite *AttendanceServiceTestSuite) TestClockInAndOut() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	req := &models.ClockRequest{StaffID: staff.ID}
+
+	event, err := suite.attendanceService.ClockIn(req, suite.hospitalID, suite.userID)
+	suite.NoError(err)
+	suite.Equal(models.AttendanceClockIn, event.Type)
+	suite.Equal(models.AttendanceSourceAPI, event.Source)
+
+	_, err = suite.attendanceService.ClockIn(req, suite.hospitalID, suite.userID)
+	suite.Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+
+	event, err = suite.attendanceService.ClockOut(req, suite.hospitalID, suite.userID)
+	suite.NoError(err)
+	suite.Equal(models.AttendanceClockOut, event.Type)
+}
+
+func (suite *AttendanceServiceTestSuite) TestConcurrentClockInsRecordOneEvent() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	req := &models.ClockRequest{StaffID: staff.ID}
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := suite.attendanceService.ClockIn(req, suite.hospitalID, suite.userID); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.Equal(int32(1), succeeded.Load())
+	var events int64
+	suite.Require().NoError(suite.containers.DB.Model(&models.AttendanceEvent{}).Where("staff_id = ?", staff.ID).Count(&events).Error)
+	suite.Equal(int64(1), events)
+}
+
+func (suite *AttendanceServiceTestSuite) TestClockOutWithoutClockIn() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	_, err = suite.attendanceService.ClockOut(&models.ClockRequest{StaffID: staff.ID}, suite.hospitalID, suite.userID)
+	suite.Error(err)
+}
+
+func (suite *AttendanceServiceTestSuite) TestKioskClockIn() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	kiosk, err := suite.attendanceService.CreateKiosk(&models.CreateKioskRequest{Name: "Main entrance"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.NotEmpty(kiosk.Token)
+
+	event, err := suite.attendanceService.KioskClock(kiosk.Token, &models.KioskClockRequest{NationalID: staff.NationalID}, models.AttendanceClockIn)
+	suite.NoError(err)
+	suite.Equal(models.AttendanceSourceKiosk, event.Source)
+	suite.Equal(kiosk.Kiosk.ID, *event.KioskID)
+
+	_, err = suite.attendanceService.KioskClock("invalid", &models.KioskClockRequest{NationalID: staff.NationalID}, models.AttendanceClockOut)
+	suite.Error(err)
+}
+
+func (suite *AttendanceServiceTestSuite) TestTimesheetCountsCorrections() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	// the helper schedules monday to wednesday
+	monday := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	suite.hire(staff.ID, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+	corrections := []models.AttendanceCorrectionRequest{
+		{StaffID: staff.ID, Type: models.AttendanceClockIn, OccurredAt: monday.Add(8*time.Hour + 30*time.Minute), Note: "forgot badge"},
+		{StaffID: staff.ID, Type: models.AttendanceClockOut, OccurredAt: monday.Add(19 * time.Hour), Note: "forgot badge"},
+	}
+	for i := range corrections {
+		_, err := suite.attendanceService.CorrectAttendance(&corrections[i], suite.hospitalID, suite.userID)
+		suite.Require().NoError(err)
+	}
+
+	timesheet, err := suite.attendanceService.GetTimesheet(suite.hospitalID, "2026-03")
+	suite.Require().NoError(err)
+	suite.Require().Len(timesheet.Entries, 1)
+
+	entry := timesheet.Entries[0]
+	suite.Equal(staff.ID, entry.StaffID)
+	suite.Equal(1, entry.PresentDays)
+	suite.Equal(1, entry.LateDays)
+	suite.Equal(630, entry.WorkedMinutes)
+	suite.Equal(90, entry.OvertimeMinutes)
+	suite.Positive(entry.AbsentDays)
+}
+
+func (suite *AttendanceServiceTestSuite) hire(staffID uint, hireDate time.Time) {
+	employment := &models.StaffEmployment{
+		StaffID:      staffID,
+		HospitalID:   suite.hospitalID,
+		ContractType: models.ContractPermanent,
+		HireDate:     hireDate,
+	}
+	suite.Require().NoError(suite.containers.DB.Create(employment).Error)
+}
+
+func (suite *AttendanceServiceTestSuite) TestTimesheetSkipsDaysOutsideEmployment() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	// hired on monday 9 March, on leave for the week of 16 March
+	suite.hire(staff.ID, time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC))
+	changes := []models.StaffStatusChange{
+		{StaffID: staff.ID, HospitalID: suite.hospitalID, FromStatus: models.StaffActive, ToStatus: models.StaffOnLeave, Reason: "annual leave", EffectiveAt: time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{StaffID: staff.ID, HospitalID: suite.hospitalID, FromStatus: models.StaffOnLeave, ToStatus: models.StaffActive, EffectiveAt: time.Date(2026, time.March, 23, 0, 0, 0, 0, time.UTC)},
+	}
+	suite.Require().NoError(suite.containers.DB.Create(&changes).Error)
+
+	timesheet, err := suite.attendanceService.GetTimesheet(suite.hospitalID, "2026-03")
+	suite.Require().NoError(err)
+	suite.Require().Len(timesheet.Entries, 1)
+
+	// monday to wednesday of the weeks of 9, 23 and 30 March
+	entry := timesheet.Entries[0]
+	suite.Equal(8, entry.ScheduledDays)
+	suite.Equal(8, entry.AbsentDays)
+}
+
+func (suite *AttendanceServiceTestSuite) TestTimesheetKeepsStaffDeletedSince() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	monday := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	corrections := []models.AttendanceCorrectionRequest{
+		{StaffID: staff.ID, Type: models.AttendanceClockIn, OccurredAt: monday.Add(8 * time.Hour), Note: "forgot badge"},
+		{StaffID: staff.ID, Type: models.AttendanceClockOut, OccurredAt: monday.Add(17 * time.Hour), Note: "forgot badge"},
+	}
+	for i := range corrections {
+		_, err := suite.attendanceService.CorrectAttendance(&corrections[i], suite.hospitalID, suite.userID)
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(suite.containers.DB.Delete(&models.Staff{}, staff.ID).Error)
+
+	timesheet, err := suite.attendanceService.GetTimesheet(suite.hospitalID, "2026-03")
+	suite.Require().NoError(err)
+	suite.Require().Len(timesheet.Entries, 1)
+	suite.Equal(staff.ID, timesheet.Entries[0].StaffID)
+	suite.Equal(1, timesheet.Entries[0].PresentDays)
+
+	// a month that started after the deletion leaves them out
+	next := time.Now().AddDate(0, 1, 0).Format("2006-01")
+	timesheet, err = suite.attendanceService.GetTimesheet(suite.hospitalID, next)
+	suite.Require().NoError(err)
+	suite.Empty(timesheet.Entries)
+}
+
+func (suite *AttendanceServiceTestSuite) TestTimesheetCountsNightShiftOnItsFirstDay() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	suite.hire(staff.ID, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	// monday 22:00 to tuesday 06:00
+	monday := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	corrections := []models.AttendanceCorrectionRequest{
+		{StaffID: staff.ID, Type: models.AttendanceClockIn, OccurredAt: monday.Add(22 * time.Hour), Note: "night shift"},
+		{StaffID: staff.ID, Type: models.AttendanceClockOut, OccurredAt: monday.Add(30 * time.Hour), Note: "night shift"},
+	}
+	for i := range corrections {
+		_, err := suite.attendanceService.CorrectAttendance(&corrections[i], suite.hospitalID, suite.userID)
+		suite.Require().NoError(err)
+	}
+
+	days, err := suite.attendanceService.ReconcileDay(suite.hospitalID, "2026-03-02")
+	suite.Require().NoError(err)
+	suite.Require().Len(days, 1)
+	suite.Equal(models.AttendanceStatusPresent, days[0].Status)
+	suite.Equal(480, days[0].WorkedMinutes)
+
+	timesheet, err := suite.attendanceService.GetTimesheet(suite.hospitalID, "2026-03")
+	suite.Require().NoError(err)
+	suite.Require().Len(timesheet.Entries, 1)
+	suite.Equal(0, timesheet.Entries[0].IncompleteDays)
+	suite.Equal(480, timesheet.Entries[0].WorkedMinutes)
+}
+
+func TestReconcileAttendanceDay(t *testing.T) {
+	shift := services.AttendanceShift{Start: 8 * time.Hour, End: 17 * time.Hour, LateGrace: 10 * time.Minute}
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	now := day.AddDate(0, 0, 2)
+
+	event := func(eventType models.AttendanceEventType, offset time.Duration) models.AttendanceEvent {
+		return models.AttendanceEvent{Type: eventType, OccurredAt: day.Add(offset)}
+	}
+
+	t.Run("on time", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, true, shift, []models.AttendanceEvent{
+			event(models.AttendanceClockIn, 8*time.Hour+5*time.Minute),
+			event(models.AttendanceClockOut, 17*time.Hour),
+		}, now)
+
+		assert.Equal(t, models.AttendanceStatusPresent, record.Status)
+		assert.Equal(t, 0, record.LateMinutes)
+		assert.Equal(t, 0, record.OvertimeMinutes)
+		assert.Equal(t, 535, record.WorkedMinutes)
+	})
+
+	t.Run("late with overtime", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, true, shift, []models.AttendanceEvent{
+			event(models.AttendanceClockOut, 20*time.Hour),
+			event(models.AttendanceClockIn, 9*time.Hour),
+		}, now)
+
+		assert.Equal(t, models.AttendanceStatusPresent, record.Status)
+		assert.Equal(t, 60, record.LateMinutes)
+		assert.Equal(t, 660, record.WorkedMinutes)
+		assert.Equal(t, 120, record.OvertimeMinutes)
+	})
+
+	t.Run("split shift", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, true, shift, []models.AttendanceEvent{
+			event(models.AttendanceClockIn, 8*time.Hour),
+			event(models.AttendanceClockOut, 12*time.Hour),
+			event(models.AttendanceClockIn, 13*time.Hour),
+			event(models.AttendanceClockOut, 17*time.Hour),
+		}, now)
+
+		assert.Equal(t, 480, record.WorkedMinutes)
+		assert.Equal(t, 0, record.OvertimeMinutes)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, true, shift, nil, now)
+		assert.Equal(t, models.AttendanceStatusAbsent, record.Status)
+	})
+
+	t.Run("pending before shift end", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, true, shift, nil, day.Add(9*time.Hour))
+		assert.Equal(t, models.AttendanceStatusPending, record.Status)
+	})
+
+	t.Run("unscheduled work is overtime", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, false, shift, []models.AttendanceEvent{
+			event(models.AttendanceClockIn, 10*time.Hour),
+			event(models.AttendanceClockOut, 14*time.Hour),
+		}, now)
+
+		assert.Equal(t, 0, record.LateMinutes)
+		assert.Equal(t, 240, record.OvertimeMinutes)
+	})
+
+	t.Run("night shift", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, false, shift, []models.AttendanceEvent{
+			event(models.AttendanceClockIn, 22*time.Hour),
+			event(models.AttendanceClockOut, 30*time.Hour),
+		}, now)
+
+		assert.Equal(t, models.AttendanceStatusPresent, record.Status)
+		assert.Equal(t, 480, record.WorkedMinutes)
+	})
+
+	t.Run("missing clock-out", func(t *testing.T) {
+		record := services.ReconcileAttendanceDay(day, true, shift, []models.AttendanceEvent{
+			event(models.AttendanceClockIn, 8*time.Hour),
+		}, now)
+
+		assert.Equal(t, models.AttendanceStatusIncomplete, record.Status)
+		assert.Equal(t, 0, record.WorkedMinutes)
+	})
+}
+
+func TestAttendanceServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AttendanceServiceTestSuite))
+}
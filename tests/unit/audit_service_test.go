@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditServiceTestSuite struct {
+	suite.Suite
+	containers   *helpers.TestContainers
+	auditService *services.AuditService
+	staffService *services.StaffService
+	authService  *services.AuthService
+	hospitalID   uint
+	userID       uint
+}
+
+func (suite *AuditServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.auditService = services.NewAuditService(containers.DB)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+	suite.Require().NoError(services.EnableAuditLog(containers.DB))
+}
+
+func (suite *AuditServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *AuditServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, user, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+	suite.userID = user.ID
+}
+
+func (suite *AuditServiceTestSuite) TestStaffWritesAreAudited() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	ctx := services.WithAuditActor(context.Background(), services.AuditActor{
+		UserID:     &suite.userID,
+		HospitalID: &suite.hospitalID,
+		RequestID:  "req-audit-1",
+		IP:         "10.0.0.7",
+	})
+	_, err = suite.staffService.WithContext(ctx).UpdateStaff(staff.ID, &models.UpdateStaffRequest{FirstName: "Güneş"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.WithContext(ctx).DeleteStaff(staff.ID, suite.hospitalID))
+
+	result, err := suite.auditService.GetAuditLogs(&models.AuditFilterRequest{Resource: "staffs", ResourceID: staff.ID}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().GreaterOrEqual(len(result.Data), 3)
+
+	var update *models.AuditLog
+	for i := range result.Data {
+		if result.Data[i].Action == models.AuditUpdate && strings.Contains(string(result.Data[i].Changes), "first_name") {
+			update = &result.Data[i]
+		}
+	}
+	suite.Require().NotNil(update)
+	suite.Contains(string(update.Changes), "Güneş")
+	suite.Require().NotNil(update.ActorUserID)
+	suite.Equal(suite.userID, *update.ActorUserID)
+	suite.Equal("req-audit-1", update.RequestID)
+	suite.Equal("10.0.0.7", update.IP)
+
+	byRequest, err := suite.auditService.GetAuditLogs(&models.AuditFilterRequest{RequestID: "req-audit-1", Action: models.AuditDelete}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.NotZero(byRequest.TotalCount)
+
+	users, err := suite.auditService.GetAuditLogs(&models.AuditFilterRequest{Resource: "users"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(users.Data)
+	suite.NotContains(string(users.Data[0].Changes), "$2a$", "password hashes are redacted")
+}
+
+func (suite *AuditServiceTestSuite) TestWebhookSecretsAreRedacted() {
+	webhookService := services.NewWebhookService(suite.containers.DB, suite.containers.Config)
+	created, err := webhookService.CreateSubscription(&models.CreateWebhookRequest{URL: "https://example.com/hooks"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	rotated, err := webhookService.RotateSecret(created.Subscription.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	result, err := suite.auditService.GetAuditLogs(&models.AuditFilterRequest{Resource: "webhook_subscriptions", ResourceID: created.Subscription.ID}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Data, 2)
+	for _, entry := range result.Data {
+		suite.Contains(string(entry.Changes), "secret")
+		suite.NotContains(string(entry.Changes), created.Secret)
+		suite.NotContains(string(entry.Changes), rotated.Secret)
+	}
+}
+
+func (suite *AuditServiceTestSuite) TestVerifyDetectsTampering() {
+	_, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	verification, err := suite.auditService.VerifyChain()
+	suite.Require().NoError(err)
+	suite.True(verification.Valid)
+	suite.NotZero(verification.Checked)
+
+	var entry models.AuditLog
+	suite.Require().NoError(suite.containers.DB.Where("resource = ?", "staffs").Order("id").First(&entry).Error)
+	suite.Require().NoError(suite.containers.DB.Exec("UPDATE audit_logs SET ip = ? WHERE id = ?", "203.0.113.9", entry.ID).Error)
+
+	verification, err = suite.auditService.VerifyChain()
+	suite.Require().NoError(err)
+	suite.False(verification.Valid)
+	suite.Require().NotNil(verification.BrokenAt)
+	suite.Equal(entry.ID, *verification.BrokenAt)
+}
+
+func TestAuditServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
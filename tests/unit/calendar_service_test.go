@@ -0,0 +1,181 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalendarServiceTestSuite struct {
+	suite.Suite
+	containers      *helpers.TestContainers
+	calendarService *services.CalendarService
+	authService     *services.AuthService
+	hospitalID      uint
+	clinicID        uint
+}
+
+func (suite *CalendarServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.calendarService = services.NewCalendarService(containers.DB, containers.Config)
+}
+
+func (suite *CalendarServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *CalendarServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *CalendarServiceTestSuite) TestOpeningHoursValidation() {
+	_, err := suite.calendarService.SetOpeningHours(suite.clinicID, &models.OpeningHoursRequest{
+		Periods: []models.OpeningHoursPeriod{
+			{Weekday: models.Monday, OpensAt: "08:00", ClosesAt: "12:30"},
+			{Weekday: models.Monday, OpensAt: "12:00", ClosesAt: "17:00"},
+		},
+	}, suite.hospitalID)
+	suite.Error(err, "overlapping periods")
+
+	_, err = suite.calendarService.SetOpeningHours(suite.clinicID, &models.OpeningHoursRequest{
+		Periods: []models.OpeningHoursPeriod{{Weekday: models.Monday, OpensAt: "17:00", ClosesAt: "08:00"}},
+	}, suite.hospitalID)
+	suite.Error(err, "closing before opening")
+}
+
+func (suite *CalendarServiceTestSuite) TestImportHolidaysAndIsOpen() {
+	_, err := suite.calendarService.SetOpeningHours(suite.clinicID, &models.OpeningHoursRequest{
+		Periods: []models.OpeningHoursPeriod{
+			{Weekday: models.Wednesday, OpensAt: "08:00", ClosesAt: "17:00"},
+			{Weekday: models.Thursday, OpensAt: "08:00", ClosesAt: "17:00"},
+		},
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	result, err := suite.calendarService.ImportNationalHolidays(2026, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Positive(result.Imported)
+
+	again, err := suite.calendarService.ImportNationalHolidays(2026, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Zero(again.Imported)
+	suite.Len(again.Holidays, result.Imported)
+
+	_, err = suite.calendarService.ImportNationalHolidays(1990, suite.hospitalID)
+	suite.Error(err)
+
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	suite.Require().NoError(err)
+
+	// 28 October 2026 is a Wednesday and the half-day eve of Republic Day
+	status, err := suite.calendarService.IsOpenAt(suite.clinicID, suite.hospitalID, time.Date(2026, time.October, 28, 10, 0, 0, 0, istanbul))
+	suite.Require().NoError(err)
+	suite.True(status.Open)
+
+	status, err = suite.calendarService.IsOpenAt(suite.clinicID, suite.hospitalID, time.Date(2026, time.October, 28, 14, 0, 0, 0, istanbul))
+	suite.Require().NoError(err)
+	suite.False(status.Open)
+	suite.NotNil(status.Holiday)
+
+	status, err = suite.calendarService.IsOpenAt(suite.clinicID, suite.hospitalID, time.Date(2026, time.October, 29, 10, 0, 0, 0, istanbul))
+	suite.Require().NoError(err)
+	suite.False(status.Open)
+
+	days, err := suite.calendarService.GetCalendar(suite.clinicID, suite.hospitalID, "2026-10-26", "2026-11-01")
+	suite.Require().NoError(err)
+	suite.Len(days, 7)
+}
+
+func (suite *CalendarServiceTestSuite) TestImportHolidaysOnSharedDate() {
+	result, err := suite.calendarService.ImportNationalHolidays(2027, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(result.Holidays, result.Imported)
+
+	// 19 May 2027 is both Youth and Sports Day and the last day of Kurban Bayramı
+	var shared *models.HospitalHoliday
+	for i := range result.Holidays {
+		if result.Holidays[i].Date.Format(time.DateOnly) == "2027-05-19" {
+			shared = &result.Holidays[i]
+		}
+	}
+	suite.Require().NotNil(shared)
+	suite.Contains(shared.Name, "Gençlik ve Spor Bayramı")
+	suite.Contains(shared.Name, "Kurban Bayramı 4. Gün")
+}
+
+func TestResolveClinicDay(t *testing.T) {
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	hours := []models.ClinicOpeningHours{
+		{Weekday: models.Monday, OpensAt: "13:00", ClosesAt: "17:00"},
+		{Weekday: models.Monday, OpensAt: "08:00", ClosesAt: "12:00"},
+		{Weekday: models.Tuesday, OpensAt: "00:00", ClosesAt: "24:00"},
+	}
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	t.Run("weekly hours", func(t *testing.T) {
+		resolved := services.ResolveClinicDay(day, hours, false, nil, nil)
+		assert.Equal(t, models.Monday, resolved.Weekday)
+		assert.Equal(t, []models.OpenPeriod{{Start: at(8, 0), End: at(12, 0)}, {Start: at(13, 0), End: at(17, 0)}}, resolved.Open)
+	})
+
+	t.Run("full day holiday", func(t *testing.T) {
+		resolved := services.ResolveClinicDay(day, hours, false, &models.HospitalHoliday{Name: "Bayram"}, nil)
+		assert.Empty(t, resolved.Open)
+	})
+
+	t.Run("clinic open on holidays", func(t *testing.T) {
+		resolved := services.ResolveClinicDay(day, hours, true, &models.HospitalHoliday{Name: "Bayram"}, nil)
+		assert.Len(t, resolved.Open, 2)
+	})
+
+	t.Run("half day holiday", func(t *testing.T) {
+		resolved := services.ResolveClinicDay(day, hours, false, &models.HospitalHoliday{Name: "Arife", ClosesAt: "13:00"}, nil)
+		assert.Equal(t, []models.OpenPeriod{{Start: at(8, 0), End: at(12, 0)}}, resolved.Open)
+	})
+
+	t.Run("closure splits a period", func(t *testing.T) {
+		resolved := services.ResolveClinicDay(day, hours, false, nil, []models.ClinicClosure{
+			{StartsAt: at(9, 0), EndsAt: at(10, 30)},
+		})
+		assert.Equal(t, []models.OpenPeriod{
+			{Start: at(8, 0), End: at(9, 0)},
+			{Start: at(10, 30), End: at(12, 0)},
+			{Start: at(13, 0), End: at(17, 0)},
+		}, resolved.Open)
+		assert.Len(t, resolved.Closures, 1)
+	})
+
+	t.Run("open all day", func(t *testing.T) {
+		resolved := services.ResolveClinicDay(day.AddDate(0, 0, 1), hours, false, nil, nil)
+		assert.Equal(t, []models.OpenPeriod{{Start: day.AddDate(0, 0, 1), End: day.AddDate(0, 0, 2)}}, resolved.Open)
+	})
+}
+
+func TestCalendarServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarServiceTestSuite))
+}
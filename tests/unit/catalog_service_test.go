@@ -0,0 +1,171 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type CatalogServiceTestSuite struct {
+	suite.Suite
+	containers     *helpers.TestContainers
+	catalogService *services.CatalogService
+	clinicService  *services.ClinicService
+	authService    *services.AuthService
+	hospitalID     uint
+	otherID        uint
+}
+
+func (suite *CatalogServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.catalogService = services.NewCatalogService(containers.DB, containers.Redis)
+	suite.clinicService = services.NewClinicService(containers.DB)
+}
+
+func (suite *CatalogServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *CatalogServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.containers.DB.Where("hospital_id IS NOT NULL").Delete(&models.ClinicType{}).Error)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	other, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.otherID = other.ID
+}
+
+func (suite *CatalogServiceTestSuite) TestPrivateClinicTypeVisibility() {
+	clinicType, err := suite.catalogService.CreateClinicType(&models.CatalogEntryRequest{Name: "Uyku Laboratuvarı"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(clinicType.HospitalID)
+
+	var global models.ClinicType
+	suite.Require().NoError(suite.containers.DB.Where("hospital_id IS NULL").First(&global).Error)
+	_, err = suite.catalogService.CreateClinicType(&models.CatalogEntryRequest{Name: global.Name}, suite.hospitalID)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeConflict, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+
+	otherTypes, err := suite.catalogService.GetClinicTypes(suite.otherID)
+	suite.Require().NoError(err)
+	for _, ct := range otherTypes {
+		suite.NotEqual(clinicType.ID, ct.ID)
+	}
+
+	_, err = suite.clinicService.CreateClinic(&models.CreateClinicRequest{ClinicTypeID: clinicType.ID}, suite.otherID)
+	suite.Error(err)
+
+	_, err = suite.clinicService.CreateClinic(&models.CreateClinicRequest{ClinicTypeID: clinicType.ID}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	err = suite.catalogService.DeleteClinicType(clinicType.ID, suite.hospitalID)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+}
+
+func (suite *CatalogServiceTestSuite) TestPromoteMergesDuplicates() {
+	first, err := suite.catalogService.CreateClinicType(&models.CatalogEntryRequest{Name: "Uyku Laboratuvarı"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	second, err := suite.catalogService.CreateClinicType(&models.CatalogEntryRequest{Name: "UYKU LABORATUVARI"}, suite.otherID)
+	suite.Require().NoError(err)
+
+	clinic, err := suite.clinicService.CreateClinic(&models.CreateClinicRequest{ClinicTypeID: second.ID}, suite.otherID)
+	suite.Require().NoError(err)
+
+	candidates, err := suite.catalogService.GetPromotionCandidates(2)
+	suite.Require().NoError(err)
+	suite.Require().Len(candidates, 1)
+	suite.Equal(models.CatalogClinicType, candidates[0].Kind)
+	suite.Equal(int64(2), candidates[0].Hospitals)
+
+	promoted, err := suite.catalogService.PromoteClinicType(first.ID)
+	suite.Require().NoError(err)
+	suite.Nil(promoted.HospitalID)
+
+	var moved models.Clinic
+	suite.Require().NoError(suite.containers.DB.First(&moved, clinic.ID).Error)
+	suite.Equal(promoted.ID, moved.ClinicTypeID)
+
+	var remaining int64
+	suite.containers.DB.Model(&models.ClinicType{}).Where("id = ?", second.ID).Count(&remaining)
+	suite.Equal(int64(0), remaining)
+
+	_, err = suite.catalogService.PromoteClinicType(first.ID)
+	suite.Error(err)
+}
+
+func (suite *CatalogServiceTestSuite) TestTitleInStaffHistoryCannotBeDeleted() {
+	var group models.ProfessionGroup
+	suite.Require().NoError(suite.containers.DB.Where("hospital_id IS NULL").First(&group).Error)
+	title, err := suite.catalogService.CreateTitle(&models.CreateTitleRequest{Name: "Uyku Teknisyeni", ProfessionGroupID: group.ID}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	// only a past version of some staff member still names the title
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.containers.DB.Model(&models.StaffVersion{}).
+		Where("staff_id = ?", staff.ID).
+		Updates(map[string]interface{}{"title_id": title.ID, "profession_group_id": group.ID}).Error)
+
+	err = suite.catalogService.DeleteTitle(title.ID, suite.hospitalID)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+}
+
+func (suite *CatalogServiceTestSuite) TestTitleInHeadcountSnapshotsCannotBeDeleted() {
+	var group models.ProfessionGroup
+	suite.Require().NoError(suite.containers.DB.Where("hospital_id IS NULL").First(&group).Error)
+	title, err := suite.catalogService.CreateTitle(&models.CreateTitleRequest{Name: "Uyku Teknisyeni", ProfessionGroupID: group.ID}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.containers.DB.Create(&models.HeadcountSnapshot{
+		Date:              time.Now(),
+		HospitalID:        suite.hospitalID,
+		ProfessionGroupID: group.ID,
+		TitleID:           title.ID,
+		Count:             1,
+	}).Error)
+
+	err = suite.catalogService.DeleteTitle(title.ID, suite.hospitalID)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+}
+
+func TestCatalogServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CatalogServiceTestSuite))
+}
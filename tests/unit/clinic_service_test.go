@@ -0,0 +1,219 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type ClinicServiceTestSuite struct {
+	suite.Suite
+	containers    *helpers.TestContainers
+	clinicService *services.ClinicService
+	staffService  *services.StaffService
+	authService   *services.AuthService
+	hospitalID    uint
+	clinicID      uint
+}
+
+func (suite *ClinicServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.clinicService = services.NewClinicService(containers.DB)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+}
+
+func (suite *ClinicServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *ClinicServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *ClinicServiceTestSuite) TestUpdateClinicHead() {
+	member, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	outsider, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	_, err = suite.clinicService.UpdateClinic(suite.clinicID, &models.UpdateClinicRequest{HeadStaffID: &outsider.ID}, suite.hospitalID)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+
+	clinic, err := suite.clinicService.UpdateClinic(suite.clinicID, &models.UpdateClinicRequest{
+		Name:           "Çocuk Acil",
+		Floor:          "2",
+		Building:       "B Blok",
+		PhoneExtension: "2140",
+		HeadStaffID:    &member.ID,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal("Çocuk Acil", clinic.DisplayName())
+	suite.Require().NotNil(clinic.HeadStaff)
+	suite.Equal(member.ID, clinic.HeadStaff.ID)
+
+	_, err = suite.staffService.TransferStaff(member.ID, &models.TransferStaffRequest{Reason: "left the clinic"}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+
+	clinic, err = suite.clinicService.GetClinic(suite.clinicID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Nil(clinic.HeadStaffID, "head of clinic is cleared when they leave")
+}
+
+func (suite *ClinicServiceTestSuite) TestRooms() {
+	exam, err := suite.clinicService.CreateRoom(suite.clinicID, &models.ClinicRoomRequest{Name: "Muayene 1", Kind: models.ClinicRoomExamination, Capacity: 1}, suite.hospitalID)
+	suite.Require().NoError(err)
+	_, err = suite.clinicService.CreateRoom(suite.clinicID, &models.ClinicRoomRequest{Name: "Servis", Kind: models.ClinicRoomWard, Capacity: 12}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	_, err = suite.clinicService.CreateRoom(suite.clinicID, &models.ClinicRoomRequest{Name: "Muayene 1", Kind: models.ClinicRoomDesk, Capacity: 1}, suite.hospitalID)
+	suite.Error(err, "room names are unique within a clinic")
+
+	_, err = suite.clinicService.UpdateRoom(suite.clinicID, exam.ID, &models.ClinicRoomRequest{Name: "Muayene 1", Kind: models.ClinicRoomExamination, Capacity: 2}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	page, err := suite.clinicService.GetClinics(&models.ClinicFilterRequest{}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(page.Data, 1)
+	suite.Len(page.Data[0].Rooms, 2)
+	suite.Equal(14, page.Data[0].TotalCapacity)
+
+	suite.Require().NoError(suite.clinicService.DeleteRoom(suite.clinicID, exam.ID, suite.hospitalID))
+	rooms, err := suite.clinicService.GetRooms(suite.clinicID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(rooms, 1)
+}
+
+func TestClinicServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ClinicServiceTestSuite))
+}
+
+// queryCounter is a gorm logger that counts the statements it is asked to
+// trace, which is every statement gorm sends to the database.
+type queryCounter struct {
+	logger.Interface
+	queries atomic.Int64
+}
+
+func (q *queryCounter) LogMode(logger.LogLevel) logger.Interface {
+	return q
+}
+
+func (q *queryCounter) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	q.queries.Add(1)
+}
+
+// maxClinicListQueries is the count, the clinics, the clinic type, head of
+// clinic, head title and room preloads, and the staff aggregate.
+const maxClinicListQueries = 7
+
+// createPopulatedClinics creates a clinic for each of up to 20 global clinic
+// types the hospital has no clinic of yet, each with a room, two staff and a
+// head of clinic, and returns how many it created.
+func createPopulatedClinics(db *gorm.DB, hospitalID uint) (int, error) {
+	var clinicTypes []models.ClinicType
+	err := db.Where("hospital_id IS NULL AND id NOT IN (?)", db.Model(&models.Clinic{}).Select("clinic_type_id").Where("hospital_id = ?", hospitalID)).
+		Order("id").
+		Limit(20).
+		Find(&clinicTypes).Error
+	if err != nil {
+		return 0, err
+	}
+
+	clinicService := services.NewClinicService(db)
+	for i, clinicType := range clinicTypes {
+		clinic, err := clinicService.CreateClinic(&models.CreateClinicRequest{ClinicTypeID: clinicType.ID}, hospitalID)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := clinicService.CreateRoom(clinic.ID, &models.ClinicRoomRequest{Name: fmt.Sprintf("Muayene %d", i), Kind: models.ClinicRoomExamination, Capacity: 1}, hospitalID); err != nil {
+			return 0, err
+		}
+		head, err := helpers.CreateTestStaff(db, hospitalID, &clinic.ID)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := helpers.CreateTestStaff(db, hospitalID, &clinic.ID); err != nil {
+			return 0, err
+		}
+		if _, err := clinicService.UpdateClinic(clinic.ID, &models.UpdateClinicRequest{HeadStaffID: &head.ID}, hospitalID); err != nil {
+			return 0, err
+		}
+	}
+	return len(clinicTypes), nil
+}
+
+// TestGetClinicsQueryCount fails if the number of queries needed to list a
+// page of fully populated clinics grows with the number of clinics.
+func (suite *ClinicServiceTestSuite) TestGetClinicsQueryCount() {
+	created, err := createPopulatedClinics(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	counter := &queryCounter{Interface: logger.Discard}
+	counted := services.NewClinicService(suite.containers.DB.Session(&gorm.Session{Logger: counter}))
+
+	page, err := counted.GetClinics(&models.ClinicFilterRequest{Limit: 100}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(page.Data, created+1)
+	suite.LessOrEqual(counter.queries.Load(), int64(maxClinicListQueries))
+}
+
+// BenchmarkGetClinics times listing a page of fully populated clinics. The
+// containers are set up once; only the sub-benchmark is run b.N times.
+func BenchmarkGetClinics(b *testing.B) {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = containers.Cleanup(ctx) }()
+
+	authService := services.NewAuthService(containers.DB, containers.Config)
+	hospital, _, _, err := helpers.CreateTestHospital(containers.DB, authService)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := createPopulatedClinics(containers.DB, hospital.ID); err != nil {
+		b.Fatal(err)
+	}
+
+	clinicService := services.NewClinicService(containers.DB)
+	b.Run("page", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := clinicService.GetClinics(&models.ClinicFilterRequest{Limit: 100}, hospital.ID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type recordingNotifier struct {
+	mu            sync.Mutex
+	notifications []services.Notification
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, notification services.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+type CredentialServiceTestSuite struct {
+	suite.Suite
+	containers        *helpers.TestContainers
+	credentialService *services.CredentialService
+	authService       *services.AuthService
+	notifier          *recordingNotifier
+	hospitalID        uint
+	staffID           uint
+}
+
+func (suite *CredentialServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+}
+
+func (suite *CredentialServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *CredentialServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	suite.notifier = &recordingNotifier{}
+	suite.credentialService = services.NewCredentialService(suite.containers.DB, suite.notifier, suite.containers.Config)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	suite.staffID = staff.ID
+}
+
+func (suite *CredentialServiceTestSuite) credentialRequest(expiresAt *time.Time) *models.CredentialRequest {
+	return &models.CredentialRequest{
+		Type:        models.CredentialCertification,
+		Name:        "ACLS",
+		Number:      "ACLS-2024-001",
+		IssuingBody: "Türk Kardiyoloji Derneği",
+		IssuedAt:    time.Now().AddDate(-2, 0, 0),
+		ExpiresAt:   expiresAt,
+		Mandatory:   true,
+	}
+}
+
+func (suite *CredentialServiceTestSuite) TestCreateAndListCredentials() {
+	expiresAt := time.Now().AddDate(0, 0, 10)
+	credential, err := suite.credentialService.CreateCredential(suite.staffID, suite.credentialRequest(&expiresAt), suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(models.CredentialExpiringSoon, credential.Status)
+
+	diploma := suite.credentialRequest(nil)
+	diploma.Type = models.CredentialDiplomaRegistration
+	_, err = suite.credentialService.CreateCredential(suite.staffID, diploma, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	credentials, err := suite.credentialService.GetCredentials(suite.staffID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(credentials, 2)
+	suite.Equal(credential.ID, credentials[0].ID)
+	suite.Equal(models.CredentialValid, credentials[1].Status)
+
+	expiring, err := suite.credentialService.GetExpiringCredentials(&models.ExpiringCredentialFilterRequest{Days: 30}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(expiring, 1)
+}
+
+func (suite *CredentialServiceTestSuite) TestExpiryBeforeIssueDate() {
+	expiresAt := time.Now().AddDate(-3, 0, 0)
+	_, err := suite.credentialService.CreateCredential(suite.staffID, suite.credentialRequest(&expiresAt), suite.hospitalID)
+	suite.Error(err)
+}
+
+func (suite *CredentialServiceTestSuite) TestCheckExpiryNotifiesOnce() {
+	soon := time.Now().AddDate(0, 0, 5)
+	expired := time.Now().AddDate(0, 0, -1)
+
+	renewed, err := suite.credentialService.CreateCredential(suite.staffID, suite.credentialRequest(&soon), suite.hospitalID)
+	suite.Require().NoError(err)
+	_, err = suite.credentialService.CreateCredential(suite.staffID, suite.credentialRequest(&expired), suite.hospitalID)
+	suite.Require().NoError(err)
+
+	sent, err := suite.credentialService.CheckExpiry(context.Background(), time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(2, sent)
+
+	types := map[string]bool{}
+	for _, notification := range suite.notifier.notifications {
+		suite.Equal(suite.hospitalID, notification.HospitalID)
+		types[notification.Type] = true
+	}
+	suite.True(types[services.NotificationCredentialExpiringSoon])
+	suite.True(types[services.NotificationCredentialExpired])
+
+	sent, err = suite.credentialService.CheckExpiry(context.Background(), time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(0, sent)
+
+	// the expiring credential expires and is reported again as expired
+	sent, err = suite.credentialService.CheckExpiry(context.Background(), time.Now().AddDate(0, 0, 6))
+	suite.Require().NoError(err)
+	suite.Equal(1, sent)
+
+	renewedExpiry := time.Now().AddDate(0, 0, 20)
+	_, err = suite.credentialService.UpdateCredential(suite.staffID, renewed.ID, suite.credentialRequest(&renewedExpiry), suite.hospitalID)
+	suite.Require().NoError(err)
+
+	sent, err = suite.credentialService.CheckExpiry(context.Background(), time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(1, sent)
+}
+
+func (suite *CredentialServiceTestSuite) TestBlockAssignmentWithExpiredMandatoryCredential() {
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	expired := time.Now().AddDate(0, 0, -1)
+	_, err = suite.credentialService.CreateCredential(suite.staffID, suite.credentialRequest(&expired), suite.hospitalID)
+	suite.Require().NoError(err)
+
+	staffService := services.NewStaffService(suite.containers.DB, suite.containers.Redis)
+	staffService.SetBlockExpiredCredentials(true)
+
+	_, err = staffService.TransferStaff(suite.staffID, &models.TransferStaffRequest{ClinicID: &clinic.ID, Reason: "new post"}, suite.hospitalID, 0)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+
+	staffService.SetBlockExpiredCredentials(false)
+	_, err = staffService.TransferStaff(suite.staffID, &models.TransferStaffRequest{ClinicID: &clinic.ID, Reason: "new post"}, suite.hospitalID, 0)
+	suite.NoError(err)
+}
+
+func TestCredentialStatusAt(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 15, 0, 0, 0, time.UTC)
+	date := func(days int) *time.Time {
+		d := time.Date(2026, time.March, 10+days, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	assert.Equal(t, models.CredentialValid, services.CredentialStatusAt(&models.StaffCredential{}, now, 30))
+	assert.Equal(t, models.CredentialValid, services.CredentialStatusAt(&models.StaffCredential{ExpiresAt: date(31)}, now, 30))
+	assert.Equal(t, models.CredentialExpiringSoon, services.CredentialStatusAt(&models.StaffCredential{ExpiresAt: date(30)}, now, 30))
+	assert.Equal(t, models.CredentialExpiringSoon, services.CredentialStatusAt(&models.StaffCredential{ExpiresAt: date(0)}, now, 30))
+	assert.Equal(t, models.CredentialExpired, services.CredentialStatusAt(&models.StaffCredential{ExpiresAt: date(-1)}, now, 30))
+}
+
+func TestCredentialServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CredentialServiceTestSuite))
+}
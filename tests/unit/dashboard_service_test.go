@@ -0,0 +1,176 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DashboardServiceTestSuite struct {
+	suite.Suite
+	containers        *helpers.TestContainers
+	dashboardService  *services.DashboardService
+	employmentService *services.EmploymentService
+	authService       *services.AuthService
+	hospitalID        uint
+	clinicID          uint
+}
+
+func (suite *DashboardServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.dashboardService = services.NewDashboardService(containers.DB, containers.Redis)
+	suite.employmentService = services.NewEmploymentService(containers.DB)
+	suite.Require().NoError(services.EnableAuditLog(containers.DB))
+}
+
+func (suite *DashboardServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *DashboardServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.containers.Redis.FlushDB(context.Background()).Err())
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *DashboardServiceTestSuite) TestDashboardCounts() {
+	doctor, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	_, err = helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	_, err = helpers.CreateTestUser(suite.containers.DB, suite.authService, suite.hospitalID, models.UserTypeEmployee)
+	suite.Require().NoError(err)
+
+	dashboard, err := suite.dashboardService.GetDashboard(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), dashboard.Headcount)
+	suite.Require().Len(dashboard.ByClinic, 1)
+	suite.Equal(int64(1), dashboard.ByClinic[0].Count)
+	suite.Equal(int64(1), dashboard.ByClinic[0].Doctors)
+	suite.Empty(dashboard.ClinicsWithoutDoctors)
+	suite.Len(dashboard.UnassignedStaff, 1)
+	suite.Equal(int64(2), dashboard.StaffChanges.Added)
+	suite.Equal(int64(0), dashboard.StaffChanges.Removed)
+	suite.Equal(int64(1), dashboard.UsersByType[models.UserTypeAuthorized])
+	suite.Equal(int64(1), dashboard.UsersByType[models.UserTypeEmployee])
+
+	_, err = suite.employmentService.ChangeStatus(doctor.ID, &models.ChangeStaffStatusRequest{Status: models.StaffTerminated, Reason: "resigned"}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+
+	dashboard, err = suite.dashboardService.GetDashboard(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), dashboard.Headcount)
+	suite.Require().Len(dashboard.ClinicsWithoutDoctors, 1)
+	suite.Equal(suite.clinicID, dashboard.ClinicsWithoutDoctors[0].ClinicID)
+	suite.Equal(int64(1), dashboard.StaffChanges.Removed)
+}
+
+func (suite *DashboardServiceTestSuite) cached(hospitalID uint) bool {
+	exists, err := suite.containers.Redis.Exists(context.Background(), fmt.Sprintf("dashboard:%d", hospitalID)).Result()
+	suite.Require().NoError(err)
+	return exists == 1
+}
+
+func (suite *DashboardServiceTestSuite) TestChangesInvalidateCache() {
+	ctx := context.Background()
+	other, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+
+	// the first pass has no cursor yet
+	_, err = suite.dashboardService.Invalidate(ctx)
+	suite.Require().NoError(err)
+
+	_, err = suite.dashboardService.GetDashboard(suite.hospitalID)
+	suite.Require().NoError(err)
+	_, err = suite.dashboardService.GetDashboard(other.ID)
+	suite.Require().NoError(err)
+	suite.True(suite.cached(suite.hospitalID))
+
+	// a change that has not committed yet leaves the cache alone
+	err = suite.containers.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := helpers.CreateTestStaff(tx, suite.hospitalID, &suite.clinicID); err != nil {
+			return err
+		}
+		_, err := suite.dashboardService.Invalidate(ctx)
+		suite.Require().NoError(err)
+		suite.True(suite.cached(suite.hospitalID))
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.dashboardService.Invalidate(ctx)
+	suite.Require().NoError(err)
+	suite.False(suite.cached(suite.hospitalID))
+	suite.True(suite.cached(other.ID), "only the changed hospital is dropped")
+
+	dashboard, err := suite.dashboardService.GetDashboard(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), dashboard.Headcount)
+
+	// unscoped model updates still name the hospital of the rows they touch
+	suite.Require().NoError(suite.containers.DB.Unscoped().Model(&models.Clinic{}).Where("id = ?", suite.clinicID).Update("floor", "3").Error)
+	_, err = suite.dashboardService.Invalidate(ctx)
+	suite.Require().NoError(err)
+	suite.False(suite.cached(suite.hospitalID))
+	suite.True(suite.cached(other.ID))
+}
+
+func (suite *DashboardServiceTestSuite) TestBuildRacingInvalidationIsNotCached() {
+	ctx := context.Background()
+	_, err := suite.dashboardService.Invalidate(ctx)
+	suite.Require().NoError(err)
+
+	// hold the build at its last query, counting users, after it has read
+	// the clinics
+	block := suite.containers.DB.Begin()
+	suite.Require().NoError(block.Exec("LOCK TABLE users IN ACCESS EXCLUSIVE MODE").Error)
+	built := make(chan error, 1)
+	go func() {
+		_, err := suite.dashboardService.GetDashboard(suite.hospitalID)
+		built <- err
+	}()
+	suite.Eventually(func() bool {
+		var waiting int64
+		suite.containers.DB.Raw("SELECT COUNT(*) FROM pg_locks WHERE NOT granted").Scan(&waiting)
+		return waiting > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	suite.Require().NoError(suite.containers.DB.Model(&models.Clinic{}).Where("id = ?", suite.clinicID).Update("floor", "2").Error)
+	_, err = suite.dashboardService.Invalidate(ctx)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(block.Rollback().Error)
+	suite.Require().NoError(<-built)
+	suite.False(suite.cached(suite.hospitalID), "a dashboard built before the invalidation is not cached")
+
+	_, err = suite.dashboardService.GetDashboard(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.True(suite.cached(suite.hospitalID))
+}
+
+func TestDashboardServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(DashboardServiceTestSuite))
+}
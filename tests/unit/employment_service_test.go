@@ -0,0 +1,243 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmploymentServiceTestSuite struct {
+	suite.Suite
+	containers        *helpers.TestContainers
+	employmentService *services.EmploymentService
+	staffService      *services.StaffService
+	authService       *services.AuthService
+	hospitalID        uint
+	clinicID          uint
+}
+
+func (suite *EmploymentServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.employmentService = services.NewEmploymentService(containers.DB)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+}
+
+func (suite *EmploymentServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *EmploymentServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *EmploymentServiceTestSuite) TestCreateOnboardingStaffWithContract() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffActive, staff.Status)
+
+	contractEnd := time.Now().AddDate(1, 0, 0)
+	locum, err := suite.staffService.CreateStaff(&models.CreateStaffRequest{
+		FirstName:         "Deniz",
+		LastName:          "Yıldız",
+		NationalID:        "20000000001",
+		Phone:             "+905550000101",
+		ProfessionGroupID: staff.ProfessionGroupID,
+		TitleID:           staff.TitleID,
+		WorkingDays:       []models.WorkingDay{models.Monday},
+		Status:            models.StaffOnboarding,
+		Employment: &models.EmploymentRequest{
+			ContractType:   models.ContractLocum,
+			HireDate:       time.Now(),
+			ContractEndsAt: &contractEnd,
+		},
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffOnboarding, locum.Status)
+
+	employment, err := suite.employmentService.GetEmployment(locum.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(employment.Employments, 1)
+	suite.Equal(models.ContractLocum, employment.Employments[0].ContractType)
+
+	_, err = suite.staffService.CreateStaff(&models.CreateStaffRequest{
+		FirstName:         "Ece",
+		LastName:          "Kaya",
+		NationalID:        "20000000002",
+		Phone:             "+905550000102",
+		ProfessionGroupID: staff.ProfessionGroupID,
+		TitleID:           staff.TitleID,
+		Employment:        &models.EmploymentRequest{ContractType: models.ContractContracted, HireDate: time.Now()},
+	}, suite.hospitalID)
+	suite.Error(err)
+}
+
+func (suite *EmploymentServiceTestSuite) TestInvalidTransition() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	_, err = suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{Status: models.StaffOnboarding}, suite.hospitalID, 0)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+
+	_, err = suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{Status: models.StaffTerminated}, suite.hospitalID, 0)
+	suite.Error(err, "termination requires a reason")
+}
+
+func (suite *EmploymentServiceTestSuite) TestTerminateKeepsStaffQueryable() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	_, err = suite.employmentService.UpdateEmployment(staff.ID, &models.EmploymentRequest{
+		ContractType: models.ContractPermanent,
+		HireDate:     time.Now().AddDate(-2, 0, 0),
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	onLeave, err := suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{Status: models.StaffOnLeave, Reason: "maternity leave"}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffOnLeave, onLeave.Status)
+	suite.NotNil(onLeave.ClinicID)
+
+	terminated, err := suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{Status: models.StaffTerminated, Reason: "resigned"}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffTerminated, terminated.Status)
+	suite.Nil(terminated.ClinicID)
+
+	result, err := suite.staffService.GetStaff(&models.StaffFilterRequest{}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), result.TotalCount)
+
+	result, err = suite.staffService.GetStaff(&models.StaffFilterRequest{Status: "terminated"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), result.TotalCount)
+
+	_, err = suite.staffService.GetStaff(&models.StaffFilterRequest{Status: "retired"}, suite.hospitalID)
+	suite.Error(err)
+
+	_, err = suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{ClinicID: &suite.clinicID, Reason: "back"}, suite.hospitalID, 0)
+	suite.Error(err)
+
+	employment, err := suite.employmentService.GetEmployment(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(employment.Employments, 1)
+	suite.NotNil(employment.Employments[0].TerminatedAt)
+	suite.Equal("resigned", employment.Employments[0].TerminationReason)
+	suite.Len(employment.StatusChanges, 2)
+
+	rehired, err := suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{
+		Status:     models.StaffOnboarding,
+		Reason:     "rehired",
+		Employment: &models.EmploymentRequest{ContractType: models.ContractPermanent, HireDate: time.Now()},
+	}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffOnboarding, rehired.Status)
+
+	employment, err = suite.employmentService.GetEmployment(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(employment.Employments, 2)
+}
+
+func (suite *EmploymentServiceTestSuite) TestBackdatedStatusChangeKeepsHistoryInOrder() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	// the clinic assignment opened when the staff member was created
+	beforeAssignment := time.Now().Add(-time.Hour)
+	_, err = suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{
+		Status:      models.StaffOnLeave,
+		Reason:      "annual leave",
+		EffectiveAt: &beforeAssignment,
+	}, suite.hospitalID, 0)
+	suite.assertValidationError(err, "effective_at")
+
+	onLeaveAt := time.Now().Add(-time.Minute)
+	_, err = suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{
+		Status:      models.StaffOnLeave,
+		Reason:      "annual leave",
+		EffectiveAt: &onLeaveAt,
+	}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+
+	beforeLeave := onLeaveAt.Add(-time.Second)
+	_, err = suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{
+		Status:      models.StaffActive,
+		EffectiveAt: &beforeLeave,
+	}, suite.hospitalID, 0)
+	suite.assertValidationError(err, "effective_at")
+
+	afterLeave := onLeaveAt.Add(time.Second)
+	_, err = suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{
+		Status:      models.StaffActive,
+		EffectiveAt: &afterLeave,
+	}, suite.hospitalID, 0)
+	suite.NoError(err)
+}
+
+func (suite *EmploymentServiceTestSuite) assertValidationError(err error, field string) {
+	suite.Require().Error(err)
+	appErr, ok := errors.IsAppError(err)
+	suite.Require().True(ok, "expected AppError, got %T", err)
+	suite.Equal(errors.ErrCodeValidation, appErr.Code)
+	suite.Equal(field, appErr.Context["field"])
+}
+
+func (suite *EmploymentServiceTestSuite) TestConcurrentTerminationsRecordOneChange() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.employmentService.ChangeStatus(staff.ID, &models.ChangeStaffStatusRequest{
+				Status: models.StaffTerminated,
+				Reason: "resigned",
+			}, suite.hospitalID, 0)
+			if err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.Equal(int32(1), succeeded.Load())
+	employment, err := suite.employmentService.GetEmployment(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffTerminated, employment.Status)
+	suite.Len(employment.StatusChanges, 1)
+}
+
+func TestEmploymentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(EmploymentServiceTestSuite))
+}
@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventServiceTestSuite struct {
+	suite.Suite
+	containers   *helpers.TestContainers
+	eventService *services.EventService
+	staffService *services.StaffService
+	authService  *services.AuthService
+	hospitalID   uint
+}
+
+func (suite *EventServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+	suite.eventService = services.NewEventService(containers.DB, containers.Redis)
+	suite.Require().NoError(services.EnableAuditLog(containers.DB))
+}
+
+func (suite *EventServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *EventServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.containers.Redis.FlushDB(context.Background()).Err())
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+}
+
+func (suite *EventServiceTestSuite) receive(events <-chan models.DomainEvent) models.DomainEvent {
+	select {
+	case event, ok := <-events:
+		suite.Require().True(ok, "subscription closed")
+		return event
+	case <-time.After(5 * time.Second):
+		suite.FailNow("no event received")
+		return models.DomainEvent{}
+	}
+}
+
+func (suite *EventServiceTestSuite) TestRelayFansOutToHospitalSubscribers() {
+	ctx := context.Background()
+	events, unsubscribe, err := suite.eventService.Subscribe(ctx, suite.hospitalID)
+	suite.Require().NoError(err)
+	defer unsubscribe()
+
+	otherHospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	otherEvents, unsubscribeOther, err := suite.eventService.Subscribe(ctx, otherHospital.ID)
+	suite.Require().NoError(err)
+	defer unsubscribeOther()
+
+	// the first run only places the cursor at the end of the log
+	relayed, err := suite.eventService.Relay(ctx)
+	suite.Require().NoError(err)
+	suite.Zero(relayed)
+
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	relayed, err = suite.eventService.Relay(ctx)
+	suite.Require().NoError(err)
+	suite.Equal(1, relayed)
+
+	event := suite.receive(events)
+	suite.Equal("staff.created", event.Type)
+	suite.Equal(staff.ID, event.ResourceID)
+	suite.Equal(suite.hospitalID, event.HospitalID)
+
+	select {
+	case event := <-otherEvents:
+		suite.Failf("event leaked to another hospital", "%+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// nothing new
+	relayed, err = suite.eventService.Relay(ctx)
+	suite.Require().NoError(err)
+	suite.Zero(relayed)
+}
+
+func (suite *EventServiceTestSuite) TestEventsSinceResumesAfterLastEvent() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	all, err := suite.eventService.EventsSince(suite.hospitalID, 0, 100)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(all)
+	last := all[len(all)-1]
+
+	_, err = suite.staffService.UpdateStaff(staff.ID, &models.UpdateStaffRequest{FirstName: "Güneş"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(staff.ID, suite.hospitalID))
+
+	missed, err := suite.eventService.EventsSince(suite.hospitalID, last.ID, 100)
+	suite.Require().NoError(err)
+	suite.Require().Len(missed, 2)
+	suite.Equal("staff.updated", missed[0].Type)
+	suite.Equal("staff.deleted", missed[1].Type)
+	suite.Less(missed[0].ID, missed[1].ID)
+	for _, event := range missed {
+		suite.Equal(staff.ID, event.ResourceID)
+	}
+}
+
+func TestEventServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(EventServiceTestSuite))
+}
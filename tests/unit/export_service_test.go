@@ -0,0 +1,159 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExportServiceTestSuite struct {
+	suite.Suite
+	containers    *helpers.TestContainers
+	exportService *services.ExportService
+	authService   *services.AuthService
+	hospitalID    uint
+	clinicID      uint
+}
+
+func (suite *ExportServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.exportService = services.NewExportService(containers.DB, services.NewClinicService(containers.DB))
+}
+
+func (suite *ExportServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *ExportServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+
+	_, err = helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &clinic.ID)
+	suite.Require().NoError(err)
+	_, err = helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+}
+
+func (suite *ExportServiceTestSuite) TestExportStaffCSVWithColumns() {
+	req := &models.StaffExportRequest{Format: services.ExportFormatCSV, Columns: "first_name,clinic"}
+	req.ClinicID = strconv.FormatUint(uint64(suite.clinicID), 10)
+
+	var buf bytes.Buffer
+	suite.Require().NoError(suite.exportService.ExportStaff(&buf, req, suite.hospitalID))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 2)
+	suite.Equal([]string{"First Name", "Clinic"}, records[0])
+	suite.NotEmpty(records[1][1])
+}
+
+func (suite *ExportServiceTestSuite) TestExportStaffUsesClinicName() {
+	err := suite.containers.DB.Model(&models.Clinic{}).Where("id = ?", suite.clinicID).Update("name", "Kalp Merkezi").Error
+	suite.Require().NoError(err)
+
+	req := &models.StaffExportRequest{Format: services.ExportFormatCSV, Columns: "clinic"}
+	req.ClinicID = strconv.FormatUint(uint64(suite.clinicID), 10)
+
+	var buf bytes.Buffer
+	suite.Require().NoError(suite.exportService.ExportStaff(&buf, req, suite.hospitalID))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 2)
+	suite.Equal("Kalp Merkezi", records[1][0])
+}
+
+func (suite *ExportServiceTestSuite) TestExportStaffXLSX() {
+	req := &models.StaffExportRequest{Format: services.ExportFormatXLSX}
+
+	var buf bytes.Buffer
+	suite.Require().NoError(suite.exportService.ExportStaff(&buf, req, suite.hospitalID))
+
+	file, err := excelize.OpenReader(&buf)
+	suite.Require().NoError(err)
+	defer func() { _ = file.Close() }()
+
+	rows, err := file.GetRows(file.GetSheetName(0))
+	suite.Require().NoError(err)
+	suite.Len(rows, 3)
+}
+
+func (suite *ExportServiceTestSuite) TestExportEscapesFormulas() {
+	err := suite.containers.DB.Model(&models.Staff{}).Where("clinic_id = ?", suite.clinicID).
+		Updates(map[string]interface{}{"first_name": `=HYPERLINK("http://evil.example","x")`, "last_name": "@SUM(A1)", "phone": "+905550000001"}).Error
+	suite.Require().NoError(err)
+
+	expected := []string{`'=HYPERLINK("http://evil.example","x")`, "'@SUM(A1)", "'+905550000001"}
+	for _, format := range []string{services.ExportFormatCSV, services.ExportFormatXLSX} {
+		req := &models.StaffExportRequest{Format: format, Columns: "first_name,last_name,phone"}
+		req.ClinicID = strconv.FormatUint(uint64(suite.clinicID), 10)
+
+		var buf bytes.Buffer
+		suite.Require().NoError(suite.exportService.ExportStaff(&buf, req, suite.hospitalID))
+
+		var records [][]string
+		if format == services.ExportFormatCSV {
+			records, err = csv.NewReader(&buf).ReadAll()
+			suite.Require().NoError(err)
+		} else {
+			file, err := excelize.OpenReader(&buf)
+			suite.Require().NoError(err)
+			records, err = file.GetRows(file.GetSheetName(0))
+			_ = file.Close()
+			suite.Require().NoError(err)
+		}
+		suite.Require().Len(records, 2, format)
+		suite.Equal(expected, records[1], format)
+	}
+}
+
+func (suite *ExportServiceTestSuite) TestExportStaffUnknownColumn() {
+	err := suite.exportService.ValidateStaffExport(&models.StaffExportRequest{Format: services.ExportFormatCSV, Columns: "password"})
+	suite.Error(err)
+}
+
+func (suite *ExportServiceTestSuite) TestStaffingReportPDF() {
+	var buf bytes.Buffer
+	err := suite.exportService.WriteStaffingReport(&buf, &models.StaffFilterRequest{}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.True(bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+}
+
+func (suite *ExportServiceTestSuite) TestExportClinicsCSV() {
+	var buf bytes.Buffer
+	suite.Require().NoError(suite.exportService.ExportClinics(&buf, services.ExportFormatCSV, suite.hospitalID))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 2)
+	suite.Equal("1", records[1][2])
+}
+
+func TestExportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportServiceTestSuite))
+}
@@ -272,6 +272,59 @@ func (suite *HospitalServiceTestSuite) TestRegisterHospitalInvalidProvinceDistri
 	}
 }
 
+func (suite *HospitalServiceTestSuite) TestRegisterHospitalWithNeighbourhood() {
+	db := suite.containers.DB
+	var district models.District
+	suite.Require().NoError(db.Joins("JOIN provinces ON provinces.id = districts.province_id").
+		Where("provinces.plate_code = ? AND districts.name = ?", 34, "Kadıköy").First(&district).Error)
+	var neighbourhood, elsewhere models.Neighbourhood
+	suite.Require().NoError(db.Where("district_id = ? AND name = ?", district.ID, "Caferağa").First(&neighbourhood).Error)
+	suite.Require().NoError(db.Where("district_id <> ?", district.ID).First(&elsewhere).Error)
+
+	latitude, longitude := 40.9869, 29.0259
+	request := func() *models.HospitalRegistrationRequest {
+		return &models.HospitalRegistrationRequest{
+			HospitalName:    faker.Name(),
+			TaxID:           faker.UUIDDigit(),
+			Email:           faker.Email(),
+			Phone:           faker.Phonenumber(),
+			ProvinceID:      district.ProvinceID,
+			DistrictID:      district.ID,
+			NeighbourhoodID: &neighbourhood.ID,
+			Address:         faker.Sentence(),
+			Latitude:        &latitude,
+			Longitude:       &longitude,
+			FirstName:       faker.FirstName(),
+			LastName:        faker.LastName(),
+			NationalID:      faker.UUIDDigit(),
+			UserEmail:       faker.Email(),
+			UserPhone:       faker.Phonenumber(),
+			Password:        faker.Password(),
+		}
+	}
+
+	hospital, _, err := suite.hospitalService.RegisterHospital(request())
+	suite.Require().NoError(err)
+	suite.Require().NotNil(hospital.Neighbourhood)
+	suite.Equal("Caferağa", hospital.Neighbourhood.Name)
+	suite.Require().NotNil(hospital.Latitude)
+	suite.InDelta(latitude, *hospital.Latitude, 1e-9)
+
+	wrongDistrict := request()
+	wrongDistrict.NeighbourhoodID = &elsewhere.ID
+	_, _, err = suite.hospitalService.RegisterHospital(wrongDistrict)
+	appErr, ok := errors.IsAppError(err)
+	suite.Require().True(ok)
+	suite.Equal(errors.ErrCodeValidation, appErr.Code)
+
+	halfPosition := request()
+	halfPosition.Longitude = nil
+	_, _, err = suite.hospitalService.RegisterHospital(halfPosition)
+	appErr, ok = errors.IsAppError(err)
+	suite.Require().True(ok)
+	suite.Equal(errors.ErrCodeValidation, appErr.Code)
+}
+
 func TestHospitalServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(HospitalServiceTestSuite))
 }
@@ -0,0 +1,225 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobServiceTestSuite struct {
+	suite.Suite
+	containers *helpers.TestContainers
+	jobService *services.JobService
+	calls      int
+	failUntil  int
+}
+
+func (suite *JobServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+	suite.containers = containers
+}
+
+func (suite *JobServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *JobServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.containers.Redis.FlushDB(context.Background()).Err())
+
+	cfg := *suite.containers.Config
+	cfg.Jobs.MaxAttempts = 2
+	cfg.Jobs.BackoffSeconds = 60
+	cfg.Jobs.PasswordResetPurgeSchedule = ""
+	cfg.Jobs.CacheWarmSchedule = ""
+	suite.jobService = services.NewJobService(suite.containers.DB, suite.containers.Redis, &cfg)
+
+	suite.calls = 0
+	suite.failUntil = 0
+	suite.jobService.Register("test-job", func(_ context.Context, _ []byte) error {
+		suite.calls++
+		if suite.calls <= suite.failUntil {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	suite.Require().NoError(suite.jobService.Schedule("test-job", "0 * * * *"))
+}
+
+func (suite *JobServiceTestSuite) run(id uint) models.JobRun {
+	var run models.JobRun
+	suite.Require().NoError(suite.containers.DB.First(&run, id).Error)
+	return run
+}
+
+func (suite *JobServiceTestSuite) TestProcessRunsJobOnce() {
+	ctx := context.Background()
+	queued, err := suite.jobService.Enqueue(ctx, "test-job", []byte(`{"hospital_id":1}`))
+	suite.Require().NoError(err)
+
+	claimed, err := suite.jobService.Process(ctx, queued.ID)
+	suite.Require().NoError(err)
+	suite.True(claimed)
+
+	// a duplicate message for the same run is ignored
+	claimed, err = suite.jobService.Process(ctx, queued.ID)
+	suite.Require().NoError(err)
+	suite.False(claimed)
+
+	run := suite.run(queued.ID)
+	suite.Equal(models.JobSucceeded, run.Status)
+	suite.Equal(1, run.Attempts)
+	suite.NotNil(run.FinishedAt)
+	suite.Equal(1, suite.calls)
+
+	_, err = suite.jobService.Enqueue(ctx, "unknown-job", nil)
+	suite.Error(err)
+}
+
+func (suite *JobServiceTestSuite) TestFailedRunsRetryThenFail() {
+	ctx := context.Background()
+	suite.failUntil = 10
+	queued, err := suite.jobService.Enqueue(ctx, "test-job", nil)
+	suite.Require().NoError(err)
+
+	_, err = suite.jobService.Process(ctx, queued.ID)
+	suite.Error(err)
+	run := suite.run(queued.ID)
+	suite.Equal(models.JobQueued, run.Status)
+	suite.Equal("temporary failure", run.LastError)
+	suite.True(run.RunAt.After(time.Now().Add(50 * time.Second)))
+
+	// not due yet, then due after the backoff
+	promoted, err := suite.jobService.PromoteDue(ctx, time.Now())
+	suite.Require().NoError(err)
+	suite.Zero(promoted)
+	promoted, err = suite.jobService.PromoteDue(ctx, time.Now().Add(2*time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal(1, promoted)
+
+	_, err = suite.jobService.Process(ctx, queued.ID)
+	suite.Error(err)
+	run = suite.run(queued.ID)
+	suite.Equal(models.JobFailed, run.Status)
+	suite.Equal(2, run.Attempts)
+
+	failed, err := suite.jobService.GetRuns(&models.JobRunFilterRequest{Status: models.JobFailed})
+	suite.Require().NoError(err)
+	suite.Require().Len(failed.Data, 1)
+	suite.Equal(queued.ID, failed.Data[0].ID)
+}
+
+func (suite *JobServiceTestSuite) TestTickQueuesEachOccurrenceOnce() {
+	ctx := context.Background()
+	start := time.Date(2026, time.March, 10, 9, 30, 0, 0, time.UTC)
+
+	// the first tick only records the time
+	queued, err := suite.jobService.Tick(ctx, "test-job", start)
+	suite.Require().NoError(err)
+	suite.False(queued)
+
+	queued, err = suite.jobService.Tick(ctx, "test-job", start.Add(20*time.Minute))
+	suite.Require().NoError(err)
+	suite.False(queued)
+
+	// 10:00 and 11:00 were missed; one run is queued for 11:00
+	queued, err = suite.jobService.Tick(ctx, "test-job", start.Add(2*time.Hour))
+	suite.Require().NoError(err)
+	suite.True(queued)
+
+	queued, err = suite.jobService.Tick(ctx, "test-job", start.Add(2*time.Hour+time.Minute))
+	suite.Require().NoError(err)
+	suite.False(queued)
+
+	runs, err := suite.jobService.GetRuns(&models.JobRunFilterRequest{Name: "test-job"})
+	suite.Require().NoError(err)
+	suite.Require().Len(runs.Data, 1)
+	suite.Require().NotNil(runs.Data[0].ScheduledFor)
+	suite.True(runs.Data[0].ScheduledFor.Equal(time.Date(2026, time.March, 10, 11, 0, 0, 0, time.UTC)))
+}
+
+func (suite *JobServiceTestSuite) TestRecoverRetriesAbandonedRuns() {
+	ctx := context.Background()
+	queued, err := suite.jobService.Enqueue(ctx, "test-job", nil)
+	suite.Require().NoError(err)
+
+	// a worker claimed the run and died
+	lease := time.Now().Add(-time.Minute)
+	err = suite.containers.DB.Model(&models.JobRun{}).Where("id = ?", queued.ID).
+		Updates(map[string]interface{}{"status": models.JobRunning, "attempts": 1, "lease_until": lease}).Error
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.jobService.Recover(ctx, time.Now()))
+
+	run := suite.run(queued.ID)
+	suite.Equal(models.JobQueued, run.Status)
+	suite.NotEmpty(run.LastError)
+}
+
+func (suite *JobServiceTestSuite) TestBuiltinBackgroundJobsRun() {
+	ctx := context.Background()
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, services.NewAuthService(suite.containers.DB, suite.containers.Config))
+	suite.Require().NoError(err)
+	_, err = helpers.CreateTestStaff(suite.containers.DB, hospital.ID, nil)
+	suite.Require().NoError(err)
+
+	for _, name := range []string{services.JobCheckCredentials, services.JobPurgeTrash, services.JobSnapshotHeadcount, services.JobDeliverWebhooks} {
+		queued, err := suite.jobService.Enqueue(ctx, name, nil)
+		suite.Require().NoError(err, name)
+		claimed, err := suite.jobService.Process(ctx, queued.ID)
+		suite.Require().NoError(err, name)
+		suite.True(claimed, name)
+		suite.Equal(models.JobSucceeded, suite.run(queued.ID).Status, name)
+	}
+
+	var snapshots int64
+	suite.Require().NoError(suite.containers.DB.Model(&models.HeadcountSnapshot{}).Count(&snapshots).Error)
+	suite.Positive(snapshots)
+}
+
+func TestJobServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(JobServiceTestSuite))
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	next := func(expression string, after time.Time) time.Time {
+		schedule, err := services.ParseCronSchedule(expression, time.UTC)
+		require.NoError(t, err)
+		return schedule.Next(after)
+	}
+
+	// 2026-03-10 is a Tuesday
+	assert.Equal(t, at(10, 9, 30), next("*/30 * * * *", at(10, 9, 0)))
+	assert.Equal(t, at(10, 10, 0), next("@hourly", at(10, 9, 0)))
+	assert.Equal(t, at(11, 0, 0), next("@daily", at(10, 9, 0)))
+	assert.Equal(t, at(10, 18, 0), next("0 8-18/2 * * 1-5", at(10, 16, 0)))
+	assert.Equal(t, at(13, 8, 0), next("0 8-18/2 * * 1-5", at(12, 18, 0)))
+	assert.Equal(t, at(15, 3, 0), next("0 3 * * 7", at(10, 9, 0)))
+	assert.Equal(t, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), next("@monthly", at(10, 9, 0)))
+	// either restricted day field matches
+	assert.Equal(t, at(12, 0, 0), next("0 0 12 * 1", at(10, 9, 0)))
+	assert.Equal(t, at(16, 0, 0), next("0 0 12 * 1", at(12, 9, 0)))
+	assert.True(t, next("0 0 30 2 *", at(10, 9, 0)).IsZero())
+
+	for _, expression := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "@every"} {
+		_, err := services.ParseCronSchedule(expression, time.UTC)
+		assert.Error(t, err, expression)
+	}
+}
@@ -0,0 +1,33 @@
+package unit
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerRedactsAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var output bytes.Buffer
+	previous := gin.DefaultWriter
+	gin.DefaultWriter = &output
+	defer func() { gin.DefaultWriter = previous }()
+
+	router := gin.New()
+	router.Use(middleware.Logger())
+	router.GET("/api/events/stream", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/api/events/stream?last_event_id=7&access_token=eyJhbGciOiJIUzI1NiJ9.secret", nil)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.NotContains(t, output.String(), "eyJhbGciOiJIUzI1NiJ9")
+	assert.Contains(t, output.String(), "access_token=REDACTED")
+	assert.Contains(t, output.String(), "last_event_id=7")
+}
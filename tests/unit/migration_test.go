@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/database"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationTestSuite struct {
+	suite.Suite
+	containers *helpers.TestContainers
+	migrator   *database.Migrator
+}
+
+func (suite *MigrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+	suite.containers = containers
+
+	suite.migrator, err = database.NewMigrator(containers.DB, database.MigrationFiles)
+	suite.Require().NoError(err)
+}
+
+func (suite *MigrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *MigrationTestSuite) TestStartupAppliesEveryMigration() {
+	statuses, err := suite.migrator.Status()
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.NotNil(status.AppliedAt, "migration %d", status.Version)
+		suite.False(status.Modified)
+	}
+
+	applied, err := suite.migrator.Up(0)
+	suite.Require().NoError(err)
+	suite.Zero(applied)
+}
+
+func (suite *MigrationTestSuite) TestInitialMigrationAdoptsExistingSchema() {
+	// a database that AutoMigrate built before versioned migrations: the
+	// tables of the time with rows in them, and no migration history
+	baseline, err := os.ReadFile("testdata/baseline_schema.sql")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.containers.DB.Exec("CREATE DATABASE baseline_hospital_tracker").Error)
+	cfg := suite.containers.Config.Database
+	cfg.Name = "baseline_hospital_tracker"
+	db, err := database.Connect(cfg)
+	suite.Require().NoError(err)
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}()
+	suite.Require().NoError(db.Exec(string(baseline)).Error)
+
+	migrations, err := database.LoadMigrations(database.MigrationFiles)
+	suite.Require().NoError(err)
+	migrator, err := database.NewMigrator(db, database.MigrationFiles)
+	suite.Require().NoError(err)
+	applied, err := migrator.Up(0)
+	suite.Require().NoError(err)
+	suite.Equal(len(migrations), applied)
+
+	columns := map[string][]string{
+		"profession_groups": {"hospital_id"},
+		"titles":            {"hospital_id"},
+		"clinic_types":      {"hospital_id"},
+		"clinics":           {"name", "floor", "building", "phone_extension", "head_staff_id", "open_on_holidays"},
+		"staffs":            {"status"},
+	}
+	for table, names := range columns {
+		for _, name := range names {
+			var count int64
+			err := db.Raw("SELECT COUNT(*) FROM information_schema.columns WHERE table_name = ? AND column_name = ?", table, name).Scan(&count).Error
+			suite.Require().NoError(err)
+			suite.Equal(int64(1), count, "column %s.%s", table, name)
+		}
+	}
+
+	indexes := []string{
+		"idx_profession_groups_global_name",
+		"idx_titles_hospital_id",
+		"idx_clinics_head_staff_id",
+		"idx_staffs_status",
+		"idx_staffs_national_id",
+		"idx_staff_clinic_assignments_open",
+	}
+	for _, name := range indexes {
+		var count int64
+		suite.Require().NoError(db.Raw("SELECT COUNT(*) FROM pg_indexes WHERE indexname = ?", name).Scan(&count).Error)
+		suite.Equal(int64(1), count, "index %s", name)
+	}
+
+	var statuses []string
+	suite.Require().NoError(db.Table("staffs").Order("id").Pluck("status", &statuses).Error)
+	suite.Equal([]string{"active", "active"}, statuses)
+
+	var assignments []models.StaffClinicAssignment
+	suite.Require().NoError(db.Find(&assignments).Error)
+	suite.Require().Len(assignments, 1)
+	suite.Equal(uint(1), assignments[0].StaffID)
+	suite.Equal(uint(1), assignments[0].ClinicID)
+	suite.Nil(assignments[0].EndedAt)
+	suite.True(assignments[0].StartedAt.Equal(time.Date(2024, time.January, 15, 6, 0, 0, 0, time.UTC)))
+
+	var versions []models.StaffVersion
+	suite.Require().NoError(db.Order("staff_id").Find(&versions).Error)
+	suite.Require().Len(versions, 2)
+	for _, version := range versions {
+		suite.Equal(1, version.Version)
+		suite.Nil(version.ValidTo)
+	}
+	suite.Equal("Ayşe", versions[0].FirstName)
+	suite.Equal("Kaya", versions[1].LastName)
+}
+
+func (suite *MigrationTestSuite) TestModifiedMigrationIsRejected() {
+	db := suite.containers.DB
+	suite.Require().NoError(db.Exec("UPDATE schema_migrations SET checksum = 'edited' WHERE version = 1").Error)
+	defer func() {
+		migrations, err := database.LoadMigrations(database.MigrationFiles)
+		suite.Require().NoError(err)
+		suite.Require().NoError(db.Exec("UPDATE schema_migrations SET checksum = ? WHERE version = 1", migrations[0].Checksum).Error)
+	}()
+
+	_, err := suite.migrator.Up(0)
+	suite.Error(err)
+
+	statuses, err := suite.migrator.Status()
+	suite.Require().NoError(err)
+	suite.True(statuses[0].Modified)
+}
+
+func TestMigrationTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationTestSuite))
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := database.LoadMigrations(fstest.MapFS{
+		"0002_add_notes.up.sql":   {Data: []byte("ALTER TABLE staffs ADD COLUMN notes text;")},
+		"0002_add_notes.down.sql": {Data: []byte("ALTER TABLE staffs DROP COLUMN notes;")},
+		"0001_initial.up.sql":     {Data: []byte("CREATE TABLE a (id int);")},
+		"0001_initial.down.sql":   {Data: []byte("DROP TABLE a;")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "initial", migrations[0].Name)
+	assert.Equal(t, "add_notes", migrations[1].Name)
+	assert.Equal(t, "DROP TABLE a;", migrations[0].Down)
+	assert.Len(t, migrations[0].Checksum, 64)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+
+	invalid := []fstest.MapFS{
+		{"0001_initial.up.sql": {Data: []byte("SELECT 1;")}},
+		{"initial.up.sql": {Data: []byte("SELECT 1;")}, "initial.down.sql": {Data: []byte("SELECT 1;")}},
+		{"0001_initial.sql": {Data: []byte("SELECT 1;")}},
+		{
+			"0001_a.up.sql": {Data: []byte("SELECT 1;")}, "0001_a.down.sql": {Data: []byte("SELECT 1;")},
+			"0001_b.up.sql": {Data: []byte("SELECT 1;")}, "0001_b.down.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+	for _, files := range invalid {
+		_, err := database.LoadMigrations(files)
+		assert.Error(t, err)
+	}
+
+	embedded, err := database.LoadMigrations(database.MigrationFiles)
+	require.NoError(t, err)
+	assert.Equal(t, "initial", embedded[0].Name)
+}
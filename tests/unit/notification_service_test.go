@@ -0,0 +1,172 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type recordingSender struct {
+	mu      sync.Mutex
+	channel models.NotificationChannel
+	sent    []uint
+}
+
+func (s *recordingSender) Channel() models.NotificationChannel {
+	return s.channel
+}
+
+func (s *recordingSender) Send(_ context.Context, user *models.User, _ services.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, user.ID)
+	return nil
+}
+
+type NotificationServiceTestSuite struct {
+	suite.Suite
+	containers          *helpers.TestContainers
+	notificationService *services.NotificationService
+	authService         *services.AuthService
+	email               *recordingSender
+	hospitalID          uint
+	admin               *models.User
+	employee            *models.User
+}
+
+func (suite *NotificationServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+}
+
+func (suite *NotificationServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *NotificationServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	suite.email = &recordingSender{channel: models.ChannelEmail}
+	suite.notificationService = services.NewNotificationService(suite.containers.DB)
+	suite.notificationService.RegisterSender(suite.email)
+
+	hospital, admin, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+	suite.admin = admin
+
+	suite.employee, err = helpers.CreateTestUser(suite.containers.DB, suite.authService, hospital.ID, models.UserTypeEmployee)
+	suite.Require().NoError(err)
+}
+
+func (suite *NotificationServiceTestSuite) notify(userIDs ...uint) {
+	err := suite.notificationService.Notify(context.Background(), services.Notification{
+		HospitalID: suite.hospitalID,
+		UserIDs:    userIDs,
+		Type:       services.NotificationCredentialExpired,
+		Title:      "Credential expired",
+		Message:    "License of Ayşe Yılmaz expired",
+		Data:       map[string]interface{}{"credential_id": 7},
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *NotificationServiceTestSuite) TestNotifyReachesAuthorizedUsersInApp() {
+	suite.notify()
+
+	inbox, err := suite.notificationService.GetNotifications(suite.admin.ID, &models.NotificationFilterRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Len(inbox.Data, 1)
+	suite.Equal(services.NotificationCredentialExpired, inbox.Data[0].Type)
+	suite.JSONEq(`{"credential_id":7}`, string(inbox.Data[0].Data))
+	suite.EqualValues(1, inbox.UnreadCount)
+
+	employeeInbox, err := suite.notificationService.GetNotifications(suite.employee.ID, &models.NotificationFilterRequest{})
+	suite.Require().NoError(err)
+	suite.Empty(employeeInbox.Data)
+
+	// email is off by default
+	suite.Empty(suite.email.sent)
+}
+
+func (suite *NotificationServiceTestSuite) TestPreferencesChooseChannels() {
+	_, err := suite.notificationService.UpdatePreferences(suite.admin.ID, &models.UpdateNotificationPreferencesRequest{
+		Preferences: []models.NotificationPreferenceItem{
+			{Type: services.NotificationCredentialExpired, InApp: false, Email: true},
+		},
+	})
+	suite.Require().NoError(err)
+
+	suite.notify()
+
+	inbox, err := suite.notificationService.GetNotifications(suite.admin.ID, &models.NotificationFilterRequest{})
+	suite.Require().NoError(err)
+	suite.Empty(inbox.Data)
+	suite.Equal([]uint{suite.admin.ID}, suite.email.sent)
+
+	preferences, err := suite.notificationService.GetPreferences(suite.admin.ID)
+	suite.Require().NoError(err)
+	suite.Len(preferences, len(services.NotificationTypes))
+	for _, preference := range preferences {
+		if preference.Type == services.NotificationCredentialExpired {
+			suite.False(preference.InApp)
+			suite.True(preference.Email)
+		} else {
+			suite.True(preference.InApp)
+			suite.False(preference.Email)
+		}
+	}
+
+	_, err = suite.notificationService.UpdatePreferences(suite.admin.ID, &models.UpdateNotificationPreferencesRequest{
+		Preferences: []models.NotificationPreferenceItem{{Type: "unknown.type", InApp: true}},
+	})
+	suite.Error(err)
+}
+
+func (suite *NotificationServiceTestSuite) TestMarkRead() {
+	suite.notify(suite.employee.ID)
+	suite.notify(suite.employee.ID)
+
+	inbox, err := suite.notificationService.GetNotifications(suite.employee.ID, &models.NotificationFilterRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Len(inbox.Data, 2)
+
+	// another user cannot mark it
+	_, err = suite.notificationService.MarkRead(inbox.Data[0].ID, suite.admin.ID)
+	suite.Error(err)
+
+	read, err := suite.notificationService.MarkRead(inbox.Data[0].ID, suite.employee.ID)
+	suite.Require().NoError(err)
+	suite.NotNil(read.ReadAt)
+
+	unread, err := suite.notificationService.GetNotifications(suite.employee.ID, &models.NotificationFilterRequest{Unread: true})
+	suite.Require().NoError(err)
+	suite.Len(unread.Data, 1)
+	suite.EqualValues(1, unread.UnreadCount)
+
+	updated, err := suite.notificationService.MarkAllRead(suite.employee.ID)
+	suite.Require().NoError(err)
+	suite.EqualValues(1, updated)
+
+	unread, err = suite.notificationService.GetNotifications(suite.employee.ID, &models.NotificationFilterRequest{Unread: true})
+	suite.Require().NoError(err)
+	suite.Empty(unread.Data)
+	suite.Zero(unread.UnreadCount)
+}
+
+func TestNotificationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationServiceTestSuite))
+}
@@ -0,0 +1,132 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportServiceTestSuite struct {
+	suite.Suite
+	containers    *helpers.TestContainers
+	reportService *services.ReportService
+	authService   *services.AuthService
+	hospitalID    uint
+	clinicID      uint
+}
+
+func (suite *ReportServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.reportService = services.NewReportService(containers.DB, containers.Config)
+}
+
+func (suite *ReportServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *ReportServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *ReportServiceTestSuite) TestSnapshotTrend() {
+	_, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	_, err = helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	now := time.Now()
+	_, err = suite.reportService.Snapshot(now)
+	suite.Require().NoError(err)
+	// a second run on the same day replaces the first
+	_, err = suite.reportService.Snapshot(now)
+	suite.Require().NoError(err)
+
+	today := now.Format("2006-01-02")
+	trend, err := suite.reportService.GetHeadcountTrend(&models.HeadcountTrendRequest{From: today, To: today, Granularity: models.GranularityDay}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(trend.Series, 1)
+	suite.Require().Len(trend.Series[0].Points, 1)
+	suite.Equal(int64(2), trend.Series[0].Points[0].Count)
+
+	trend, err = suite.reportService.GetHeadcountTrend(&models.HeadcountTrendRequest{From: today, To: today, Granularity: models.GranularityMonth, GroupBy: "clinic"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(trend.Series, 2)
+	for _, series := range trend.Series {
+		suite.Require().Len(series.Points, 1)
+		suite.Equal(int64(1), series.Points[0].Count)
+	}
+
+	trend, err = suite.reportService.GetHeadcountTrend(&models.HeadcountTrendRequest{From: today, To: today, Granularity: models.GranularityWeek, ClinicID: "unassigned"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), trend.Series[0].Points[0].Count)
+
+	_, err = suite.reportService.GetHeadcountTrend(&models.HeadcountTrendRequest{From: today, To: "2020-01-01", Granularity: models.GranularityDay}, suite.hospitalID)
+	suite.Error(err)
+}
+
+func (suite *ReportServiceTestSuite) TestBackfill() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	leaver, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	now := time.Now()
+	suite.Require().NoError(suite.containers.DB.Model(&models.Staff{}).
+		Where("id IN ?", []uint{staff.ID, leaver.ID}).
+		Update("created_at", now.AddDate(0, 0, -10)).Error)
+	suite.Require().NoError(suite.containers.DB.Unscoped().Model(&models.Staff{}).
+		Where("id = ?", leaver.ID).
+		Update("deleted_at", now.AddDate(0, 0, -5)).Error)
+
+	result, err := suite.reportService.Backfill(now.AddDate(0, 0, -12), now.AddDate(0, 0, -1), false)
+	suite.Require().NoError(err)
+	suite.Equal(12, result.Days)
+
+	trend, err := suite.reportService.GetHeadcountTrend(&models.HeadcountTrendRequest{
+		From:        now.AddDate(0, 0, -12).Format("2006-01-02"),
+		To:          now.AddDate(0, 0, -1).Format("2006-01-02"),
+		Granularity: models.GranularityDay,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(trend.Series, 1)
+
+	counts := map[string]int64{}
+	for _, point := range trend.Series[0].Points {
+		counts[point.Date] = point.Count
+	}
+	suite.Equal(int64(2), counts[now.AddDate(0, 0, -7).Format("2006-01-02")])
+	suite.Equal(int64(1), counts[now.AddDate(0, 0, -2).Format("2006-01-02")])
+
+	result, err = suite.reportService.Backfill(now.AddDate(0, 0, -12), now.AddDate(0, 0, -1), false)
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), result.Rows, "days with snapshots are skipped")
+
+	_, err = suite.reportService.Backfill(now, now.AddDate(0, 0, 2), false)
+	suite.Error(err)
+}
+
+func TestReportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportServiceTestSuite))
+}
@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/database"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type SeedTestSuite struct {
+	suite.Suite
+	containers *helpers.TestContainers
+}
+
+func (suite *SeedTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+	suite.containers = containers
+}
+
+func (suite *SeedTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *SeedTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+}
+
+func (suite *SeedTestSuite) count(model interface{}) int64 {
+	var count int64
+	suite.Require().NoError(suite.containers.DB.Model(model).Count(&count).Error)
+	return count
+}
+
+func (suite *SeedTestSuite) TestReferenceSeedIsIdempotent() {
+	db := suite.containers.DB
+	provinces := suite.count(&models.Province{})
+	districts := suite.count(&models.District{})
+	titles := suite.count(&models.Title{})
+	clinicTypes := suite.count(&models.ClinicType{})
+	suite.Positive(provinces)
+
+	var versions []database.SeedVersion
+	suite.Require().NoError(db.Find(&versions).Error)
+	suite.Len(versions, 4)
+
+	suite.Require().NoError(database.SeedReference(db, true))
+	suite.Equal(provinces, suite.count(&models.Province{}))
+	suite.Equal(districts, suite.count(&models.District{}))
+	suite.Equal(titles, suite.count(&models.Title{}))
+	suite.Equal(clinicTypes, suite.count(&models.ClinicType{}))
+	suite.Equal(int64(1), suite.count(&models.StaffingRule{}))
+}
+
+func (suite *SeedTestSuite) TestLocationsCoverTurkey() {
+	db := suite.containers.DB
+	suite.Equal(int64(81), suite.count(&models.Province{}))
+	suite.Equal(int64(973), suite.count(&models.District{}))
+
+	var istanbul models.Province
+	suite.Require().NoError(db.Where("plate_code = ?", 34).First(&istanbul).Error)
+	suite.Equal("İstanbul", istanbul.Name)
+	suite.Require().NotNil(istanbul.Latitude)
+	suite.Require().NotNil(istanbul.Longitude)
+	suite.InDelta(41.0, *istanbul.Latitude, 0.5)
+
+	var districts int64
+	suite.Require().NoError(db.Model(&models.District{}).Where("province_id = ?", istanbul.ID).Count(&districts).Error)
+	suite.Equal(int64(39), districts)
+	suite.Positive(suite.count(&models.Neighbourhood{}))
+}
+
+func (suite *SeedTestSuite) TestReferenceSeedRestoresMissingRows() {
+	db := suite.containers.DB
+	var district models.District
+	suite.Require().NoError(db.Where("name = ?", "Bornova").First(&district).Error)
+	suite.Require().NoError(db.Delete(&district).Error)
+
+	// unchanged files are skipped
+	suite.Require().NoError(database.SeedReference(db, false))
+	suite.Error(db.Where("name = ?", "Bornova").First(&models.District{}).Error)
+
+	suite.Require().NoError(db.Model(&database.SeedVersion{}).Where("dataset = ?", "locations").Update("checksum", "stale").Error)
+	suite.Require().NoError(database.SeedReference(db, false))
+	var restored models.District
+	suite.Require().NoError(db.Where("name = ?", "Bornova").First(&restored).Error)
+	suite.Equal(district.ProvinceID, restored.ProvinceID)
+}
+
+func (suite *SeedTestSuite) TestDemoSeedCreatesHospitalOnce() {
+	db := suite.containers.DB
+	suite.Require().NoError(database.SeedDemo(db))
+	suite.Require().NoError(database.SeedDemo(db))
+
+	suite.Equal(int64(1), suite.count(&models.Hospital{}))
+	suite.Equal(int64(2), suite.count(&models.User{}))
+	suite.Equal(int64(2), suite.count(&models.Clinic{}))
+	suite.Equal(int64(5), suite.count(&models.Staff{}))
+	suite.Equal(int64(5), suite.count(&models.StaffVersion{}))
+	suite.Equal(int64(3), suite.count(&models.StaffClinicAssignment{}))
+}
+
+func TestSeedTestSuite(t *testing.T) {
+	suite.Run(t, new(SeedTestSuite))
+}
@@ -0,0 +1,138 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type StaffHistoryServiceTestSuite struct {
+	suite.Suite
+	containers   *helpers.TestContainers
+	staffService *services.StaffService
+	authService  *services.AuthService
+	hospitalID   uint
+	clinicID     uint
+}
+
+func (suite *StaffHistoryServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+}
+
+func (suite *StaffHistoryServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *StaffHistoryServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *StaffHistoryServiceTestSuite) TestHistoryListsFieldChanges() {
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	_, err = suite.staffService.UpdateStaff(staff.ID, &models.UpdateStaffRequest{FirstName: "Güneş"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	// an update that changes nothing opens no version
+	_, err = suite.staffService.UpdateStaff(staff.ID, &models.UpdateStaffRequest{FirstName: "Güneş"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	_, err = suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{Reason: "left the clinic"}, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+
+	history, err := suite.staffService.GetStaffHistory(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(history.Versions, 3)
+
+	latest := history.Versions[0]
+	suite.Equal(3, latest.Version)
+	suite.Nil(latest.ValidTo)
+	suite.Require().Len(latest.Changes, 1)
+	suite.Equal("clinic_id", latest.Changes[0].Field)
+	suite.Equal(suite.clinicID, latest.Changes[0].From)
+	suite.Nil(latest.Changes[0].To)
+
+	renamed := history.Versions[1]
+	suite.Require().Len(renamed.Changes, 1)
+	suite.Equal("first_name", renamed.Changes[0].Field)
+	suite.Equal(staff.FirstName, renamed.Changes[0].From)
+	suite.Equal("Güneş", renamed.Changes[0].To)
+	suite.Require().NotNil(renamed.ValidTo)
+	suite.True(latest.ValidFrom.Equal(*renamed.ValidTo))
+
+	created := history.Versions[2]
+	suite.Equal(1, created.Version)
+	suite.Len(created.Changes, 10)
+	for _, change := range created.Changes {
+		suite.Nil(change.From)
+	}
+}
+
+func (suite *StaffHistoryServiceTestSuite) TestGetStaffAsOf() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	_, err = suite.staffService.UpdateStaff(staff.ID, &models.UpdateStaffRequest{LastName: "Yıldız"}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(staff.ID, suite.hospitalID))
+
+	history, err := suite.staffService.GetStaffHistory(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(history.Versions, 3)
+	first := history.Versions[2]
+
+	asOf, err := suite.staffService.GetStaffAsOf(staff.ID, suite.hospitalID, first.ValidFrom.Format(time.RFC3339Nano))
+	suite.Require().NoError(err)
+	suite.Equal(staff.LastName, asOf.LastName)
+	suite.Require().NotNil(asOf.ClinicID)
+	suite.Equal(suite.clinicID, *asOf.ClinicID)
+	suite.Require().NotNil(asOf.Clinic)
+	suite.Equal(staff.TitleID, asOf.Title.ID)
+
+	renamed := history.Versions[1]
+	asOf, err = suite.staffService.GetStaffAsOf(staff.ID, suite.hospitalID, renamed.ValidFrom.Format(time.RFC3339Nano))
+	suite.Require().NoError(err)
+	suite.Equal("Yıldız", asOf.LastName)
+
+	_, err = suite.staffService.GetStaffAsOf(staff.ID, suite.hospitalID, time.Now().Format(time.RFC3339Nano))
+	suite.Require().Error(err)
+	appErr, ok := apperrors.IsAppError(err)
+	suite.Require().True(ok)
+	suite.Equal(apperrors.ErrCodeStaffNotFound, appErr.Code)
+
+	_, err = suite.staffService.GetStaffAsOf(staff.ID, suite.hospitalID, "2001-01-01")
+	suite.Require().Error(err)
+
+	_, err = suite.staffService.GetStaffAsOf(staff.ID, suite.hospitalID, "yesterday")
+	suite.Require().Error(err)
+	appErr, ok = apperrors.IsAppError(err)
+	suite.Require().True(ok)
+	suite.Equal(apperrors.ErrCodeValidation, appErr.Code)
+}
+
+func TestStaffHistoryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(StaffHistoryServiceTestSuite))
+}
@@ -0,0 +1,207 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+	"github.com/xuri/excelize/v2"
+)
+
+type StaffImportServiceTestSuite struct {
+	suite.Suite
+	containers         *helpers.TestContainers
+	staffImportService *services.StaffImportService
+	authService        *services.AuthService
+	hospitalID         uint
+	userID             uint
+	clinicName         string
+}
+
+func (suite *StaffImportServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	staffService := services.NewStaffService(containers.DB, containers.Redis)
+	suite.staffImportService = services.NewStaffImportService(containers.DB, staffService)
+}
+
+func (suite *StaffImportServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *StaffImportServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, user, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+	suite.userID = user.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicName = clinic.ClinicType.Name
+}
+
+func (suite *StaffImportServiceTestSuite) staffCount() int64 {
+	var count int64
+	suite.Require().NoError(suite.containers.DB.Model(&models.Staff{}).Where("hospital_id = ?", suite.hospitalID).Count(&count).Error)
+	return count
+}
+
+func (suite *StaffImportServiceTestSuite) validCSV() []byte {
+	return []byte("first_name,last_name,national_id,phone,profession_group,title,clinic,working_days\n" +
+		"Ayşe,Yılmaz,10000000001,+905550000001,Doktor,Uzman," + suite.clinicName + ",\"monday,tuesday\"\n" +
+		"Mehmet,Kaya,10000000002,+905550000002,İDARİ PERSONEL,Müdür,,pazartesi;cuma\n")
+}
+
+func (suite *StaffImportServiceTestSuite) TestDryRunDoesNotImport() {
+	response, err := suite.staffImportService.Import("staff.csv", suite.validCSV(), true, suite.hospitalID, suite.userID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(response.Report)
+
+	suite.True(response.Report.DryRun)
+	suite.False(response.Report.Committed)
+	suite.Equal(2, response.Report.TotalRows)
+	suite.Equal(2, response.Report.ValidRows)
+	suite.Empty(response.Report.Errors)
+	suite.Equal(int64(0), suite.staffCount())
+}
+
+func (suite *StaffImportServiceTestSuite) TestCommitImportsAllRows() {
+	response, err := suite.staffImportService.Import("staff.csv", suite.validCSV(), false, suite.hospitalID, suite.userID)
+	suite.Require().NoError(err)
+
+	suite.True(response.Report.Committed)
+	suite.Equal(2, response.Report.ImportedRows)
+	suite.Equal(int64(2), suite.staffCount())
+}
+
+func (suite *StaffImportServiceTestSuite) TestClinicMatchesOwnOrTypeName() {
+	err := suite.containers.DB.Model(&models.Clinic{}).Where("hospital_id = ?", suite.hospitalID).Update("name", "Kalp Merkezi").Error
+	suite.Require().NoError(err)
+
+	data := []byte("first_name,last_name,national_id,phone,profession_group,title,clinic,working_days\n" +
+		"Ayşe,Yılmaz,10000000001,+905550000001,Doktor,Uzman,kalp merkezi,monday\n" +
+		"Mehmet,Kaya,10000000002,+905550000002,Doktor,Uzman," + suite.clinicName + ",monday\n")
+	response, err := suite.staffImportService.Import("staff.csv", data, false, suite.hospitalID, suite.userID)
+	suite.Require().NoError(err)
+	suite.Empty(response.Report.Errors)
+	suite.Equal(2, response.Report.ImportedRows)
+
+	var unassigned int64
+	suite.Require().NoError(suite.containers.DB.Model(&models.Staff{}).Where("hospital_id = ? AND clinic_id IS NULL", suite.hospitalID).Count(&unassigned).Error)
+	suite.Zero(unassigned)
+}
+
+func (suite *StaffImportServiceTestSuite) TestImportReadsEscapedExport() {
+	data := []byte("first_name,last_name,national_id,phone,profession_group,title,clinic,working_days\n" +
+		"Ayşe,Yılmaz,10000000001,'+905550000001,Doktor,Uzman,,monday\n")
+	response, err := suite.staffImportService.Import("staff.csv", data, false, suite.hospitalID, suite.userID)
+	suite.Require().NoError(err)
+	suite.Require().Empty(response.Report.Errors)
+
+	var staff models.Staff
+	suite.Require().NoError(suite.containers.DB.Where("national_id = ?", "10000000001").First(&staff).Error)
+	suite.Equal("+905550000001", staff.Phone)
+}
+
+func (suite *StaffImportServiceTestSuite) TestInvalidRowsAbortImport() {
+	data := []byte("first_name;last_name;national_id;phone;profession_group;title;clinic;working_days\n" +
+		"Ali;Demir;10000000003;+905550000003;Doktor;Uzman;;monday\n" +
+		"Veli;Demir;10000000003;+905550000004;Doktor;Uzman;;monday\n" +
+		"Can;Öz;10000000005;+905550000005;Doktor;Müdür;;monday\n" +
+		"Ece;Ak;10000000006;+905550000006;İdari Personel;Başhekim;;monday\n" +
+		"Eda;Ak;10000000007;+905550000007;İdari Personel;Başhekim;;monday\n" +
+		"Nur;Ak;10000000008;+905550000008;Doktor;Uzman;Yok Klinik;monday\n")
+
+	response, err := suite.staffImportService.Import("staff.csv", data, false, suite.hospitalID, suite.userID)
+	suite.Require().NoError(err)
+
+	report := response.Report
+	suite.False(report.Committed)
+	suite.Equal(6, report.TotalRows)
+	suite.Equal(2, report.ValidRows)
+	suite.Require().Len(report.Errors, 4)
+
+	suite.Equal(3, report.Errors[0].Row)
+	suite.Contains(report.Errors[0].Message, "national ID")
+	suite.Equal(4, report.Errors[1].Row)
+	suite.Equal("title", report.Errors[1].Field)
+	suite.Equal(6, report.Errors[2].Row)
+	suite.Contains(report.Errors[2].Message, "Başhekim")
+	suite.Equal(7, report.Errors[3].Row)
+	suite.Equal("clinic", report.Errors[3].Field)
+
+	suite.Equal(int64(0), suite.staffCount())
+}
+
+func (suite *StaffImportServiceTestSuite) TestImportXLSX() {
+	file := excelize.NewFile()
+	sheet := file.GetSheetName(0)
+	rows := [][]interface{}{
+		{"First Name", "Last Name", "National ID", "Phone", "Profession Group", "Title", "Clinic", "Working Days"},
+		{"Zeynep", "Çelik", "10000000009", "+905550000009", "Hizmet Personeli", "Güvenlik", "", "saturday sunday"},
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		suite.Require().NoError(err)
+		suite.Require().NoError(file.SetSheetRow(sheet, cell, &row))
+	}
+
+	var buf bytes.Buffer
+	suite.Require().NoError(file.Write(&buf))
+
+	response, err := suite.staffImportService.Import("staff.xlsx", buf.Bytes(), false, suite.hospitalID, suite.userID)
+	suite.Require().NoError(err)
+	suite.Equal(1, response.Report.ImportedRows)
+	suite.Equal(int64(1), suite.staffCount())
+}
+
+func (suite *StaffImportServiceTestSuite) TestUnsupportedFileType() {
+	_, err := suite.staffImportService.Import("staff.txt", []byte("data"), true, suite.hospitalID, suite.userID)
+	suite.Error(err)
+}
+
+func (suite *StaffImportServiceTestSuite) TestQueuedJobRunsOnce() {
+	// a worker stopped while this job was running
+	job := &models.StaffImportJob{
+		HospitalID:  suite.hospitalID,
+		CreatedByID: suite.userID,
+		FileName:    "staff.csv",
+		File:        suite.validCSV(),
+		Status:      models.StaffImportRunning,
+		TotalRows:   2,
+	}
+	suite.Require().NoError(suite.containers.DB.Create(job).Error)
+
+	suite.Require().NoError(suite.staffImportService.RunJob(context.Background(), job.ID))
+	suite.Equal(int64(2), suite.staffCount())
+
+	stored, err := suite.staffImportService.GetJob(job.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffImportCompleted, stored.Status)
+	suite.Equal(2, stored.ProcessedRows)
+	suite.Require().NotNil(stored.Report)
+	suite.True(stored.Report.Committed)
+	suite.Empty(stored.File)
+
+	// a retried run leaves the finished job alone
+	suite.Require().NoError(suite.staffImportService.RunJob(context.Background(), job.ID))
+	suite.Equal(int64(2), suite.staffCount())
+}
+
+func TestStaffImportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(StaffImportServiceTestSuite))
+}
@@ -0,0 +1,210 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type StaffingRuleServiceTestSuite struct {
+	suite.Suite
+	containers          *helpers.TestContainers
+	staffingRuleService *services.StaffingRuleService
+	staffService        *services.StaffService
+	authService         *services.AuthService
+	hospitalID          uint
+	clinicID            uint
+}
+
+func (suite *StaffingRuleServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.staffingRuleService = services.NewStaffingRuleService(containers.DB)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+}
+
+func (suite *StaffingRuleServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *StaffingRuleServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *StaffingRuleServiceTestSuite) title(professionGroupName, titleName string) models.Title {
+	var title models.Title
+	err := suite.containers.DB.Joins("ProfessionGroup").
+		Where("titles.name = ? AND \"ProfessionGroup\".name = ?", titleName, professionGroupName).
+		First(&title).Error
+	suite.Require().NoError(err)
+	return title
+}
+
+// createStaff adds onboarding staff, which count towards the rules and can
+// still be deleted.
+func (suite *StaffingRuleServiceTestSuite) createStaff(title models.Title, clinicID *uint) (*models.Staff, error) {
+	return suite.staffService.CreateStaff(&models.CreateStaffRequest{
+		FirstName:         faker.FirstName(),
+		LastName:          faker.LastName(),
+		NationalID:        faker.UUIDDigit()[:11],
+		Phone:             faker.Phonenumber(),
+		ProfessionGroupID: title.ProfessionGroupID,
+		TitleID:           title.ID,
+		ClinicID:          clinicID,
+		Status:            models.StaffOnboarding,
+		WorkingDays:       []models.WorkingDay{models.Monday},
+	}, suite.hospitalID)
+}
+
+func (suite *StaffingRuleServiceTestSuite) assertViolation(err error, ruleID uint) {
+	suite.Require().Error(err)
+	appErr, ok := errors.IsAppError(err)
+	suite.Require().True(ok, "Expected AppError, got %T", err)
+	suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+	suite.Equal(ruleID, appErr.Context["rule_id"])
+}
+
+func (suite *StaffingRuleServiceTestSuite) TestPlatformDefaultsAreListedButReadOnly() {
+	rules, err := suite.staffingRuleService.GetRules(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(rules)
+	suite.Nil(rules[0].HospitalID)
+	suite.Equal(models.StaffingRuleMaxTitleCount, rules[0].Type)
+
+	err = suite.staffingRuleService.DeleteRule(rules[0].ID, suite.hospitalID)
+	suite.Error(err)
+}
+
+func (suite *StaffingRuleServiceTestSuite) TestMaxTitleCountPerClinic() {
+	specialist := suite.title("Doktor", "Uzman")
+	rule, err := suite.staffingRuleService.CreateRule(&models.StaffingRuleRequest{
+		Type:      models.StaffingRuleMaxTitleCount,
+		Scope:     models.StaffingRuleScopeClinic,
+		TitleID:   &specialist.ID,
+		Threshold: 1,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	_, err = suite.createStaff(specialist, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	// unassigned specialists are not counted against any clinic
+	unassigned, err := suite.createStaff(specialist, nil)
+	suite.Require().NoError(err)
+
+	_, err = suite.createStaff(specialist, &suite.clinicID)
+	suite.assertViolation(err, rule.ID)
+
+	_, err = suite.staffService.TransferStaff(unassigned.ID, &models.TransferStaffRequest{ClinicID: &suite.clinicID, Reason: "cover"}, suite.hospitalID, 0)
+	suite.assertViolation(err, rule.ID)
+
+	disabled := false
+	_, err = suite.staffingRuleService.UpdateRule(rule.ID, &models.StaffingRuleRequest{
+		Type:      models.StaffingRuleMaxTitleCount,
+		Scope:     models.StaffingRuleScopeClinic,
+		TitleID:   &specialist.ID,
+		Threshold: 1,
+		Enabled:   &disabled,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	_, err = suite.createStaff(specialist, &suite.clinicID)
+	suite.NoError(err)
+}
+
+func (suite *StaffingRuleServiceTestSuite) TestMinProfessionCountPerClinic() {
+	assistant := suite.title("Doktor", "Asistan")
+	rule, err := suite.staffingRuleService.CreateRule(&models.StaffingRuleRequest{
+		Type:              models.StaffingRuleMinProfessionCount,
+		ProfessionGroupID: &assistant.ProfessionGroupID,
+		Threshold:         2,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(models.StaffingRuleScopeClinic, rule.Scope)
+
+	// a clinic below the minimum is still being staffed
+	first, err := suite.createStaff(assistant, &suite.clinicID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(first.ID, suite.hospitalID))
+
+	first, err = suite.createStaff(assistant, &suite.clinicID)
+	suite.Require().NoError(err)
+	_, err = suite.createStaff(assistant, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	err = suite.staffService.DeleteStaff(first.ID, suite.hospitalID)
+	suite.assertViolation(err, rule.ID)
+
+	_, err = suite.staffService.TransferStaff(first.ID, &models.TransferStaffRequest{Reason: "leaving"}, suite.hospitalID, 0)
+	suite.assertViolation(err, rule.ID)
+
+	_, err = suite.staffService.GetStaffByID(first.ID, suite.hospitalID)
+	suite.NoError(err)
+}
+
+func (suite *StaffingRuleServiceTestSuite) TestTitleRequiresClinic() {
+	specialist := suite.title("Doktor", "Uzman")
+	rule, err := suite.staffingRuleService.CreateRule(&models.StaffingRuleRequest{
+		Type:    models.StaffingRuleTitleRequiresClinic,
+		TitleID: &specialist.ID,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	_, err = suite.createStaff(specialist, nil)
+	suite.assertViolation(err, rule.ID)
+
+	staff, err := suite.createStaff(specialist, &suite.clinicID)
+	suite.Require().NoError(err)
+
+	_, err = suite.staffService.TransferStaff(staff.ID, &models.TransferStaffRequest{Reason: "unassign"}, suite.hospitalID, 0)
+	suite.assertViolation(err, rule.ID)
+}
+
+func (suite *StaffingRuleServiceTestSuite) TestRuleValidation() {
+	_, err := suite.staffingRuleService.CreateRule(&models.StaffingRuleRequest{
+		Type:      models.StaffingRuleMaxTitleCount,
+		Threshold: 1,
+	}, suite.hospitalID)
+	suite.Error(err)
+
+	otherHospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	otherClinic, err := helpers.CreateTestClinic(suite.containers.DB, otherHospital.ID)
+	suite.Require().NoError(err)
+
+	specialist := suite.title("Doktor", "Uzman")
+	_, err = suite.staffingRuleService.CreateRule(&models.StaffingRuleRequest{
+		Type:      models.StaffingRuleMaxTitleCount,
+		Scope:     models.StaffingRuleScopeClinic,
+		TitleID:   &specialist.ID,
+		ClinicID:  &otherClinic.ID,
+		Threshold: 1,
+	}, suite.hospitalID)
+	suite.Error(err)
+}
+
+func TestStaffingRuleServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(StaffingRuleServiceTestSuite))
+}
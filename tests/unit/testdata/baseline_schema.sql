@@ -0,0 +1,156 @@
+-- The schema AutoMigrate built before versioned migrations, with a few rows,
+-- for checking that the initial migration adopts such a database.
+
+CREATE TABLE "provinces" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_provinces_name" UNIQUE ("name")
+);
+
+CREATE TABLE "districts" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "province_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_provinces_districts" FOREIGN KEY ("province_id") REFERENCES "provinces"("id")
+);
+
+CREATE TABLE "profession_groups" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_profession_groups_name" UNIQUE ("name")
+);
+
+CREATE TABLE "titles" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "profession_group_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_profession_groups_titles" FOREIGN KEY ("profession_group_id") REFERENCES "profession_groups"("id")
+);
+
+CREATE TABLE "clinic_types" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_clinic_types_name" UNIQUE ("name")
+);
+
+CREATE TABLE "password_resets" (
+    "id" bigserial,
+    "phone" text NOT NULL,
+    "code" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used" boolean DEFAULT false,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE "hospitals" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "tax_id" text NOT NULL,
+    "email" text NOT NULL,
+    "phone" text NOT NULL,
+    "province_id" bigint NOT NULL,
+    "district_id" bigint NOT NULL,
+    "address" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_hospitals_tax_id" UNIQUE ("tax_id"),
+    CONSTRAINT "uni_hospitals_email" UNIQUE ("email"),
+    CONSTRAINT "uni_hospitals_phone" UNIQUE ("phone"),
+    CONSTRAINT "fk_hospitals_province" FOREIGN KEY ("province_id") REFERENCES "provinces"("id"),
+    CONSTRAINT "fk_hospitals_district" FOREIGN KEY ("district_id") REFERENCES "districts"("id")
+);
+CREATE INDEX "idx_hospitals_deleted_at" ON "hospitals" ("deleted_at");
+
+CREATE TABLE "users" (
+    "id" bigserial,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "national_id" text NOT NULL,
+    "email" text NOT NULL,
+    "phone" text NOT NULL,
+    "password" text NOT NULL,
+    "user_type" text NOT NULL DEFAULT 'employee',
+    "hospital_id" bigint NOT NULL,
+    "created_by_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_national_id" UNIQUE ("national_id"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email"),
+    CONSTRAINT "uni_users_phone" UNIQUE ("phone"),
+    CONSTRAINT "fk_hospitals_users" FOREIGN KEY ("hospital_id") REFERENCES "hospitals"("id"),
+    CONSTRAINT "fk_users_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id")
+);
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE "clinics" (
+    "id" bigserial,
+    "hospital_id" bigint NOT NULL,
+    "clinic_type_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_hospitals_clinics" FOREIGN KEY ("hospital_id") REFERENCES "hospitals"("id"),
+    CONSTRAINT "fk_clinics_clinic_type" FOREIGN KEY ("clinic_type_id") REFERENCES "clinic_types"("id")
+);
+CREATE INDEX "idx_clinics_deleted_at" ON "clinics" ("deleted_at");
+
+CREATE TABLE "staffs" (
+    "id" bigserial,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "national_id" text NOT NULL,
+    "phone" text NOT NULL,
+    "profession_group_id" bigint NOT NULL,
+    "title_id" bigint NOT NULL,
+    "hospital_id" bigint NOT NULL,
+    "clinic_id" bigint,
+    "working_days" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_staffs_national_id" UNIQUE ("national_id"),
+    CONSTRAINT "uni_staffs_phone" UNIQUE ("phone"),
+    CONSTRAINT "fk_hospitals_staff" FOREIGN KEY ("hospital_id") REFERENCES "hospitals"("id"),
+    CONSTRAINT "fk_clinics_staff" FOREIGN KEY ("clinic_id") REFERENCES "clinics"("id"),
+    CONSTRAINT "fk_staffs_profession_group" FOREIGN KEY ("profession_group_id") REFERENCES "profession_groups"("id"),
+    CONSTRAINT "fk_staffs_title" FOREIGN KEY ("title_id") REFERENCES "titles"("id")
+);
+CREATE INDEX "idx_staffs_deleted_at" ON "staffs" ("deleted_at");
+
+INSERT INTO "provinces" ("id", "name", "created_at", "updated_at") VALUES (1, 'İstanbul', NOW(), NOW());
+INSERT INTO "districts" ("id", "name", "province_id", "created_at", "updated_at") VALUES (1, 'Kadıköy', 1, NOW(), NOW());
+INSERT INTO "profession_groups" ("id", "name", "created_at", "updated_at") VALUES (1, 'Doktor', NOW(), NOW());
+INSERT INTO "titles" ("id", "name", "profession_group_id", "created_at", "updated_at") VALUES (1, 'Uzman', 1, NOW(), NOW());
+INSERT INTO "clinic_types" ("id", "name", "created_at", "updated_at") VALUES (1, 'Kardiyoloji', NOW(), NOW());
+INSERT INTO "hospitals" ("id", "name", "tax_id", "email", "phone", "province_id", "district_id", "address", "created_at", "updated_at")
+VALUES (1, 'Moda Hastanesi', '1234567890', 'info@moda.example', '+902160000000', 1, 1, 'Moda Cd. 1', NOW(), NOW());
+INSERT INTO "clinics" ("id", "hospital_id", "clinic_type_id", "created_at", "updated_at") VALUES (1, 1, 1, NOW(), NOW());
+INSERT INTO "staffs" ("id", "first_name", "last_name", "national_id", "phone", "profession_group_id", "title_id", "hospital_id", "clinic_id", "working_days", "created_at", "updated_at")
+VALUES (1, 'Ayşe', 'Yılmaz', '10000000001', '+905550000001', 1, 1, 1, 1, 'monday,tuesday', '2024-01-15 09:00:00+03', NOW());
+INSERT INTO "staffs" ("id", "first_name", "last_name", "national_id", "phone", "profession_group_id", "title_id", "hospital_id", "working_days", "created_at", "updated_at")
+VALUES (2, 'Mehmet', 'Kaya', '10000000002', '+905550000002', 1, 1, 1, 'friday', '2024-02-01 09:00:00+03', NOW());
+SELECT setval('provinces_id_seq', 1), setval('districts_id_seq', 1), setval('profession_groups_id_seq', 1),
+    setval('titles_id_seq', 1), setval('clinic_types_id_seq', 1), setval('hospitals_id_seq', 1),
+    setval('clinics_id_seq', 1), setval('staffs_id_seq', 2);
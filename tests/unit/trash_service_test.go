@@ -0,0 +1,201 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	"github.com/caner-cetin/hospital-tracker/internal/errors"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrashServiceTestSuite struct {
+	suite.Suite
+	containers   *helpers.TestContainers
+	trashService *services.TrashService
+	staffService *services.StaffService
+	authService  *services.AuthService
+	hospitalID   uint
+	clinicID     uint
+}
+
+func (suite *TrashServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	cfg := *containers.Config
+	cfg.Trash = config.TrashConfig{StaffRetentionDays: 30, UserRetentionDays: 30, ClinicRetentionDays: 30, HospitalRetentionDays: 30}
+	suite.trashService = services.NewTrashService(containers.DB, &cfg)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+}
+
+func (suite *TrashServiceTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *TrashServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	clinic, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.clinicID = clinic.ID
+}
+
+func (suite *TrashServiceTestSuite) TestRestoreStaff() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(staff.ID, suite.hospitalID))
+
+	deleted, err := suite.trashService.GetDeletedStaff(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(deleted, 1)
+	suite.Equal(staff.ID, deleted[0].ID)
+
+	restored, err := suite.trashService.RestoreStaff(staff.ID, suite.hospitalID, 0)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(restored.ClinicID)
+	suite.Equal(suite.clinicID, *restored.ClinicID)
+
+	assignments, err := suite.staffService.GetStaffAssignments(staff.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(assignments, 2)
+
+	_, err = suite.trashService.RestoreStaff(staff.ID, suite.hospitalID, 0)
+	suite.Error(err, "live staff cannot be restored")
+}
+
+func (suite *TrashServiceTestSuite) TestRestoreStaffWithReusedPhone() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(staff.ID, suite.hospitalID))
+
+	_, err = suite.staffService.CreateStaff(&models.CreateStaffRequest{
+		FirstName:         "Deniz",
+		LastName:          "Yıldız",
+		NationalID:        "20000000001",
+		Phone:             staff.Phone,
+		ProfessionGroupID: staff.ProfessionGroupID,
+		TitleID:           staff.TitleID,
+	}, suite.hospitalID)
+	suite.Require().NoError(err, "a deleted staff member's phone is free to reuse")
+
+	_, err = suite.trashService.RestoreStaff(staff.ID, suite.hospitalID, 0)
+	suite.Require().Error(err)
+	if appErr, ok := errors.IsAppError(err); ok {
+		suite.Equal(errors.ErrCodeDuplicatePhone, appErr.Code)
+	} else {
+		suite.T().Errorf("Expected AppError, got %T", err)
+	}
+}
+
+func (suite *TrashServiceTestSuite) TestRestoreStaffWithExpiredCredential() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(staff.ID, suite.hospitalID))
+
+	expired := time.Now().AddDate(0, -1, 0)
+	err = suite.containers.DB.Create(&models.StaffCredential{
+		StaffID:     staff.ID,
+		HospitalID:  suite.hospitalID,
+		Type:        models.CredentialCertification,
+		Name:        "BLS",
+		Number:      "BLS-1",
+		IssuingBody: "Sağlık Bakanlığı",
+		IssuedAt:    expired.AddDate(-2, 0, 0),
+		ExpiresAt:   &expired,
+		Mandatory:   true,
+	}).Error
+	suite.Require().NoError(err)
+
+	cfg := *suite.containers.Config
+	cfg.Credentials.BlockExpiredAssignment = true
+	_, err = services.NewTrashService(suite.containers.DB, &cfg).RestoreStaff(staff.ID, suite.hospitalID, 0)
+	suite.Require().Error(err)
+	appErr, ok := errors.IsAppError(err)
+	suite.Require().True(ok)
+	suite.Equal(errors.ErrCodeBusinessRule, appErr.Code)
+
+	deleted, err := suite.trashService.GetDeletedStaff(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(deleted, 1, "a refused restore leaves the staff member in the trash")
+}
+
+func (suite *TrashServiceTestSuite) TestRestoreClinicWithSameTypeFails() {
+	clinicService := services.NewClinicService(suite.containers.DB)
+	suite.Require().NoError(clinicService.DeleteClinic(suite.clinicID, suite.hospitalID))
+
+	_, err := helpers.CreateTestClinic(suite.containers.DB, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	_, err = suite.trashService.RestoreClinic(suite.clinicID, suite.hospitalID)
+	suite.Error(err)
+}
+
+func (suite *TrashServiceTestSuite) TestPurge() {
+	expired, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	recent, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.staffService.DeleteStaff(expired.ID, suite.hospitalID))
+	suite.Require().NoError(suite.staffService.DeleteStaff(recent.ID, suite.hospitalID))
+
+	err = suite.containers.DB.Exec("UPDATE staffs SET deleted_at = ? WHERE id = ?", time.Now().AddDate(-1, 0, 0), expired.ID).Error
+	suite.Require().NoError(err)
+
+	result, err := suite.trashService.Purge(context.Background(), time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), result.Staff)
+
+	deleted, err := suite.trashService.GetDeletedStaff(suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(deleted, 1)
+	suite.Equal(recent.ID, deleted[0].ID)
+
+	var assignments int64
+	suite.containers.DB.Model(&models.StaffClinicAssignment{}).Where("staff_id = ?", expired.ID).Count(&assignments)
+	suite.Zero(assignments)
+}
+
+func (suite *TrashServiceTestSuite) TestPurgeKeepsClinicWithAssignmentHistory() {
+	staff, err := helpers.CreateTestOnboardingStaff(suite.containers.DB, suite.hospitalID, &suite.clinicID)
+	suite.Require().NoError(err)
+	err = suite.containers.DB.Exec("UPDATE clinics SET deleted_at = ? WHERE id = ?", time.Now().AddDate(-1, 0, 0), suite.clinicID).Error
+	suite.Require().NoError(err)
+
+	result, err := suite.trashService.Purge(context.Background(), time.Now())
+	suite.Require().NoError(err)
+	suite.Zero(result.Clinics)
+
+	var assignments int64
+	suite.containers.DB.Model(&models.StaffClinicAssignment{}).Where("staff_id = ?", staff.ID).Count(&assignments)
+	suite.Equal(int64(1), assignments)
+
+	// once the staff member is erased the clinic has no history left
+	suite.Require().NoError(suite.staffService.DeleteStaff(staff.ID, suite.hospitalID))
+	err = suite.containers.DB.Exec("UPDATE staffs SET deleted_at = ? WHERE id = ?", time.Now().AddDate(-1, 0, 0), staff.ID).Error
+	suite.Require().NoError(err)
+
+	result, err = suite.trashService.Purge(context.Background(), time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), result.Staff)
+	suite.Equal(int64(1), result.Clinics)
+}
+
+func TestTrashServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashServiceTestSuite))
+}
@@ -194,7 +194,7 @@ func (suite *UserServiceTestSuite) TestGetUsers() {
 	user2, err := suite.userService.CreateUser(req, suite.authorizedUser.ID, suite.hospitalID)
 	suite.Require().NoError(err)
 
-	users, err := suite.userService.GetUsers(suite.hospitalID)
+	users, err := suite.userService.GetUsers(suite.hospitalID, &models.UserFilterRequest{})
 
 	suite.NoError(err)
 	// 2 created + 1 application
@@ -208,6 +208,28 @@ func (suite *UserServiceTestSuite) TestGetUsers() {
 	suite.True(userIDs[user2.ID])
 }
 
+func (suite *UserServiceTestSuite) TestGetUsersSearch() {
+	req := &models.CreateUserRequest{
+		FirstName:  "Gülşen",
+		LastName:   "Öztürk",
+		NationalID: "22222222222",
+		Email:      "gulsen@test.com",
+		Phone:      "+905554444444",
+		Password:   faker.Password(),
+		UserType:   models.UserTypeEmployee,
+	}
+
+	user, err := suite.userService.CreateUser(req, suite.authorizedUser.ID, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	for _, q := range []string{"gulsen ozturk", "GÜLŞEN", "ozt", "gulsen@"} {
+		users, err := suite.userService.GetUsers(suite.hospitalID, &models.UserFilterRequest{Q: q})
+		suite.Require().NoError(err, q)
+		suite.Require().Len(users, 1, q)
+		suite.Equal(user.ID, users[0].ID, q)
+	}
+}
+
 func (suite *UserServiceTestSuite) TestGetUser() {
 	user, err := helpers.CreateTestUser(suite.containers.DB, suite.authService, suite.hospitalID, models.UserTypeEmployee)
 	suite.Require().NoError(err)
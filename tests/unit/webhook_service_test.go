@@ -0,0 +1,235 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caner-cetin/hospital-tracker/internal/config"
+	"github.com/caner-cetin/hospital-tracker/internal/models"
+	"github.com/caner-cetin/hospital-tracker/internal/services"
+	"github.com/caner-cetin/hospital-tracker/tests/helpers"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver records requests and answers with status.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	received []receivedWebhook
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, receivedWebhook{header: req.Header.Clone(), body: body})
+	w.WriteHeader(r.status)
+}
+
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	containers     *helpers.TestContainers
+	webhookService *services.WebhookService
+	staffService   *services.StaffService
+	authService    *services.AuthService
+	receiver       *webhookReceiver
+	server         *httptest.Server
+	hospitalID     uint
+}
+
+func (suite *WebhookServiceTestSuite) SetupSuite() {
+	ctx := context.Background()
+	containers, err := helpers.SetupTestContainers(ctx)
+	suite.Require().NoError(err)
+
+	suite.containers = containers
+	suite.authService = services.NewAuthService(containers.DB, containers.Config)
+	suite.staffService = services.NewStaffService(containers.DB, containers.Redis)
+	suite.webhookService = services.NewWebhookService(containers.DB, &config.Config{
+		Webhooks: config.WebhookConfig{MaxAttempts: 2, BackoffSeconds: 60, TimeoutSeconds: 5, AllowPrivateTargets: true},
+	})
+
+	suite.receiver = &webhookReceiver{}
+	suite.server = httptest.NewServer(suite.receiver)
+}
+
+func (suite *WebhookServiceTestSuite) TearDownSuite() {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	ctx := context.Background()
+	if suite.containers != nil {
+		_ = suite.containers.Cleanup(ctx)
+	}
+}
+
+func (suite *WebhookServiceTestSuite) SetupTest() {
+	err := suite.containers.CleanDatabase()
+	suite.Require().NoError(err)
+
+	hospital, _, _, err := helpers.CreateTestHospital(suite.containers.DB, suite.authService)
+	suite.Require().NoError(err)
+	suite.hospitalID = hospital.ID
+
+	suite.receiver.mu.Lock()
+	suite.receiver.status = http.StatusOK
+	suite.receiver.received = nil
+	suite.receiver.mu.Unlock()
+}
+
+func (suite *WebhookServiceTestSuite) subscribe(eventTypes ...models.WebhookEventType) *models.WebhookSecretResponse {
+	result, err := suite.webhookService.CreateSubscription(&models.CreateWebhookRequest{
+		URL:        suite.server.URL,
+		EventTypes: eventTypes,
+	}, suite.hospitalID)
+	suite.Require().NoError(err)
+	return result
+}
+
+func (suite *WebhookServiceTestSuite) TestDeliversSignedStaffEvents() {
+	subscription := suite.subscribe(models.WebhookStaffCreated, models.WebhookStaffDeleted)
+
+	staff, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	// not subscribed to updates
+	_, err = suite.staffService.UpdateStaff(staff.ID, &models.UpdateStaffRequest{FirstName: "Güneş"}, suite.hospitalID)
+	suite.Require().NoError(err)
+
+	sent, err := suite.webhookService.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(1, sent)
+
+	suite.Require().Len(suite.receiver.received, 1)
+	request := suite.receiver.received[0]
+	suite.Equal(string(models.WebhookStaffCreated), request.header.Get("X-Webhook-Event"))
+	expected := "sha256=" + services.SignWebhookPayload(subscription.Secret, request.header.Get("X-Webhook-Timestamp"), request.body)
+	suite.Equal(expected, request.header.Get("X-Webhook-Signature"))
+
+	var envelope struct {
+		Type       string                  `json:"type"`
+		HospitalID uint                    `json:"hospital_id"`
+		Data       models.WebhookStaffData `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(request.body, &envelope))
+	suite.Equal(string(models.WebhookStaffCreated), envelope.Type)
+	suite.Equal(suite.hospitalID, envelope.HospitalID)
+	suite.Equal(staff.ID, envelope.Data.ID)
+	suite.Equal(staff.NationalID, envelope.Data.NationalID)
+
+	deliveries, err := suite.webhookService.GetDeliveries(&models.WebhookDeliveryFilterRequest{Status: models.WebhookDeliveryDelivered}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries.Data, 1)
+	suite.Equal(1, deliveries.Data[0].Attempts)
+}
+
+func (suite *WebhookServiceTestSuite) TestFailedDeliveriesRetryThenDeadLetterAndReplay() {
+	suite.subscribe()
+	suite.receiver.status = http.StatusInternalServerError
+
+	_, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+
+	_, err = suite.webhookService.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+
+	pending, err := suite.webhookService.GetDeliveries(&models.WebhookDeliveryFilterRequest{Status: models.WebhookDeliveryPending}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(pending.Data, 1)
+	delivery := pending.Data[0]
+	suite.Equal(1, delivery.Attempts)
+	suite.Equal(http.StatusInternalServerError, delivery.ResponseStatus)
+	suite.True(delivery.NextAttemptAt.After(time.Now().Add(50 * time.Second)))
+
+	// not due yet
+	sent, err := suite.webhookService.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(0, sent)
+
+	err = suite.containers.DB.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).
+		Update("next_attempt_at", time.Now().Add(-time.Second)).Error
+	suite.Require().NoError(err)
+	_, err = suite.webhookService.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+
+	dead, err := suite.webhookService.GetDeliveries(&models.WebhookDeliveryFilterRequest{Status: models.WebhookDeliveryDead}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(dead.Data, 1)
+	suite.Equal(2, dead.Data[0].Attempts)
+	suite.Len(suite.receiver.received, 2)
+
+	suite.receiver.status = http.StatusNoContent
+	replay, err := suite.webhookService.Replay(&models.WebhookReplayRequest{EventIDs: []uint{dead.Data[0].EventID}}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Equal(1, replay.Events)
+	suite.Equal(1, replay.Deliveries)
+
+	sent, err = suite.webhookService.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(1, sent)
+
+	dead, err = suite.webhookService.GetDeliveries(&models.WebhookDeliveryFilterRequest{Status: models.WebhookDeliveryDead}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Empty(dead.Data)
+	delivered, err := suite.webhookService.GetDeliveries(&models.WebhookDeliveryFilterRequest{Status: models.WebhookDeliveryDelivered}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Len(delivered.Data, 1)
+}
+
+func (suite *WebhookServiceTestSuite) TestEventIsNotWrittenWhenChangeRollsBack() {
+	suite.subscribe()
+
+	rollback := errors.New("rollback")
+	err := suite.containers.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := helpers.CreateTestStaff(tx, suite.hospitalID, nil); err != nil {
+			return err
+		}
+		return rollback
+	})
+	suite.Require().ErrorIs(err, rollback)
+
+	var count int64
+	suite.Require().NoError(suite.containers.DB.Model(&models.WebhookEvent{}).Count(&count).Error)
+	suite.Zero(count)
+	suite.Require().NoError(suite.containers.DB.Model(&models.WebhookDelivery{}).Count(&count).Error)
+	suite.Zero(count)
+}
+
+func (suite *WebhookServiceTestSuite) TestPrivateTargetsAreRefused() {
+	strict := services.NewWebhookService(suite.containers.DB, &config.Config{
+		Webhooks: config.WebhookConfig{MaxAttempts: 2, BackoffSeconds: 60, TimeoutSeconds: 5},
+	})
+	for _, target := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://10.0.0.5/hook", "http://169.254.169.254/latest", "http://[::1]/hook"} {
+		_, err := strict.CreateSubscription(&models.CreateWebhookRequest{URL: target}, suite.hospitalID)
+		suite.Error(err, target)
+	}
+
+	// a subscription stored earlier is still refused when it is dialled
+	suite.subscribe()
+	_, err := helpers.CreateTestStaff(suite.containers.DB, suite.hospitalID, nil)
+	suite.Require().NoError(err)
+	_, err = strict.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+
+	pending, err := strict.GetDeliveries(&models.WebhookDeliveryFilterRequest{Status: models.WebhookDeliveryPending}, suite.hospitalID)
+	suite.Require().NoError(err)
+	suite.Require().Len(pending.Data, 1)
+	suite.Contains(pending.Data[0].LastError, "private")
+	suite.Empty(suite.receiver.received)
+}
+
+func TestWebhookServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}